@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"futsal-booking-app/internal/authz"
+	"futsal-booking-app/internal/config"
+	deliveryhttp "futsal-booking-app/internal/delivery/http"
+	"futsal-booking-app/internal/notification"
+	"futsal-booking-app/internal/payment"
+	"futsal-booking-app/internal/repository"
+	"futsal-booking-app/internal/service"
+	"futsal-booking-app/internal/worker"
+	"futsal-booking-app/pkg/db"
+	"futsal-booking-app/pkg/token"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	// Database zona waktu IANA ikut di-embed supaya zona waktu venue tetap
+	// bisa dimuat di image tanpa tzdata.
+	_ "time/tzdata"
+)
+
+// schedulerLockKey adalah key advisory lock PostgreSQL untuk memilih satu
+// replica yang menjalankan job terjadwal.
+const schedulerLockKey int64 = 7301
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+
+	conn, err := db.MewPostgresDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("Error connecting to database: %v", err)
+	}
+	defer db.Close(conn)
+
+	earthdistance, err := repository.HasEarthdistance(context.Background(), conn)
+	if err != nil {
+		log.Fatalf("Error checking database extensions: %v", err)
+	}
+	if !earthdistance {
+		log.Println("Extension earthdistance is not installed, radius search falls back to haversine")
+	}
+
+	userRepo := repository.NewUserRepository(conn, cfg.Database.QueryTimeout)
+	venueRepo := repository.NewVenueRepository(conn, cfg.Database.QueryTimeout)
+	fieldRepo := repository.NewFieldRepository(conn, cfg.Database.QueryTimeout, earthdistance)
+	bookingRepo := repository.NewBookingRepository(conn, cfg.Database.QueryTimeout)
+	paymentRepo := repository.NewPaymentRepository(conn, cfg.Database.QueryTimeout)
+	sessionRepo := repository.NewSessionRepository(conn, cfg.Database.QueryTimeout)
+	refundRepo := repository.NewRefundRepository(conn, cfg.Database.QueryTimeout)
+	historyRepo := repository.NewBookingHistoryRepository(conn, cfg.Database.QueryTimeout)
+	jobRunRepo := repository.NewJobRunRepository(conn, cfg.Database.QueryTimeout)
+	seriesRepo := repository.NewBookingSeriesRepository(conn, cfg.Database.QueryTimeout)
+	waitlistRepo := repository.NewWaitlistRepository(conn, cfg.Database.QueryTimeout)
+	rescheduleRepo := repository.NewBookingRescheduleRepository(conn, cfg.Database.QueryTimeout)
+	adjustmentRepo := repository.NewPaymentAdjustmentRepository(conn, cfg.Database.QueryTimeout)
+	promoRepo := repository.NewPromoRepository(conn, cfg.Database.QueryTimeout)
+
+	tokenManager, err := token.NewManager(cfg.Auth.TokenSecret, cfg.Auth.TokenIssuer, cfg.Auth.AccessTokenTTL)
+	if err != nil {
+		log.Fatalf("Error creating token manager: %v", err)
+	}
+
+	gateway, err := payment.NewGateway(cfg.Payment)
+	if err != nil {
+		log.Fatalf("Error creating payment gateway: %v", err)
+	}
+
+	notifier, err := notification.NewNotifier(cfg.Notifier)
+	if err != nil {
+		log.Fatalf("Error creating notifier: %v", err)
+	}
+
+	authService := service.NewAuthService(userRepo, sessionRepo, tokenManager, cfg.Auth.RefreshTokenTTL)
+	uow := repository.NewUnitOfWork(db.NewTxManager(conn), cfg.Database.QueryTimeout, earthdistance)
+	policy := authz.NewPolicy()
+
+	venueService := service.NewVenueService(uow, venueRepo, fieldRepo, bookingRepo, policy)
+	fieldService := service.NewFieldService(uow, fieldRepo, venueRepo, bookingRepo, policy)
+	bookingService := service.NewBookingService(uow, bookingRepo, fieldRepo, paymentRepo, refundRepo, historyRepo, seriesRepo, rescheduleRepo, adjustmentRepo, gateway, policy)
+	waitlistService := service.NewWaitlistService(uow, waitlistRepo, fieldRepo, bookingRepo, userRepo, gateway, notifier, policy)
+	promoService := service.NewPromoService(promoRepo, fieldRepo, policy)
+	paymentService := service.NewPaymentService(uow, gateway)
+	jobService := service.NewJobService(jobRunRepo, policy)
+
+	handlers := deliveryhttp.Handlers{
+		Auth:     deliveryhttp.NewAuthHandler(authService),
+		Venue:    deliveryhttp.NewVenueHandler(venueService),
+		Field:    deliveryhttp.NewFieldHandler(fieldService, bookingService),
+		Booking:  deliveryhttp.NewBookingHandler(bookingService),
+		Series:   deliveryhttp.NewSeriesHandler(bookingService),
+		Waitlist: deliveryhttp.NewWaitlistHandler(waitlistService),
+		Promo:    deliveryhttp.NewPromoHandler(promoService),
+		Payment:  deliveryhttp.NewPaymentHandler(paymentService),
+		Job:      deliveryhttp.NewJobHandler(jobService),
+	}
+	middleware := deliveryhttp.NewMiddleware(authService)
+
+	server := &http.Server{
+		Addr:         ":" + cfg.Server.Port,
+		Handler:      deliveryhttp.NewRouter(handlers, middleware),
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+	}
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	scheduler := worker.NewScheduler(
+		db.NewAdvisoryLock(conn, schedulerLockKey),
+		jobRunRepo,
+		cfg.Worker.InstanceID,
+		cfg.Worker.SchedulerTick,
+		worker.NewExpirePendingBookingsJob(bookingService, cfg.Worker.BookingExpirySchedule, cfg.Worker.BookingExpiryBatchSize),
+		worker.NewCompleteEndedBookingsJob(bookingService, cfg.Worker.BookingCompletionSchedule, cfg.Worker.BookingCompletionBatchSize),
+		worker.NewExpireAdjustmentsJob(bookingService, cfg.Worker.AdjustmentExpirySchedule, cfg.Worker.AdjustmentExpiryBatchSize),
+		worker.NewRetryRefundsJob(bookingService, cfg.Worker.RefundRetrySchedule, cfg.Worker.RefundRetryBatchSize),
+		worker.NewProcessWaitlistJob(waitlistService, cfg.Worker.WaitlistSchedule, cfg.Worker.WaitlistBatchSize),
+	)
+	go scheduler.Run(workerCtx)
+
+	go func() {
+		log.Printf("Server listening on %s", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Error starting server: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down server...")
+	stopWorkers()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Error shutting down server: %v", err)
+	}
+
+	// Koneksi database baru ditutup setelah scheduler selesai mencatat job
+	// terakhir dan melepas advisory lock.
+	select {
+	case <-scheduler.Done():
+	case <-ctx.Done():
+		log.Printf("Scheduler did not stop within %s", cfg.Server.ShutdownTimeout)
+	}
+}
@@ -3,8 +3,8 @@ module futsal-booking-app
 go 1.25.1
 
 require (
-	github.com/joho/godotenv v1.5.1 // indirect
-	github.com/julienschmidt/httprouter v1.3.0 // indirect
-	github.com/lib/pq v1.10.9 // indirect
-	golang.org/x/crypto v0.43.0 // indirect
+	github.com/joho/godotenv v1.5.1
+	github.com/julienschmidt/httprouter v1.3.0
+	github.com/lib/pq v1.10.9
+	golang.org/x/crypto v0.43.0
 )
@@ -0,0 +1,148 @@
+package authz
+
+import (
+	"fmt"
+	"futsal-booking-app/internal/domain"
+)
+
+type Action string
+
+const (
+	ActionVenueCreate         Action = "venue:create"
+	ActionVenueUpdate         Action = "venue:update"
+	ActionVenueDelete         Action = "venue:delete"
+	ActionVenueManageSchedule Action = "venue:manage_schedule"
+
+	ActionFieldCreate         Action = "field:create"
+	ActionFieldUpdate         Action = "field:update"
+	ActionFieldDelete         Action = "field:delete"
+	ActionFieldManageSchedule Action = "field:manage_schedule"
+	ActionFieldManagePricing  Action = "field:manage_pricing"
+	ActionFieldViewBookings   Action = "field:view_bookings"
+
+	ActionBookingCreate     Action = "booking:create"
+	ActionBookingView       Action = "booking:view"
+	ActionBookingCancel     Action = "booking:cancel"
+	ActionBookingConfirm    Action = "booking:confirm"
+	ActionBookingComplete   Action = "booking:complete"
+	ActionBookingNoShow     Action = "booking:no_show"
+	ActionBookingReschedule Action = "booking:reschedule"
+
+	ActionSeriesView   Action = "series:view"
+	ActionSeriesCancel Action = "series:cancel"
+
+	ActionWaitlistJoin   Action = "waitlist:join"
+	ActionWaitlistManage Action = "waitlist:manage"
+
+	ActionPromoCreate Action = "promo:create"
+	ActionPromoManage Action = "promo:manage"
+
+	// ActionJobView sengaja tidak punya rule: hanya admin yang boleh melihat riwayat job.
+	ActionJobView Action = "job:view"
+)
+
+// Resource adalah objek yang sedang diakses. Untuk aksi pada booking atau
+// series, Field diisi dengan lapangan milik booking tersebut supaya aturan
+// "owner lapangan" bisa dievaluasi. Untuk membuat court, Venue diisi dengan
+// venue tujuan.
+type Resource struct {
+	Venue    *domain.Venue
+	Field    *domain.Field
+	Booking  *domain.Booking
+	Series   *domain.BookingSeries
+	Waitlist *domain.WaitlistEntry
+	Promo    *domain.PromoCode
+}
+
+// Rule mengembalikan true jika actor boleh melakukan aksi pada resource.
+type Rule func(actor *domain.User, res Resource) bool
+
+// Policy memetakan setiap aksi ke daftar rule. Aksi diizinkan jika salah
+// satu rule terpenuhi. Admin selalu diizinkan. Aksi yang tidak terdaftar
+// selalu ditolak.
+type Policy struct {
+	rules map[Action][]Rule
+}
+
+func NewPolicy() *Policy {
+	return &Policy{rules: map[Action][]Rule{
+		ActionVenueCreate:         {HasRole(domain.RoleOwner)},
+		ActionVenueUpdate:         {IsVenueOwner},
+		ActionVenueDelete:         {IsVenueOwner},
+		ActionVenueManageSchedule: {IsVenueOwner},
+
+		ActionFieldCreate:         {IsVenueOwner},
+		ActionFieldUpdate:         {IsFieldOwner},
+		ActionFieldDelete:         {IsFieldOwner},
+		ActionFieldManageSchedule: {IsFieldOwner},
+		ActionFieldManagePricing:  {IsFieldOwner},
+		ActionFieldViewBookings:   {IsFieldOwner},
+
+		ActionBookingCreate:     {HasRole(domain.RoleCustomer)},
+		ActionBookingView:       {IsBookingCustomer, IsFieldOwner},
+		ActionBookingCancel:     {IsBookingCustomer},
+		ActionBookingConfirm:    {IsFieldOwner},
+		ActionBookingComplete:   {IsFieldOwner},
+		ActionBookingNoShow:     {IsFieldOwner},
+		ActionBookingReschedule: {IsBookingCustomer},
+
+		ActionSeriesView:   {IsSeriesCustomer, IsFieldOwner},
+		ActionSeriesCancel: {IsSeriesCustomer},
+
+		ActionWaitlistJoin:   {HasRole(domain.RoleCustomer)},
+		ActionWaitlistManage: {IsWaitlistCustomer},
+
+		ActionPromoCreate: {HasRole(domain.RoleOwner)},
+		ActionPromoManage: {IsPromoCreator},
+	}}
+}
+
+// Authorize mengembalikan error yang membungkus domain.ErrForbidden
+// jika actor tidak boleh melakukan aksi pada resource.
+func (p *Policy) Authorize(actor *domain.User, action Action, res Resource) error {
+	if actor == nil {
+		return fmt.Errorf("%w: %s", domain.ErrForbidden, action)
+	}
+
+	if actor.IsAdmin() {
+		return nil
+	}
+
+	for _, rule := range p.rules[action] {
+		if rule(actor, res) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", domain.ErrForbidden, action)
+}
+
+func HasRole(role domain.Role) Rule {
+	return func(actor *domain.User, _ Resource) bool {
+		return actor.Role == role
+	}
+}
+
+func IsVenueOwner(actor *domain.User, res Resource) bool {
+	return res.Venue != nil && actor.IsOwner() && res.Venue.IsOwnedBy(actor.ID)
+}
+
+func IsFieldOwner(actor *domain.User, res Resource) bool {
+	return res.Field != nil && actor.IsOwner() && res.Field.IsOwnedBy(actor.ID)
+}
+
+func IsBookingCustomer(actor *domain.User, res Resource) bool {
+	return res.Booking != nil && res.Booking.UserID == actor.ID
+}
+
+func IsSeriesCustomer(actor *domain.User, res Resource) bool {
+	return res.Series != nil && res.Series.UserID == actor.ID
+}
+
+func IsWaitlistCustomer(actor *domain.User, res Resource) bool {
+	return res.Waitlist != nil && res.Waitlist.UserID == actor.ID
+}
+
+func IsPromoCreator(actor *domain.User, res Resource) bool {
+	return res.Promo != nil && res.Promo.IsCreatedBy(actor.ID)
+}
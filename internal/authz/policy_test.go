@@ -0,0 +1,93 @@
+package authz
+
+import (
+	"errors"
+	"futsal-booking-app/internal/domain"
+	"testing"
+)
+
+func TestPolicyAuthorize(t *testing.T) {
+	owner := &domain.User{ID: 1, Role: domain.RoleOwner}
+	otherOwner := &domain.User{ID: 2, Role: domain.RoleOwner}
+	customer := &domain.User{ID: 3, Role: domain.RoleCustomer}
+	otherCustomer := &domain.User{ID: 4, Role: domain.RoleCustomer}
+	admin := &domain.User{ID: 5, Role: domain.RoleAdmin}
+
+	venue := &domain.Venue{ID: 5, OwnerID: owner.ID}
+	field := &domain.Field{ID: 10, VenueID: venue.ID, OwnerID: owner.ID}
+	booking := &domain.Booking{ID: 20, FieldID: field.ID, UserID: customer.ID}
+	bookingRes := Resource{Field: field, Booking: booking}
+	seriesRes := Resource{Field: field, Series: &domain.BookingSeries{ID: 30, FieldID: field.ID, UserID: customer.ID}}
+	promoRes := Resource{Promo: &domain.PromoCode{ID: 50, CreatedBy: owner.ID}}
+	waitlistRes := Resource{Field: field, Waitlist: &domain.WaitlistEntry{ID: 40, FieldID: field.ID, UserID: customer.ID}}
+
+	tests := []struct {
+		name    string
+		actor   *domain.User
+		action  Action
+		res     Resource
+		allowed bool
+	}{
+		{"owner creates venue", owner, ActionVenueCreate, Resource{}, true},
+		{"customer cannot create venue", customer, ActionVenueCreate, Resource{}, false},
+		{"owner updates own venue", owner, ActionVenueUpdate, Resource{Venue: venue}, true},
+		{"other owner cannot manage venue schedule", otherOwner, ActionVenueManageSchedule, Resource{Venue: venue}, false},
+		{"owner creates court in own venue", owner, ActionFieldCreate, Resource{Venue: venue}, true},
+		{"other owner cannot create court in venue", otherOwner, ActionFieldCreate, Resource{Venue: venue}, false},
+		{"customer cannot create court", customer, ActionFieldCreate, Resource{Venue: venue}, false},
+		{"owner updates own field", owner, ActionFieldUpdate, Resource{Field: field}, true},
+		{"owner cannot update other owner's field", otherOwner, ActionFieldUpdate, Resource{Field: field}, false},
+		{"field rule without field is denied", owner, ActionFieldDelete, Resource{}, false},
+		{"owner manages own field pricing", owner, ActionFieldManagePricing, Resource{Field: field}, true},
+		{"other owner cannot manage field pricing", otherOwner, ActionFieldManagePricing, Resource{Field: field}, false},
+		{"customer creates booking", customer, ActionBookingCreate, Resource{Field: field}, true},
+		{"owner cannot create booking", owner, ActionBookingCreate, Resource{Field: field}, false},
+		{"customer views own booking", customer, ActionBookingView, bookingRes, true},
+		{"field owner views booking", owner, ActionBookingView, bookingRes, true},
+		{"other customer cannot view booking", otherCustomer, ActionBookingView, bookingRes, false},
+		{"customer cancels own booking", customer, ActionBookingCancel, bookingRes, true},
+		{"field owner cannot cancel booking", owner, ActionBookingCancel, bookingRes, false},
+		{"field owner confirms booking", owner, ActionBookingConfirm, bookingRes, true},
+		{"customer cannot confirm booking", customer, ActionBookingConfirm, bookingRes, false},
+		{"field owner marks no-show", owner, ActionBookingNoShow, bookingRes, true},
+		{"customer cannot mark own booking as no-show", customer, ActionBookingNoShow, bookingRes, false},
+		{"customer reschedules own booking", customer, ActionBookingReschedule, bookingRes, true},
+		{"field owner cannot reschedule booking", owner, ActionBookingReschedule, bookingRes, false},
+		{"other customer cannot reschedule booking", otherCustomer, ActionBookingReschedule, bookingRes, false},
+		{"customer views own series", customer, ActionSeriesView, seriesRes, true},
+		{"field owner views series", owner, ActionSeriesView, seriesRes, true},
+		{"other customer cannot view series", otherCustomer, ActionSeriesView, seriesRes, false},
+		{"customer cancels own series", customer, ActionSeriesCancel, seriesRes, true},
+		{"field owner cannot cancel series", owner, ActionSeriesCancel, seriesRes, false},
+		{"owner creates promo", owner, ActionPromoCreate, Resource{}, true},
+		{"customer cannot create promo", customer, ActionPromoCreate, Resource{}, false},
+		{"owner manages own promo", owner, ActionPromoManage, promoRes, true},
+		{"other owner cannot manage promo", otherOwner, ActionPromoManage, promoRes, false},
+		{"customer joins waitlist", customer, ActionWaitlistJoin, Resource{Field: field}, true},
+		{"owner cannot join waitlist", owner, ActionWaitlistJoin, Resource{Field: field}, false},
+		{"customer manages own waitlist entry", customer, ActionWaitlistManage, waitlistRes, true},
+		{"other customer cannot manage waitlist entry", otherCustomer, ActionWaitlistManage, waitlistRes, false},
+		{"owner cannot view job runs", owner, ActionJobView, Resource{}, false},
+		{"admin views job runs", admin, ActionJobView, Resource{}, true},
+		{"admin is always allowed", admin, ActionBookingComplete, bookingRes, true},
+		{"nil actor is denied", nil, ActionBookingView, bookingRes, false},
+		{"unknown action is denied", owner, Action("field:unknown"), Resource{Field: field}, false},
+	}
+
+	policy := NewPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Authorize(tt.actor, tt.action, tt.res)
+
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("Authorize() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, domain.ErrForbidden) {
+				t.Fatalf("Authorize() error = %v, want ErrForbidden", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"fmt"
+	"futsal-booking-app/internal/notification"
+	"futsal-booking-app/internal/payment"
+	"futsal-booking-app/pkg/cron"
+	"futsal-booking-app/pkg/db"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+type Config struct {
+	Server   ServerConfig
+	Database db.Config
+	Auth     AuthConfig
+	Worker   WorkerConfig
+	Payment  payment.Config
+	Notifier notification.Config
+}
+
+type ServerConfig struct {
+	Port            string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	ShutdownTimeout time.Duration
+}
+
+type AuthConfig struct {
+	TokenSecret     string
+	TokenIssuer     string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+type WorkerConfig struct {
+	// InstanceID menandai replica yang menjalankan job di riwayat job_runs.
+	InstanceID    string
+	SchedulerTick time.Duration
+
+	// Jadwal job diisi dari *_SCHEDULE, berupa durasi ("1m") atau ekspresi
+	// cron ("*/5 * * * *"), lihat cron.Parse.
+	BookingExpirySchedule  cron.Schedule
+	BookingExpiryBatchSize int
+
+	BookingCompletionSchedule  cron.Schedule
+	BookingCompletionBatchSize int
+
+	WaitlistSchedule  cron.Schedule
+	WaitlistBatchSize int
+
+	AdjustmentExpirySchedule  cron.Schedule
+	AdjustmentExpiryBatchSize int
+
+	RefundRetrySchedule  cron.Schedule
+	RefundRetryBatchSize int
+}
+
+// Load membaca konfigurasi dari environment variable.
+// File .env (jika ada) dimuat terlebih dahulu, tapi environment variable
+// yang sudah di-set tetap diutamakan.
+func Load() (*Config, error) {
+	_ = godotenv.Load()
+
+	cfg := &Config{
+		Server: ServerConfig{
+			Port:            getEnv("SERVER_PORT", "8080"),
+			ReadTimeout:     getDuration("SERVER_READ_TIMEOUT", 10*time.Second),
+			WriteTimeout:    getDuration("SERVER_WRITE_TIMEOUT", 10*time.Second),
+			ShutdownTimeout: getDuration("SERVER_SHUTDOWN_TIMEOUT", 15*time.Second),
+		},
+		Database: db.Config{
+			Host:     getEnv("DB_HOST", "localhost"),
+			Port:     getEnv("DB_PORT", "5432"),
+			User:     getEnv("DB_USER", "postgres"),
+			Password: getEnv("DB_PASSWORD", ""),
+			DBName:   getEnv("DB_NAME", "futsal_booking"),
+			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			QueryTimeout: getDuration("DB_QUERY_TIMEOUT", 5*time.Second),
+		},
+		Auth: AuthConfig{
+			TokenSecret:     getEnv("AUTH_TOKEN_SECRET", ""),
+			TokenIssuer:     getEnv("AUTH_TOKEN_ISSUER", "futsal-booking-app"),
+			AccessTokenTTL:  getDuration("AUTH_ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL: getDuration("AUTH_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		},
+		Worker: WorkerConfig{
+			InstanceID:    getEnv("SCHEDULER_INSTANCE_ID", defaultInstanceID()),
+			SchedulerTick: getDuration("SCHEDULER_TICK", 10*time.Second),
+
+			BookingExpiryBatchSize: getInt("BOOKING_EXPIRY_BATCH_SIZE", 100),
+
+			BookingCompletionBatchSize: getInt("BOOKING_COMPLETION_BATCH_SIZE", 100),
+
+			WaitlistBatchSize: getInt("WAITLIST_BATCH_SIZE", 100),
+
+			AdjustmentExpiryBatchSize: getInt("ADJUSTMENT_EXPIRY_BATCH_SIZE", 100),
+
+			RefundRetryBatchSize: getInt("REFUND_RETRY_BATCH_SIZE", 100),
+		},
+		Payment: payment.Config{
+			Provider:           getEnv("PAYMENT_GATEWAY", ""),
+			MidtransServerKey:  getEnv("MIDTRANS_SERVER_KEY", ""),
+			MidtransProduction: getBool("MIDTRANS_PRODUCTION", false),
+			FakeSecret:         getEnv("PAYMENT_FAKE_SECRET", ""),
+		},
+		Notifier: notification.Config{
+			Driver:         getEnv("NOTIFICATION_DRIVER", "log"),
+			WebhookURL:     getEnv("NOTIFICATION_WEBHOOK_URL", ""),
+			WebhookTimeout: getDuration("NOTIFICATION_WEBHOOK_TIMEOUT", 5*time.Second),
+		},
+	}
+
+	if cfg.Server.Port == "" {
+		return nil, fmt.Errorf("SERVER_PORT cannot be empty")
+	}
+
+	if cfg.Auth.TokenSecret == "" {
+		return nil, fmt.Errorf("AUTH_TOKEN_SECRET is required")
+	}
+
+	if cfg.Payment.Provider == "" {
+		return nil, fmt.Errorf("PAYMENT_GATEWAY is required")
+	}
+
+	if cfg.Worker.BookingExpiryBatchSize <= 0 {
+		return nil, fmt.Errorf("BOOKING_EXPIRY_BATCH_SIZE must be positive")
+	}
+
+	if cfg.Worker.BookingCompletionBatchSize <= 0 {
+		return nil, fmt.Errorf("BOOKING_COMPLETION_BATCH_SIZE must be positive")
+	}
+
+	if cfg.Worker.WaitlistBatchSize <= 0 {
+		return nil, fmt.Errorf("WAITLIST_BATCH_SIZE must be positive")
+	}
+
+	if cfg.Worker.AdjustmentExpiryBatchSize <= 0 {
+		return nil, fmt.Errorf("ADJUSTMENT_EXPIRY_BATCH_SIZE must be positive")
+	}
+
+	if cfg.Worker.RefundRetryBatchSize <= 0 {
+		return nil, fmt.Errorf("REFUND_RETRY_BATCH_SIZE must be positive")
+	}
+
+	schedules := []struct {
+		key      string
+		fallback string
+		target   *cron.Schedule
+	}{
+		{"BOOKING_EXPIRY_SCHEDULE", "1m", &cfg.Worker.BookingExpirySchedule},
+		{"BOOKING_COMPLETION_SCHEDULE", "5m", &cfg.Worker.BookingCompletionSchedule},
+		{"WAITLIST_SCHEDULE", "30s", &cfg.Worker.WaitlistSchedule},
+		{"ADJUSTMENT_EXPIRY_SCHEDULE", "1m", &cfg.Worker.AdjustmentExpirySchedule},
+		{"REFUND_RETRY_SCHEDULE", "5m", &cfg.Worker.RefundRetrySchedule},
+	}
+
+	for _, entry := range schedules {
+		schedule, err := cron.Parse(getEnv(entry.key, entry.fallback))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", entry.key, err)
+		}
+		*entry.target = schedule
+	}
+
+	if cfg.Worker.SchedulerTick <= 0 {
+		return nil, fmt.Errorf("SCHEDULER_TICK must be positive")
+	}
+
+	return cfg, nil
+}
+
+func defaultInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+func getInt(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fallback
+	}
+
+	return parsed
+}
+
+func getBool(key string, fallback bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback
+	}
+
+	return parsed
+}
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fallback
+	}
+
+	return parsed
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/service"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type AuthHandler struct {
+	authService service.AuthService
+}
+
+func NewAuthHandler(authService service.AuthService) *AuthHandler {
+	return &AuthHandler{authService: authService}
+}
+
+type registerRequest struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+func (req *registerRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if strings.TrimSpace(req.Name) == "" {
+		errs["name"] = "is required"
+	}
+
+	if strings.TrimSpace(req.Email) == "" {
+		errs["email"] = "is required"
+	}
+
+	if req.Password == "" {
+		errs["password"] = "is required"
+	}
+
+	if req.Role == "" {
+		req.Role = string(domain.RoleCustomer)
+	}
+
+	return errs
+}
+
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (req *loginRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if strings.TrimSpace(req.Email) == "" {
+		errs["email"] = "is required"
+	}
+
+	if req.Password == "" {
+		errs["password"] = "is required"
+	}
+
+	return errs
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (req *refreshRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		errs["refresh_token"] = "is required"
+	}
+
+	return errs
+}
+
+// Register handles POST /api/auth/register
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req registerRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	user, err := h.authService.RegisterUser(r.Context(), req.Name, req.Email, req.Password, domain.Role(strings.ToUpper(req.Role)))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, newUserResponse(user))
+}
+
+// Login handles POST /api/auth/login
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req loginRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	user, tokens, err := h.authService.LoginUser(r.Context(), req.Email, req.Password)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, loginResponse{
+		User:   newUserResponse(user),
+		Tokens: newTokenResponse(tokens),
+	})
+}
+
+// Refresh handles POST /api/auth/refresh
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req refreshRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	tokens, err := h.authService.RefreshTokens(r.Context(), req.RefreshToken)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newTokenResponse(tokens))
+}
+
+// Logout handles POST /api/auth/logout
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if err := h.authService.Logout(r.Context(), currentClaims(r).SessionID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, nil)
+}
+
+// LogoutAll handles POST /api/auth/logout-all
+func (h *AuthHandler) LogoutAll(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if err := h.authService.RevokeAllSessions(r.Context(), currentUser(r).ID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, nil)
+}
+
+// Me handles GET /api/auth/me
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeSuccess(w, http.StatusOK, newUserResponse(currentUser(r)))
+}
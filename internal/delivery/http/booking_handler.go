@@ -0,0 +1,290 @@
+package http
+
+import (
+	"futsal-booking-app/internal/service"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type BookingHandler struct {
+	bookingService service.BookingService
+}
+
+func NewBookingHandler(bookingService service.BookingService) *BookingHandler {
+	return &BookingHandler{bookingService: bookingService}
+}
+
+// bookingDuration adalah durasi booking pada request. duration_minutes adalah
+// field utama; duration_hours masih diterima untuk client lama.
+type bookingDuration struct {
+	DurationMinutes int `json:"duration_minutes"`
+	DurationHours   int `json:"duration_hours"`
+}
+
+func (d bookingDuration) minutes() int {
+	if d.DurationMinutes != 0 {
+		return d.DurationMinutes
+	}
+	return d.DurationHours * 60
+}
+
+type createBookingRequest struct {
+	FieldID   int       `json:"field_id"`
+	StartTime time.Time `json:"start_time"`
+	PromoCode string    `json:"promo_code"`
+	bookingDuration
+}
+
+func (req *createBookingRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if req.FieldID <= 0 {
+		errs["field_id"] = "is required"
+	}
+
+	if req.StartTime.IsZero() {
+		errs["start_time"] = "is required (RFC3339)"
+	}
+
+	if req.minutes() <= 0 {
+		errs["duration_minutes"] = "must be positive"
+	}
+
+	return errs
+}
+
+type rescheduleBookingRequest struct {
+	StartTime time.Time `json:"start_time"`
+	bookingDuration
+}
+
+func (req *rescheduleBookingRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if req.StartTime.IsZero() {
+		errs["start_time"] = "is required (RFC3339)"
+	}
+
+	if req.minutes() < 0 {
+		errs["duration_minutes"] = "cannot be negative"
+	}
+
+	return errs
+}
+
+// Create handles POST /api/bookings
+func (h *BookingHandler) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req createBookingRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	booking, err := h.bookingService.CreateBooking(r.Context(), currentUser(r), req.FieldID, req.StartTime, req.minutes(), req.PromoCode)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, newBookingResponse(booking))
+}
+
+// ListMine handles GET /api/bookings
+func (h *BookingHandler) ListMine(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	bookings, err := h.bookingService.GetMyBookings(r.Context(), currentUser(r).ID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newBookingResponses(bookings))
+}
+
+// Get handles GET /api/bookings/:id
+func (h *BookingHandler) Get(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	bookingID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	booking, err := h.bookingService.GetBookingByID(r.Context(), currentUser(r), bookingID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newBookingResponse(booking))
+}
+
+// Payment handles GET /api/bookings/:id/payment
+func (h *BookingHandler) Payment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	bookingID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	payment, err := h.bookingService.GetBookingPayment(r.Context(), currentUser(r), bookingID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newPaymentResponse(payment))
+}
+
+// Refunds handles GET /api/bookings/:id/refunds
+func (h *BookingHandler) Refunds(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	bookingID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	refunds, err := h.bookingService.GetBookingRefunds(r.Context(), currentUser(r), bookingID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newRefundResponses(refunds))
+}
+
+// Cancel handles POST /api/bookings/:id/cancel
+func (h *BookingHandler) Cancel(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	bookingID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.bookingService.CancelBooking(r.Context(), currentUser(r), bookingID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	h.respondWithBooking(w, r, bookingID)
+}
+
+// Reschedule handles POST /api/bookings/:id/reschedule
+// duration_minutes boleh dikosongkan untuk mempertahankan durasi booking.
+func (h *BookingHandler) Reschedule(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	bookingID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	var req rescheduleBookingRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	result, err := h.bookingService.RescheduleBooking(r.Context(), currentUser(r), bookingID, req.StartTime, req.minutes())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newRescheduleResultResponse(result))
+}
+
+// Reschedules handles GET /api/bookings/:id/reschedules
+func (h *BookingHandler) Reschedules(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	bookingID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	reschedules, err := h.bookingService.GetBookingReschedules(r.Context(), currentUser(r), bookingID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newRescheduleResponses(reschedules))
+}
+
+// Adjustments handles GET /api/bookings/:id/adjustments
+func (h *BookingHandler) Adjustments(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	bookingID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	adjustments, err := h.bookingService.GetBookingAdjustments(r.Context(), currentUser(r), bookingID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newAdjustmentResponses(adjustments))
+}
+
+// Confirm handles POST /api/bookings/:id/confirm
+func (h *BookingHandler) Confirm(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	bookingID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.bookingService.ConfirmBooking(r.Context(), currentUser(r), bookingID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	h.respondWithBooking(w, r, bookingID)
+}
+
+// Complete handles POST /api/bookings/:id/complete
+func (h *BookingHandler) Complete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	bookingID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.bookingService.CompleteBooking(r.Context(), currentUser(r), bookingID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	h.respondWithBooking(w, r, bookingID)
+}
+
+// NoShow handles POST /api/bookings/:id/no-show
+func (h *BookingHandler) NoShow(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	bookingID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.bookingService.MarkNoShow(r.Context(), currentUser(r), bookingID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	h.respondWithBooking(w, r, bookingID)
+}
+
+// History handles GET /api/bookings/:id/history
+func (h *BookingHandler) History(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	bookingID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	changes, err := h.bookingService.GetBookingHistory(r.Context(), currentUser(r), bookingID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newBookingStatusChangeResponses(changes))
+}
+
+func (h *BookingHandler) respondWithBooking(w http.ResponseWriter, r *http.Request, bookingID int) {
+	booking, err := h.bookingService.GetBookingByID(r.Context(), currentUser(r), bookingID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newBookingResponse(booking))
+}
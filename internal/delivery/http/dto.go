@@ -0,0 +1,855 @@
+package http
+
+import (
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/service"
+	"time"
+)
+
+// Response DTO memisahkan bentuk JSON API dari struct domain,
+// sehingga data sensitif seperti PasswordHash tidak pernah ikut terkirim.
+
+type userResponse struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newUserResponse(u *domain.User) userResponse {
+	return userResponse{
+		ID:        u.ID,
+		Name:      u.Name,
+		Email:     u.Email,
+		Role:      string(u.Role),
+		CreatedAt: u.CreatedAt,
+	}
+}
+
+type tokenResponse struct {
+	TokenType             string    `json:"token_type"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+func newTokenResponse(t *service.AuthTokens) tokenResponse {
+	return tokenResponse{
+		TokenType:             "Bearer",
+		AccessToken:           t.AccessToken,
+		AccessTokenExpiresAt:  t.AccessTokenExpiresAt,
+		RefreshToken:          t.RefreshToken,
+		RefreshTokenExpiresAt: t.RefreshTokenExpiresAt,
+	}
+}
+
+type loginResponse struct {
+	User   userResponse  `json:"user"`
+	Tokens tokenResponse `json:"tokens"`
+}
+
+type fieldResponse struct {
+	ID                 int                    `json:"id"`
+	VenueID            int                    `json:"venue_id"`
+	OwnerID            int                    `json:"owner_id"`
+	Name               string                 `json:"name"`
+	Address            string                 `json:"address"`
+	Description        string                 `json:"description"`
+	PricePerHour       int                    `json:"price_per_hour"`
+	SurfaceType        string                 `json:"surface_type"`
+	Indoor             bool                   `json:"indoor"`
+	ImageURL           string                 `json:"image_url"`
+	TimeZone           string                 `json:"time_zone"`
+	Amenities          []string               `json:"amenities"`
+	Latitude           *float64               `json:"latitude"`
+	Longitude          *float64               `json:"longitude"`
+	PaymentHoldMinutes int                    `json:"payment_hold_minutes"`
+	CancellationPolicy cancellationPolicyItem `json:"cancellation_policy"`
+	WaitlistPolicy     waitlistPolicyItem     `json:"waitlist_policy"`
+	ReschedulePolicy   reschedulePolicyItem   `json:"reschedule_policy"`
+	SlotPolicy         slotPolicyItem         `json:"slot_policy"`
+	CreatedAt          time.Time              `json:"created_at"`
+}
+
+func newFieldResponse(f *domain.Field) fieldResponse {
+	res := fieldResponse{
+		ID:                 f.ID,
+		VenueID:            f.VenueID,
+		OwnerID:            f.OwnerID,
+		Name:               f.Name,
+		Address:            f.Address,
+		Description:        f.Description,
+		PricePerHour:       f.PricePerHour,
+		SurfaceType:        string(f.SurfaceType),
+		Indoor:             f.Indoor,
+		ImageURL:           f.ImageURL,
+		TimeZone:           f.TimeZone,
+		Amenities:          f.Amenities,
+		PaymentHoldMinutes: f.PaymentHoldMinutes,
+		CancellationPolicy: cancellationPolicyItem{
+			FullRefundHours:      f.CancellationPolicy.FullRefundHours,
+			PartialRefundPercent: f.CancellationPolicy.PartialRefundPercent,
+		},
+		WaitlistPolicy: waitlistPolicyItem{
+			Order:          string(f.WaitlistPolicy.Order),
+			OfferMinutes:   f.WaitlistPolicy.OfferMinutes,
+			NotifyCustomer: f.WaitlistPolicy.NotifyCustomer,
+			NotifyOwner:    f.WaitlistPolicy.NotifyOwner,
+		},
+		ReschedulePolicy: reschedulePolicyItem{
+			MinHoursBefore: f.ReschedulePolicy.MinHoursBefore,
+			MaxReschedules: f.ReschedulePolicy.MaxReschedules,
+		},
+		SlotPolicy: slotPolicyItem{
+			SlotMinutes:        f.SlotPolicy.SlotMinutes,
+			MinDurationMinutes: f.SlotPolicy.MinDurationMinutes,
+			MaxDurationMinutes: f.SlotPolicy.MaxDurationMinutes,
+			BufferMinutes:      f.SlotPolicy.BufferMinutes,
+		},
+		CreatedAt: f.CreatedAt,
+	}
+
+	if f.Coordinates != nil {
+		res.Latitude = &f.Coordinates.Latitude
+		res.Longitude = &f.Coordinates.Longitude
+	}
+
+	return res
+}
+
+func newFieldResponses(fields []*domain.Field) []fieldResponse {
+	res := make([]fieldResponse, 0, len(fields))
+	for _, f := range fields {
+		res = append(res, newFieldResponse(f))
+	}
+	return res
+}
+
+type venueResponse struct {
+	ID          int       `json:"id"`
+	OwnerID     int       `json:"owner_id"`
+	Name        string    `json:"name"`
+	Address     string    `json:"address"`
+	Description string    `json:"description"`
+	ImageURL    string    `json:"image_url"`
+	Amenities   []string  `json:"amenities"`
+	Latitude    *float64  `json:"latitude"`
+	Longitude   *float64  `json:"longitude"`
+	TimeZone    string    `json:"time_zone"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func newVenueResponse(v *domain.Venue) venueResponse {
+	res := venueResponse{
+		ID:          v.ID,
+		OwnerID:     v.OwnerID,
+		Name:        v.Name,
+		Address:     v.Address,
+		Description: v.Description,
+		ImageURL:    v.ImageURL,
+		Amenities:   v.Amenities,
+		TimeZone:    v.TimeZone,
+		CreatedAt:   v.CreatedAt,
+	}
+
+	if v.Coordinates != nil {
+		res.Latitude = &v.Coordinates.Latitude
+		res.Longitude = &v.Coordinates.Longitude
+	}
+
+	return res
+}
+
+func newVenueResponses(venues []*domain.Venue) []venueResponse {
+	res := make([]venueResponse, 0, len(venues))
+	for _, v := range venues {
+		res = append(res, newVenueResponse(v))
+	}
+	return res
+}
+
+type venueDetailResponse struct {
+	venueResponse
+	Courts []fieldResponse `json:"courts"`
+}
+
+type courtAvailabilityResponse struct {
+	CourtID     int    `json:"court_id"`
+	Name        string `json:"name"`
+	SurfaceType string `json:"surface_type"`
+	Indoor      bool   `json:"indoor"`
+	Available   bool   `json:"available"`
+	Price       int    `json:"price,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+func newCourtAvailabilityResponses(courts []service.CourtAvailability) []courtAvailabilityResponse {
+	res := make([]courtAvailabilityResponse, 0, len(courts))
+	for _, c := range courts {
+		res = append(res, courtAvailabilityResponse{
+			CourtID:     c.Field.ID,
+			Name:        c.Field.Name,
+			SurfaceType: string(c.Field.SurfaceType),
+			Indoor:      c.Field.Indoor,
+			Available:   c.Available,
+			Price:       c.Price,
+			Reason:      c.Reason,
+		})
+	}
+	return res
+}
+
+type scheduleResponse struct {
+	ID        int    `json:"id"`
+	FieldID   int    `json:"field_id,omitempty"`
+	DayOfWeek int    `json:"day_of_week"`
+	DayName   string `json:"day_name"`
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+	Overnight bool   `json:"overnight"`
+}
+
+func newScheduleResponses(schedules []*domain.Schedule) []scheduleResponse {
+	res := make([]scheduleResponse, 0, len(schedules))
+	for _, s := range schedules {
+		res = append(res, scheduleResponse{
+			ID:        s.ID,
+			FieldID:   s.FieldID,
+			DayOfWeek: int(s.DayOfWeek),
+			DayName:   s.GetDayName(),
+			OpenTime:  s.OpenTime.Format("15:04"),
+			CloseTime: s.CloseTime.Format("15:04"),
+			Overnight: s.IsOvernight(),
+		})
+	}
+	return res
+}
+
+type slotResponse struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Available bool      `json:"available"`
+	Price     int       `json:"price"`
+}
+
+func newSlotResponses(slots []domain.TimeSlot) []slotResponse {
+	res := make([]slotResponse, 0, len(slots))
+	for _, s := range slots {
+		res = append(res, slotResponse{
+			StartTime: s.StartTime,
+			EndTime:   s.EndTime,
+			Available: s.Available,
+			Price:     s.Price,
+		})
+	}
+	return res
+}
+
+// fieldCalendarResponse adalah satu baris grid kalender ketersediaan.
+type fieldCalendarResponse struct {
+	FieldID   int                   `json:"field_id"`
+	FieldName string                `json:"field_name"`
+	VenueID   int                   `json:"venue_id"`
+	TimeZone  string                `json:"time_zone"`
+	Days      []calendarDayResponse `json:"days"`
+}
+
+type calendarDayResponse struct {
+	Date  string         `json:"date"`
+	Slots []slotResponse `json:"slots"`
+}
+
+func newCalendarResponses(rows []service.FieldAvailability) []fieldCalendarResponse {
+	res := make([]fieldCalendarResponse, 0, len(rows))
+	for _, row := range rows {
+		days := make([]calendarDayResponse, 0, len(row.Days))
+		for _, day := range row.Days {
+			days = append(days, calendarDayResponse{
+				Date:  day.Date.Format("2006-01-02"),
+				Slots: newSlotResponses(day.Slots),
+			})
+		}
+
+		res = append(res, fieldCalendarResponse{
+			FieldID:   row.Field.ID,
+			FieldName: row.Field.Name,
+			VenueID:   row.Field.VenueID,
+			TimeZone:  row.Field.TimeZone,
+			Days:      days,
+		})
+	}
+	return res
+}
+
+type fieldSearchItem struct {
+	Field        fieldResponse `json:"field"`
+	DistanceKm   *float64      `json:"distance_km,omitempty"`
+	PricePerHour int           `json:"price_per_hour"`
+	Price        int           `json:"price,omitempty"`
+}
+
+type fieldSearchResponse struct {
+	Results  []fieldSearchItem `json:"results"`
+	Total    int               `json:"total"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"page_size"`
+}
+
+func newFieldSearchResponse(page *service.FieldSearchPage) fieldSearchResponse {
+	res := fieldSearchResponse{
+		Results:  make([]fieldSearchItem, 0, len(page.Results)),
+		Total:    page.Total,
+		Page:     page.Page,
+		PageSize: page.PageSize,
+	}
+
+	for _, result := range page.Results {
+		res.Results = append(res.Results, fieldSearchItem{
+			Field:        newFieldResponse(result.Field),
+			DistanceKm:   result.DistanceKm,
+			PricePerHour: result.PricePerHour,
+			Price:        result.Price,
+		})
+	}
+
+	return res
+}
+
+type fieldDistanceResponse struct {
+	Field      fieldResponse `json:"field"`
+	DistanceKm float64       `json:"distance_km"`
+}
+
+func newFieldDistanceResponses(fields []domain.FieldDistance) []fieldDistanceResponse {
+	res := make([]fieldDistanceResponse, 0, len(fields))
+	for _, item := range fields {
+		res = append(res, fieldDistanceResponse{Field: newFieldResponse(item.Field), DistanceKm: item.DistanceKm})
+	}
+	return res
+}
+
+type bookingResponse struct {
+	ID              int                 `json:"id"`
+	UserID          int                 `json:"user_id"`
+	FieldID         int                 `json:"field_id"`
+	StartTime       time.Time           `json:"start_time"`
+	EndTime         time.Time           `json:"end_time"`
+	OriginalPrice   int                 `json:"original_price"`
+	DiscountAmount  int                 `json:"discount_amount"`
+	TotalPrice      int                 `json:"total_price"`
+	Status          string              `json:"status"`
+	PaymentID       *int                `json:"payment_id,omitempty"`
+	SeriesID        *int                `json:"series_id,omitempty"`
+	ExpiresAt       *time.Time          `json:"expires_at,omitempty"`
+	RescheduleCount int                 `json:"reschedule_count"`
+	DurationMinutes int                 `json:"duration_minutes"`
+	BufferMinutes   int                 `json:"buffer_minutes"`
+	PriceBreakdown  []priceLineResponse `json:"price_breakdown"`
+	CreatedAt       time.Time           `json:"created_at"`
+}
+
+type priceLineResponse struct {
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	Minutes      int       `json:"minutes"`
+	PricePerHour int       `json:"price_per_hour"`
+	RuleID       *int      `json:"rule_id,omitempty"`
+	RuleName     string    `json:"rule_name,omitempty"`
+	Amount       int       `json:"amount"`
+}
+
+func newPriceLineResponses(lines []domain.PriceLine) []priceLineResponse {
+	res := make([]priceLineResponse, 0, len(lines))
+	for _, l := range lines {
+		res = append(res, priceLineResponse{
+			StartTime:    l.StartTime,
+			EndTime:      l.EndTime,
+			Minutes:      l.Minutes(),
+			PricePerHour: l.PricePerHour,
+			RuleID:       l.RuleID,
+			RuleName:     l.RuleName,
+			Amount:       l.Amount,
+		})
+	}
+	return res
+}
+
+type pricingRuleResponse struct {
+	ID           int      `json:"id"`
+	FieldID      int      `json:"field_id"`
+	Name         string   `json:"name"`
+	DayOfWeek    *int     `json:"day_of_week"`
+	StartTime    string   `json:"start_time"`
+	EndTime      string   `json:"end_time"`
+	StartDate    *string  `json:"start_date"`
+	EndDate      *string  `json:"end_date"`
+	PricePerHour *int     `json:"price_per_hour,omitempty"`
+	Multiplier   *float64 `json:"multiplier,omitempty"`
+	Priority     int      `json:"priority"`
+}
+
+func newPricingRuleResponses(rules []*domain.PricingRule) []pricingRuleResponse {
+	res := make([]pricingRuleResponse, 0, len(rules))
+	for _, r := range rules {
+		item := pricingRuleResponse{
+			ID:           r.ID,
+			FieldID:      r.FieldID,
+			Name:         r.Name,
+			StartTime:    formatMinuteOfDay(r.StartMinute),
+			EndTime:      formatMinuteOfDay(r.EndMinute),
+			StartDate:    formatOptionalDate(r.StartDate),
+			EndDate:      formatOptionalDate(r.EndDate),
+			PricePerHour: r.PricePerHour,
+			Multiplier:   r.Multiplier,
+			Priority:     r.Priority,
+		}
+		if r.DayOfWeek != nil {
+			day := int(*r.DayOfWeek)
+			item.DayOfWeek = &day
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+type scheduleExceptionResponse struct {
+	ID        int       `json:"id"`
+	FieldID   int       `json:"field_id"`
+	Date      string    `json:"date"`
+	Type      string    `json:"type"`
+	StartTime string    `json:"start_time,omitempty"`
+	EndTime   string    `json:"end_time,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newScheduleExceptionResponse(e *domain.ScheduleException) scheduleExceptionResponse {
+	res := scheduleExceptionResponse{
+		ID:        e.ID,
+		FieldID:   e.FieldID,
+		Date:      e.Date.Format("2006-01-02"),
+		Type:      string(e.Type),
+		Reason:    e.Reason,
+		CreatedAt: e.CreatedAt,
+	}
+
+	if e.Type != domain.ExceptionClosed {
+		res.StartTime = formatMinuteOfDay(e.StartMinute)
+		res.EndTime = formatMinuteOfDay(e.EndMinute)
+	}
+
+	return res
+}
+
+func newScheduleExceptionResponses(exceptions []*domain.ScheduleException) []scheduleExceptionResponse {
+	res := make([]scheduleExceptionResponse, 0, len(exceptions))
+	for _, e := range exceptions {
+		res = append(res, newScheduleExceptionResponse(e))
+	}
+	return res
+}
+
+// scheduleExceptionResultResponse menyertakan booking aktif yang jatuh di
+// luar jam buka setelah exception ditambahkan.
+type scheduleExceptionResultResponse struct {
+	Exception scheduleExceptionResponse `json:"exception"`
+	Conflicts []bookingResponse         `json:"conflicts"`
+}
+
+func formatMinuteOfDay(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func formatOptionalDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	date := t.Format("2006-01-02")
+	return &date
+}
+
+func newBookingResponse(b *domain.Booking) bookingResponse {
+	return bookingResponse{
+		ID:              b.ID,
+		UserID:          b.UserID,
+		FieldID:         b.FieldID,
+		StartTime:       b.StartTime,
+		EndTime:         b.EndTime,
+		OriginalPrice:   b.OriginalPrice,
+		DiscountAmount:  b.DiscountAmount,
+		TotalPrice:      b.TotalPrice,
+		Status:          string(b.Status),
+		PaymentID:       b.PaymentID,
+		SeriesID:        b.SeriesID,
+		ExpiresAt:       b.ExpiresAt,
+		RescheduleCount: b.RescheduleCount,
+		DurationMinutes: b.GetDurationMinutes(),
+		BufferMinutes:   b.BufferMinutes,
+		PriceBreakdown:  newPriceLineResponses(b.PriceLines),
+		CreatedAt:       b.CreatedAt,
+	}
+}
+
+func newBookingResponses(bookings []*domain.Booking) []bookingResponse {
+	res := make([]bookingResponse, 0, len(bookings))
+	for _, b := range bookings {
+		res = append(res, newBookingResponse(b))
+	}
+	return res
+}
+
+type seriesResponse struct {
+	ID              int               `json:"id"`
+	UserID          int               `json:"user_id"`
+	FieldID         int               `json:"field_id"`
+	Frequency       string            `json:"frequency"`
+	FirstStart      time.Time         `json:"first_start"`
+	DurationMinutes int               `json:"duration_minutes"`
+	Occurrences     int               `json:"occurrences"`
+	PaymentMode     string            `json:"payment_mode"`
+	Status          string            `json:"status"`
+	Bookings        []bookingResponse `json:"bookings"`
+	Payment         *paymentResponse  `json:"payment,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+func newSeriesResponse(d *service.BookingSeriesDetail) seriesResponse {
+	res := seriesResponse{
+		ID:              d.Series.ID,
+		UserID:          d.Series.UserID,
+		FieldID:         d.Series.FieldID,
+		Frequency:       string(d.Series.Frequency),
+		FirstStart:      d.Series.FirstStart,
+		DurationMinutes: d.Series.DurationMinutes,
+		Occurrences:     d.Series.Occurrences,
+		PaymentMode:     string(d.Series.PaymentMode),
+		Status:          string(d.Series.Status),
+		Bookings:        newBookingResponses(d.Bookings),
+		CreatedAt:       d.Series.CreatedAt,
+	}
+
+	if d.Payment != nil {
+		p := newPaymentResponse(d.Payment)
+		res.Payment = &p
+	}
+
+	return res
+}
+
+type waitlistEntryResponse struct {
+	ID             int        `json:"id"`
+	UserID         int        `json:"user_id"`
+	FieldID        int        `json:"field_id"`
+	StartTime      time.Time  `json:"start_time"`
+	EndTime        time.Time  `json:"end_time"`
+	Status         string     `json:"status"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+	BookingID      *int       `json:"booking_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func newWaitlistEntryResponse(e *domain.WaitlistEntry) waitlistEntryResponse {
+	return waitlistEntryResponse{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		FieldID:        e.FieldID,
+		StartTime:      e.StartTime,
+		EndTime:        e.EndTime,
+		Status:         string(e.Status),
+		OfferExpiresAt: e.OfferExpiresAt,
+		BookingID:      e.BookingID,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+func newWaitlistEntryResponses(entries []*domain.WaitlistEntry) []waitlistEntryResponse {
+	res := make([]waitlistEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, newWaitlistEntryResponse(e))
+	}
+	return res
+}
+
+type paymentResponse struct {
+	ID             int       `json:"id"`
+	BookingID      *int      `json:"booking_id,omitempty"`
+	SeriesID       *int      `json:"series_id,omitempty"`
+	Amount         int       `json:"amount"`
+	PaymentGateway string    `json:"payment_gateway"`
+	TransactionID  string    `json:"transaction_id"`
+	PaymentURL     string    `json:"payment_url,omitempty"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func newPaymentResponse(p *domain.Payment) paymentResponse {
+	return paymentResponse{
+		ID:             p.ID,
+		BookingID:      p.BookingID,
+		SeriesID:       p.SeriesID,
+		Amount:         p.Amount,
+		PaymentGateway: p.PaymentGateway,
+		TransactionID:  p.TransactionID,
+		PaymentURL:     p.PaymentURL,
+		Status:         string(p.Status),
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+	}
+}
+
+type rescheduleResponse struct {
+	ID           int       `json:"id"`
+	BookingID    int       `json:"booking_id"`
+	ActorID      *int      `json:"actor_id,omitempty"`
+	OldStartTime time.Time `json:"old_start_time"`
+	OldEndTime   time.Time `json:"old_end_time"`
+	NewStartTime time.Time `json:"new_start_time"`
+	NewEndTime   time.Time `json:"new_end_time"`
+	OldPrice     int       `json:"old_price"`
+	NewPrice     int       `json:"new_price"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func newRescheduleResponse(r *domain.BookingReschedule) rescheduleResponse {
+	return rescheduleResponse{
+		ID:           r.ID,
+		BookingID:    r.BookingID,
+		ActorID:      r.ActorID,
+		OldStartTime: r.OldStartTime,
+		OldEndTime:   r.OldEndTime,
+		NewStartTime: r.NewStartTime,
+		NewEndTime:   r.NewEndTime,
+		OldPrice:     r.OldPrice,
+		NewPrice:     r.NewPrice,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+func newRescheduleResponses(reschedules []*domain.BookingReschedule) []rescheduleResponse {
+	res := make([]rescheduleResponse, 0, len(reschedules))
+	for _, r := range reschedules {
+		res = append(res, newRescheduleResponse(r))
+	}
+	return res
+}
+
+type adjustmentResponse struct {
+	ID            int       `json:"id"`
+	PaymentID     int       `json:"payment_id"`
+	BookingID     int       `json:"booking_id"`
+	Amount        int       `json:"amount"`
+	Reason        string    `json:"reason"`
+	TransactionID string    `json:"transaction_id"`
+	PaymentURL    string    `json:"payment_url,omitempty"`
+	Status        string    `json:"status"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func newAdjustmentResponse(a *domain.PaymentAdjustment) adjustmentResponse {
+	return adjustmentResponse{
+		ID:            a.ID,
+		PaymentID:     a.PaymentID,
+		BookingID:     a.BookingID,
+		Amount:        a.Amount,
+		Reason:        a.Reason,
+		TransactionID: a.TransactionID,
+		PaymentURL:    a.PaymentURL,
+		Status:        string(a.Status),
+		CreatedAt:     a.CreatedAt,
+	}
+}
+
+func newAdjustmentResponses(adjustments []*domain.PaymentAdjustment) []adjustmentResponse {
+	res := make([]adjustmentResponse, 0, len(adjustments))
+	for _, a := range adjustments {
+		res = append(res, newAdjustmentResponse(a))
+	}
+	return res
+}
+
+// rescheduleResultResponse menampilkan booking setelah dipindah beserta
+// penyelesaian selisih harganya.
+type rescheduleResultResponse struct {
+	Booking    bookingResponse     `json:"booking"`
+	Reschedule rescheduleResponse  `json:"reschedule"`
+	Adjustment *adjustmentResponse `json:"adjustment,omitempty"`
+	Refunds    []refundResponse    `json:"refunds,omitempty"`
+}
+
+func newRescheduleResultResponse(r *service.RescheduleResult) rescheduleResultResponse {
+	res := rescheduleResultResponse{
+		Booking:    newBookingResponse(r.Booking),
+		Reschedule: newRescheduleResponse(r.Reschedule),
+	}
+
+	if r.Adjustment != nil {
+		a := newAdjustmentResponse(r.Adjustment)
+		res.Adjustment = &a
+	}
+
+	if len(r.Refunds) > 0 {
+		res.Refunds = newRefundResponses(r.Refunds)
+	}
+
+	return res
+}
+
+type refundResponse struct {
+	ID            int       `json:"id"`
+	BookingID     int       `json:"booking_id"`
+	PaymentID     int       `json:"payment_id"`
+	Amount        int       `json:"amount"`
+	Reason        string    `json:"reason"`
+	Status        string    `json:"status"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func newRefundResponses(refunds []*domain.Refund) []refundResponse {
+	res := make([]refundResponse, 0, len(refunds))
+	for _, r := range refunds {
+		res = append(res, refundResponse{
+			ID:            r.ID,
+			BookingID:     r.BookingID,
+			PaymentID:     r.PaymentID,
+			Amount:        r.Amount,
+			Reason:        r.Reason,
+			Status:        string(r.Status),
+			FailureReason: r.FailureReason,
+			CreatedAt:     r.CreatedAt,
+			UpdatedAt:     r.UpdatedAt,
+		})
+	}
+	return res
+}
+
+type bookingStatusChangeResponse struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ActorID    *int      `json:"actor_id,omitempty"`
+	ActorType  string    `json:"actor_type"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func newBookingStatusChangeResponses(changes []*domain.BookingStatusChange) []bookingStatusChangeResponse {
+	res := make([]bookingStatusChangeResponse, 0, len(changes))
+	for _, c := range changes {
+		res = append(res, bookingStatusChangeResponse{
+			FromStatus: string(c.FromStatus),
+			ToStatus:   string(c.ToStatus),
+			ActorID:    c.ActorID,
+			ActorType:  string(c.ActorType),
+			Reason:     c.Reason,
+			CreatedAt:  c.CreatedAt,
+		})
+	}
+	return res
+}
+
+type promoResponse struct {
+	ID               int        `json:"id"`
+	Code             string     `json:"code"`
+	CreatedBy        int        `json:"created_by"`
+	DiscountType     string     `json:"discount_type"`
+	DiscountValue    int        `json:"discount_value"`
+	FieldIDs         []int      `json:"field_ids"`
+	MinSpend         int        `json:"min_spend"`
+	ValidFrom        *time.Time `json:"valid_from,omitempty"`
+	ValidUntil       *time.Time `json:"valid_until,omitempty"`
+	MaxUses          int        `json:"max_uses"`
+	MaxUsesPerUser   int        `json:"max_uses_per_user"`
+	FirstBookingOnly bool       `json:"first_booking_only"`
+	Active           bool       `json:"active"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func newPromoResponse(p *domain.PromoCode) promoResponse {
+	return promoResponse{
+		ID:               p.ID,
+		Code:             p.Code,
+		CreatedBy:        p.CreatedBy,
+		DiscountType:     string(p.DiscountType),
+		DiscountValue:    p.DiscountValue,
+		FieldIDs:         p.FieldIDs,
+		MinSpend:         p.MinSpend,
+		ValidFrom:        p.ValidFrom,
+		ValidUntil:       p.ValidUntil,
+		MaxUses:          p.MaxUses,
+		MaxUsesPerUser:   p.MaxUsesPerUser,
+		FirstBookingOnly: p.FirstBookingOnly,
+		Active:           p.Active,
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
+
+func newPromoResponses(promos []*domain.PromoCode) []promoResponse {
+	res := make([]promoResponse, 0, len(promos))
+	for _, p := range promos {
+		res = append(res, newPromoResponse(p))
+	}
+	return res
+}
+
+type promoRedemptionResponse struct {
+	ID             int        `json:"id"`
+	PromoCodeID    int        `json:"promo_code_id"`
+	BookingID      int        `json:"booking_id"`
+	UserID         int        `json:"user_id"`
+	DiscountAmount int        `json:"discount_amount"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ReleasedAt     *time.Time `json:"released_at,omitempty"`
+}
+
+func newPromoRedemptionResponses(redemptions []*domain.PromoRedemption) []promoRedemptionResponse {
+	res := make([]promoRedemptionResponse, 0, len(redemptions))
+	for _, r := range redemptions {
+		res = append(res, promoRedemptionResponse{
+			ID:             r.ID,
+			PromoCodeID:    r.PromoCodeID,
+			BookingID:      r.BookingID,
+			UserID:         r.UserID,
+			DiscountAmount: r.DiscountAmount,
+			Status:         string(r.Status),
+			CreatedAt:      r.CreatedAt,
+			ReleasedAt:     r.ReleasedAt,
+		})
+	}
+	return res
+}
+
+type jobRunResponse struct {
+	ID         int        `json:"id"`
+	JobName    string     `json:"job_name"`
+	InstanceID string     `json:"instance_id"`
+	Status     string     `json:"status"`
+	Processed  int        `json:"processed"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+func newJobRunResponses(runs []*domain.JobRun) []jobRunResponse {
+	res := make([]jobRunResponse, 0, len(runs))
+	for _, j := range runs {
+		res = append(res, jobRunResponse{
+			ID:         j.ID,
+			JobName:    j.JobName,
+			InstanceID: j.InstanceID,
+			Status:     string(j.Status),
+			Processed:  j.Processed,
+			Error:      j.Error,
+			StartedAt:  j.StartedAt,
+			FinishedAt: j.FinishedAt,
+		})
+	}
+	return res
+}
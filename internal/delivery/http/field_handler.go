@@ -0,0 +1,597 @@
+package http
+
+import (
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/service"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type FieldHandler struct {
+	fieldService   service.FieldService
+	bookingService service.BookingService
+}
+
+func NewFieldHandler(fieldService service.FieldService, bookingService service.BookingService) *FieldHandler {
+	return &FieldHandler{fieldService: fieldService, bookingService: bookingService}
+}
+
+// fieldRequest membuat atau mengubah court. Tanpa venue_id, create membuat
+// venue baru berisi satu court dari name, address, image_url, dan time_zone.
+type fieldRequest struct {
+	VenueID            int      `json:"venue_id"`
+	Name               string   `json:"name"`
+	Address            string   `json:"address"`
+	Description        string   `json:"description"`
+	ImageURL           string   `json:"image_url"`
+	TimeZone           string   `json:"time_zone"`
+	Latitude           *float64 `json:"latitude"`
+	Longitude          *float64 `json:"longitude"`
+	PricePerHour       int      `json:"price_per_hour"`
+	SurfaceType        string   `json:"surface_type"`
+	Indoor             *bool    `json:"indoor"`
+	PaymentHoldMinutes int      `json:"payment_hold_minutes"`
+
+	CancellationPolicy *cancellationPolicyItem `json:"cancellation_policy"`
+	WaitlistPolicy     *waitlistPolicyItem     `json:"waitlist_policy"`
+	ReschedulePolicy   *reschedulePolicyItem   `json:"reschedule_policy"`
+	SlotPolicy         *slotPolicyItem         `json:"slot_policy"`
+}
+
+type cancellationPolicyItem struct {
+	FullRefundHours      int `json:"full_refund_hours"`
+	PartialRefundPercent int `json:"partial_refund_percent"`
+}
+
+type reschedulePolicyItem struct {
+	MinHoursBefore int `json:"min_hours_before"`
+	MaxReschedules int `json:"max_reschedules"`
+}
+
+type slotPolicyItem struct {
+	SlotMinutes        int `json:"slot_minutes"`
+	MinDurationMinutes int `json:"min_duration_minutes"`
+	MaxDurationMinutes int `json:"max_duration_minutes"`
+	BufferMinutes      int `json:"buffer_minutes"`
+}
+
+type waitlistPolicyItem struct {
+	Order          string `json:"order"`
+	OfferMinutes   int    `json:"offer_minutes"`
+	NotifyCustomer bool   `json:"notify_customer"`
+	NotifyOwner    bool   `json:"notify_owner"`
+}
+
+func (req *fieldRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if strings.TrimSpace(req.Name) == "" {
+		errs["name"] = "is required"
+	}
+
+	if req.VenueID < 0 {
+		errs["venue_id"] = "must be a valid venue ID"
+	}
+
+	if req.VenueID == 0 && strings.TrimSpace(req.Address) == "" {
+		errs["address"] = "is required when venue_id is not set"
+	}
+
+	validateCoordinates(req.Latitude, req.Longitude, errs)
+
+	if req.SurfaceType != "" && !domain.SurfaceType(req.SurfaceType).IsValid() {
+		errs["surface_type"] = "must be SYNTHETIC, VINYL, INTERLOCK, PARQUET, or CEMENT"
+	}
+
+	if req.PricePerHour <= 0 {
+		errs["price_per_hour"] = "must be positive"
+	}
+
+	if req.PaymentHoldMinutes < 0 {
+		errs["payment_hold_minutes"] = "cannot be negative"
+	}
+
+	if policy := req.CancellationPolicy; policy != nil {
+		if policy.FullRefundHours < 0 {
+			errs["cancellation_policy.full_refund_hours"] = "cannot be negative"
+		}
+
+		if policy.PartialRefundPercent < 0 || policy.PartialRefundPercent > 100 {
+			errs["cancellation_policy.partial_refund_percent"] = "must be between 0 and 100"
+		}
+	}
+
+	if policy := req.WaitlistPolicy; policy != nil {
+		if policy.Order != string(domain.WaitlistFIFO) && policy.Order != string(domain.WaitlistLottery) {
+			errs["waitlist_policy.order"] = "must be FIFO or LOTTERY"
+		}
+
+		if policy.OfferMinutes <= 0 {
+			errs["waitlist_policy.offer_minutes"] = "must be positive"
+		}
+	}
+
+	if policy := req.ReschedulePolicy; policy != nil {
+		if policy.MinHoursBefore < 0 {
+			errs["reschedule_policy.min_hours_before"] = "cannot be negative"
+		}
+
+		if policy.MaxReschedules < 0 {
+			errs["reschedule_policy.max_reschedules"] = "cannot be negative"
+		}
+	}
+
+	if policy := req.SlotPolicy; policy != nil {
+		if policy.SlotMinutes <= 0 || 24*60%policy.SlotMinutes != 0 {
+			errs["slot_policy.slot_minutes"] = "must be positive and divide 1440"
+		} else {
+			if policy.MinDurationMinutes <= 0 || policy.MinDurationMinutes%policy.SlotMinutes != 0 {
+				errs["slot_policy.min_duration_minutes"] = "must be a positive multiple of slot_minutes"
+			}
+
+			if policy.MaxDurationMinutes != 0 && (policy.MaxDurationMinutes%policy.SlotMinutes != 0 || policy.MaxDurationMinutes < policy.MinDurationMinutes) {
+				errs["slot_policy.max_duration_minutes"] = "must be 0 or a multiple of slot_minutes not below min_duration_minutes"
+			}
+		}
+
+		if policy.BufferMinutes < 0 {
+			errs["slot_policy.buffer_minutes"] = "cannot be negative"
+		}
+	}
+
+	return errs
+}
+
+func (req *fieldRequest) toInput() service.FieldInput {
+	input := service.FieldInput{
+		VenueID:            req.VenueID,
+		Name:               req.Name,
+		Address:            req.Address,
+		Description:        req.Description,
+		ImageURL:           req.ImageURL,
+		TimeZone:           req.TimeZone,
+		Coordinates:        toGeoPoint(req.Latitude, req.Longitude),
+		PricePerHour:       req.PricePerHour,
+		SurfaceType:        domain.SurfaceType(req.SurfaceType),
+		Indoor:             true,
+		PaymentHoldMinutes: req.PaymentHoldMinutes,
+	}
+
+	if req.Indoor != nil {
+		input.Indoor = *req.Indoor
+	}
+
+	if req.CancellationPolicy != nil {
+		input.CancellationPolicy = &domain.CancellationPolicy{
+			FullRefundHours:      req.CancellationPolicy.FullRefundHours,
+			PartialRefundPercent: req.CancellationPolicy.PartialRefundPercent,
+		}
+	}
+
+	if req.WaitlistPolicy != nil {
+		input.WaitlistPolicy = &domain.WaitlistPolicy{
+			Order:          domain.WaitlistOrder(req.WaitlistPolicy.Order),
+			OfferMinutes:   req.WaitlistPolicy.OfferMinutes,
+			NotifyCustomer: req.WaitlistPolicy.NotifyCustomer,
+			NotifyOwner:    req.WaitlistPolicy.NotifyOwner,
+		}
+	}
+
+	if req.ReschedulePolicy != nil {
+		input.ReschedulePolicy = &domain.ReschedulePolicy{
+			MinHoursBefore: req.ReschedulePolicy.MinHoursBefore,
+			MaxReschedules: req.ReschedulePolicy.MaxReschedules,
+		}
+	}
+
+	if req.SlotPolicy != nil {
+		input.SlotPolicy = &domain.SlotPolicy{
+			SlotMinutes:        req.SlotPolicy.SlotMinutes,
+			MinDurationMinutes: req.SlotPolicy.MinDurationMinutes,
+			MaxDurationMinutes: req.SlotPolicy.MaxDurationMinutes,
+			BufferMinutes:      req.SlotPolicy.BufferMinutes,
+		}
+	}
+
+	return input
+}
+
+type scheduleRequest struct {
+	Schedules []scheduleItem `json:"schedules"`
+}
+
+type scheduleItem struct {
+	DayOfWeek int    `json:"day_of_week"`
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+}
+
+func (req *scheduleRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if len(req.Schedules) == 0 {
+		errs["schedules"] = "at least one schedule is required"
+	}
+
+	for i, s := range req.Schedules {
+		if s.DayOfWeek < 0 || s.DayOfWeek > 6 {
+			errs[fmt.Sprintf("schedules[%d].day_of_week", i)] = "must be between 0 (Sunday) and 6 (Saturday)"
+		}
+
+		if _, err := time.Parse("15:04", s.OpenTime); err != nil {
+			errs[fmt.Sprintf("schedules[%d].open_time", i)] = "must use HH:MM format"
+		}
+
+		if _, err := time.Parse("15:04", s.CloseTime); err != nil {
+			errs[fmt.Sprintf("schedules[%d].close_time", i)] = "must use HH:MM format"
+		}
+	}
+
+	return errs
+}
+
+func (req *scheduleRequest) toInputs() []service.ScheduleInput {
+	inputs := make([]service.ScheduleInput, 0, len(req.Schedules))
+	for _, s := range req.Schedules {
+		inputs = append(inputs, service.ScheduleInput{
+			DayOfWeek: s.DayOfWeek,
+			OpenTime:  s.OpenTime,
+			CloseTime: s.CloseTime,
+		})
+	}
+	return inputs
+}
+
+type pricingRulesRequest struct {
+	Rules []pricingRuleItem `json:"rules"`
+}
+
+type pricingRuleItem struct {
+	Name         string   `json:"name"`
+	DayOfWeek    *int     `json:"day_of_week"`
+	StartTime    string   `json:"start_time"`
+	EndTime      string   `json:"end_time"`
+	StartDate    string   `json:"start_date"`
+	EndDate      string   `json:"end_date"`
+	PricePerHour *int     `json:"price_per_hour"`
+	Multiplier   *float64 `json:"multiplier"`
+	Priority     int      `json:"priority"`
+}
+
+func (req *pricingRulesRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	for i, rule := range req.Rules {
+		if rule.DayOfWeek != nil && (*rule.DayOfWeek < 0 || *rule.DayOfWeek > 6) {
+			errs[fmt.Sprintf("rules[%d].day_of_week", i)] = "must be between 0 (Sunday) and 6 (Saturday)"
+		}
+
+		for name, value := range map[string]string{"start_time": rule.StartTime, "end_time": rule.EndTime} {
+			if _, err := time.Parse("15:04", value); value != "" && value != "24:00" && err != nil {
+				errs[fmt.Sprintf("rules[%d].%s", i, name)] = "must use HH:MM format"
+			}
+		}
+
+		for name, value := range map[string]string{"start_date": rule.StartDate, "end_date": rule.EndDate} {
+			if _, err := time.Parse("2006-01-02", value); value != "" && err != nil {
+				errs[fmt.Sprintf("rules[%d].%s", i, name)] = "must use YYYY-MM-DD format"
+			}
+		}
+
+		if (rule.PricePerHour == nil) == (rule.Multiplier == nil) {
+			errs[fmt.Sprintf("rules[%d].price_per_hour", i)] = "set either price_per_hour or multiplier"
+		}
+	}
+
+	return errs
+}
+
+func (req *pricingRulesRequest) toInputs() []service.PricingRuleInput {
+	inputs := make([]service.PricingRuleInput, 0, len(req.Rules))
+
+	for _, rule := range req.Rules {
+		inputs = append(inputs, service.PricingRuleInput{
+			Name:         rule.Name,
+			DayOfWeek:    rule.DayOfWeek,
+			StartTime:    rule.StartTime,
+			EndTime:      rule.EndTime,
+			StartDate:    parseOptionalDate(rule.StartDate),
+			EndDate:      parseOptionalDate(rule.EndDate),
+			PricePerHour: rule.PricePerHour,
+			Multiplier:   rule.Multiplier,
+			Priority:     rule.Priority,
+		})
+	}
+
+	return inputs
+}
+
+// parseOptionalDate mengubah YYYY-MM-DD yang sudah divalidasi menjadi *time.Time.
+// String kosong berarti tanpa batas.
+func parseOptionalDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil
+	}
+
+	return &date
+}
+
+// List handles GET /api/fields
+func (h *FieldHandler) List(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	fields, err := h.fieldService.GetAllFields(r.Context())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newFieldResponses(fields))
+}
+
+// Get handles GET /api/fields/:id
+func (h *FieldHandler) Get(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	field, err := h.fieldService.GetFieldByID(r.Context(), fieldID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newFieldResponse(field))
+}
+
+// ListMine handles GET /api/owner/fields
+func (h *FieldHandler) ListMine(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	fields, err := h.fieldService.GetFieldsByOwnerID(r.Context(), currentUser(r).ID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newFieldResponses(fields))
+}
+
+// Create handles POST /api/fields
+func (h *FieldHandler) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req fieldRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	field, err := h.fieldService.CreateField(r.Context(), currentUser(r), req.toInput())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, newFieldResponse(field))
+}
+
+// Update handles PUT /api/fields/:id
+func (h *FieldHandler) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	var req fieldRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	field, err := h.fieldService.UpdateField(r.Context(), currentUser(r), fieldID, req.toInput())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newFieldResponse(field))
+}
+
+// Delete handles DELETE /api/fields/:id
+func (h *FieldHandler) Delete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.fieldService.DeleteField(r.Context(), currentUser(r), fieldID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, map[string]int{"id": fieldID})
+}
+
+// SetupSchedules handles PUT /api/fields/:id/schedules
+func (h *FieldHandler) SetupSchedules(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	var req scheduleRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.fieldService.SetupSchedules(r.Context(), currentUser(r), fieldID, req.toInputs()); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	schedules, err := h.fieldService.GetScheduleByFieldID(r.Context(), fieldID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newScheduleResponses(schedules))
+}
+
+// Schedules handles GET /api/fields/:id/schedules
+func (h *FieldHandler) Schedules(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	schedules, err := h.fieldService.GetScheduleByFieldID(r.Context(), fieldID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newScheduleResponses(schedules))
+}
+
+// Slots handles GET /api/fields/:id/slots?date=YYYY-MM-DD
+func (h *FieldHandler) Slots(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", r.URL.Query().Get("date"))
+	if err != nil {
+		writeValidationError(w, map[string]string{"date": "must use YYYY-MM-DD format"})
+		return
+	}
+
+	slots, err := h.fieldService.FindAvailableSlots(r.Context(), fieldID, date)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newSlotResponses(slots))
+}
+
+// defaultCalendarDays adalah jumlah hari kalender jika query days tidak diisi.
+const defaultCalendarDays = 7
+
+// Calendar handles GET /api/calendar?field_ids=1,2&venue_id=&from=YYYY-MM-DD&days=7
+func (h *FieldHandler) Calendar(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	errs := map[string]string{}
+
+	input := service.CalendarInput{From: time.Now(), Days: defaultCalendarDays}
+
+	if value := query.Get("field_ids"); value != "" {
+		for _, part := range strings.Split(value, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || id <= 0 {
+				errs["field_ids"] = "must be a comma-separated list of positive integers"
+				break
+			}
+			input.FieldIDs = append(input.FieldIDs, id)
+		}
+	}
+
+	if value := query.Get("venue_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			errs["venue_id"] = "must be a positive integer"
+		}
+		input.VenueID = id
+	}
+
+	if len(input.FieldIDs) == 0 && input.VenueID == 0 && errs["field_ids"] == "" && errs["venue_id"] == "" {
+		errs["field_ids"] = "field_ids or venue_id is required"
+	}
+
+	if value := query.Get("from"); value != "" {
+		from, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			errs["from"] = "must use YYYY-MM-DD format"
+		}
+		input.From = from
+	}
+
+	if value := query.Get("days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days <= 0 || days > service.MaxCalendarDays {
+			errs["days"] = fmt.Sprintf("must be between 1 and %d", service.MaxCalendarDays)
+		}
+		input.Days = days
+	}
+
+	if len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	calendar, err := h.fieldService.GetAvailabilityCalendar(r.Context(), input)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newCalendarResponses(calendar))
+}
+
+// Bookings handles GET /api/fields/:id/bookings
+func (h *FieldHandler) Bookings(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	bookings, err := h.bookingService.GetFieldBookings(r.Context(), currentUser(r), fieldID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newBookingResponses(bookings))
+}
+
+// SetPricingRules handles PUT /api/fields/:id/pricing-rules
+func (h *FieldHandler) SetPricingRules(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	var req pricingRulesRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	rules, err := h.fieldService.SetPricingRules(r.Context(), currentUser(r), fieldID, req.toInputs())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newPricingRuleResponses(rules))
+}
+
+// PricingRules handles GET /api/fields/:id/pricing-rules
+func (h *FieldHandler) PricingRules(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	rules, err := h.fieldService.GetPricingRules(r.Context(), fieldID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newPricingRuleResponses(rules))
+}
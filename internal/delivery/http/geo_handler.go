@@ -0,0 +1,99 @@
+package http
+
+import (
+	"futsal-booking-app/internal/domain"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// NearbyFields handles GET /api/geo/fields/nearby?lat=&lng=&radius_km=&limit=
+func (h *FieldHandler) NearbyFields(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	errs := map[string]string{}
+
+	values := parseFloatParams(query, errs, "lat", "lng", "radius_km")
+	limit := parseLimit(query, errs)
+
+	if len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	center := domain.GeoPoint{Latitude: values["lat"], Longitude: values["lng"]}
+
+	fields, err := h.fieldService.FindFieldsNear(r.Context(), center, values["radius_km"], limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newFieldDistanceResponses(fields))
+}
+
+// FieldsInBounds handles GET /api/geo/fields/bbox?sw_lat=&sw_lng=&ne_lat=&ne_lng=&limit=
+func (h *FieldHandler) FieldsInBounds(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	errs := map[string]string{}
+
+	values := parseFloatParams(query, errs, "sw_lat", "sw_lng", "ne_lat", "ne_lng")
+	limit := parseLimit(query, errs)
+
+	if len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	box := domain.BoundingBox{
+		SouthWest: domain.GeoPoint{Latitude: values["sw_lat"], Longitude: values["sw_lng"]},
+		NorthEast: domain.GeoPoint{Latitude: values["ne_lat"], Longitude: values["ne_lng"]},
+	}
+
+	fields, err := h.fieldService.FindFieldsInBounds(r.Context(), box, limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newFieldResponses(fields))
+}
+
+// parseFloatParams membaca query param wajib bertipe angka.
+func parseFloatParams(query url.Values, errs map[string]string, names ...string) map[string]float64 {
+	values := make(map[string]float64, len(names))
+
+	for _, name := range names {
+		value := query.Get(name)
+		if value == "" {
+			errs[name] = "is required"
+			continue
+		}
+
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			errs[name] = "must be a number"
+			continue
+		}
+
+		values[name] = parsed
+	}
+
+	return values
+}
+
+// parseLimit membaca query param limit opsional; 0 berarti limit default.
+func parseLimit(query url.Values, errs map[string]string) int {
+	value := query.Get("limit")
+	if value == "" {
+		return 0
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		errs["limit"] = "must be a non-negative integer"
+	}
+
+	return limit
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"futsal-booking-app/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type JobHandler struct {
+	jobService service.JobService
+}
+
+func NewJobHandler(jobService service.JobService) *JobHandler {
+	return &JobHandler{jobService: jobService}
+}
+
+// Runs handles GET /api/admin/job-runs?job=&status=&limit=
+func (h *JobHandler) Runs(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+
+	filter := repository.JobRunFilter{
+		JobName: query.Get("job"),
+		Status:  domain.JobRunStatus(query.Get("status")),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			writeValidationError(w, map[string]string{"limit": "must be a positive integer"})
+			return
+		}
+		filter.Limit = parsed
+	}
+
+	runs, err := h.jobService.ListJobRuns(r.Context(), currentUser(r), filter)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newJobRunResponses(runs))
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"futsal-booking-app/internal/service"
+	"io"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// maxNotificationBytes membatasi ukuran body webhook payment gateway.
+const maxNotificationBytes = 64 << 10
+
+type PaymentHandler struct {
+	paymentService service.PaymentService
+}
+
+func NewPaymentHandler(paymentService service.PaymentService) *PaymentHandler {
+	return &PaymentHandler{paymentService: paymentService}
+}
+
+// Notification handles POST /api/payments/notifications
+// Dipanggil oleh payment gateway; gateway mengulang notifikasi selama
+// response bukan 2xx, jadi notifikasi duplikat harus tetap dijawab 200.
+func (h *PaymentHandler) Notification(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxNotificationBytes))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, "failed to read request body")
+		return
+	}
+
+	if err := h.paymentService.HandleNotification(r.Context(), body); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, nil)
+}
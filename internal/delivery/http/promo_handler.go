@@ -0,0 +1,158 @@
+package http
+
+import (
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/service"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type PromoHandler struct {
+	promoService service.PromoService
+}
+
+func NewPromoHandler(promoService service.PromoService) *PromoHandler {
+	return &PromoHandler{promoService: promoService}
+}
+
+// promoRequest dipakai untuk create dan update. Code diabaikan saat update.
+// Active default true supaya promo baru langsung bisa dipakai.
+type promoRequest struct {
+	Code             string     `json:"code"`
+	DiscountType     string     `json:"discount_type"`
+	DiscountValue    int        `json:"discount_value"`
+	FieldIDs         []int      `json:"field_ids"`
+	MinSpend         int        `json:"min_spend"`
+	ValidFrom        *time.Time `json:"valid_from"`
+	ValidUntil       *time.Time `json:"valid_until"`
+	MaxUses          int        `json:"max_uses"`
+	MaxUsesPerUser   int        `json:"max_uses_per_user"`
+	FirstBookingOnly bool       `json:"first_booking_only"`
+	Active           *bool      `json:"active"`
+}
+
+func (req *promoRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	switch domain.DiscountType(req.DiscountType) {
+	case domain.DiscountPercentage, domain.DiscountFixed:
+	default:
+		errs["discount_type"] = "must be PERCENTAGE or FIXED"
+	}
+
+	if req.DiscountValue <= 0 {
+		errs["discount_value"] = "must be positive"
+	}
+
+	for _, id := range req.FieldIDs {
+		if id <= 0 {
+			errs["field_ids"] = "must contain valid field IDs"
+			break
+		}
+	}
+
+	return errs
+}
+
+func (req *promoRequest) toInput() service.PromoInput {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return service.PromoInput{
+		Code:             req.Code,
+		DiscountType:     domain.DiscountType(req.DiscountType),
+		DiscountValue:    req.DiscountValue,
+		FieldIDs:         req.FieldIDs,
+		MinSpend:         req.MinSpend,
+		ValidFrom:        req.ValidFrom,
+		ValidUntil:       req.ValidUntil,
+		MaxUses:          req.MaxUses,
+		MaxUsesPerUser:   req.MaxUsesPerUser,
+		FirstBookingOnly: req.FirstBookingOnly,
+		Active:           active,
+	}
+}
+
+// Create handles POST /api/promo-codes
+func (h *PromoHandler) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req promoRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	promo, err := h.promoService.CreatePromo(r.Context(), currentUser(r), req.toInput())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, newPromoResponse(promo))
+}
+
+// ListMine handles GET /api/promo-codes
+func (h *PromoHandler) ListMine(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	promos, err := h.promoService.GetMyPromos(r.Context(), currentUser(r))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newPromoResponses(promos))
+}
+
+// Get handles GET /api/promo-codes/:id
+func (h *PromoHandler) Get(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	promoID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	promo, err := h.promoService.GetPromo(r.Context(), currentUser(r), promoID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newPromoResponse(promo))
+}
+
+// Update handles PUT /api/promo-codes/:id
+func (h *PromoHandler) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	promoID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	var req promoRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	promo, err := h.promoService.UpdatePromo(r.Context(), currentUser(r), promoID, req.toInput())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newPromoResponse(promo))
+}
+
+// Redemptions handles GET /api/promo-codes/:id/redemptions
+func (h *PromoHandler) Redemptions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	promoID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	redemptions, err := h.promoService.GetPromoRedemptions(r.Context(), currentUser(r), promoID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newPromoRedemptionResponses(redemptions))
+}
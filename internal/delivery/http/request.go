@@ -0,0 +1,56 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const maxBodyBytes = 1 << 20
+
+// validator diimplementasikan oleh semua request body. Validate
+// mengembalikan map field -> pesan error; map kosong berarti valid.
+type validator interface {
+	Validate() map[string]string
+}
+
+// decodeAndValidate membaca body JSON ke dst lalu menjalankan validasi.
+// Jika gagal, response error sudah ditulis dan fungsi mengembalikan false.
+func decodeAndValidate(w http.ResponseWriter, r *http.Request, dst validator) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		if errors.Is(err, io.EOF) {
+			writeError(w, http.StatusBadRequest, CodeBadRequest, "request body cannot be empty")
+			return false
+		}
+		writeError(w, http.StatusBadRequest, CodeBadRequest, fmt.Sprintf("invalid JSON body: %v", err))
+		return false
+	}
+
+	if details := dst.Validate(); len(details) > 0 {
+		writeValidationError(w, details)
+		return false
+	}
+
+	return true
+}
+
+// paramID membaca path parameter numerik (misal :id).
+func paramID(w http.ResponseWriter, ps httprouter.Params, name string) (int, bool) {
+	id, err := strconv.Atoi(ps.ByName(name))
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, fmt.Sprintf("invalid %s", name))
+		return 0, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"futsal-booking-app/internal/domain"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Envelope adalah format response JSON yang konsisten untuk semua endpoint.
+// Response sukses mengisi Data, response gagal mengisi Error.
+type Envelope struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   *ErrorBody  `json:"error,omitempty"`
+}
+
+type ErrorBody struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+const (
+	CodeBadRequest     = "BAD_REQUEST"
+	CodeValidation     = "VALIDATION_ERROR"
+	CodeUnauthorized   = "UNAUTHORIZED"
+	CodeForbidden      = "FORBIDDEN"
+	CodeNotFound       = "NOT_FOUND"
+	CodeConflict       = "CONFLICT"
+	CodeTimeout        = "TIMEOUT"
+	CodeAmountMismatch = "AMOUNT_MISMATCH"
+	CodeInternal       = "INTERNAL_ERROR"
+)
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		log.Printf("error encoding response: %v", err)
+	}
+}
+
+func writeSuccess(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, Envelope{Success: true, Data: data})
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, Envelope{
+		Success: false,
+		Error:   &ErrorBody{Code: code, Message: message},
+	})
+}
+
+func writeValidationError(w http.ResponseWriter, details map[string]string) {
+	writeJSON(w, http.StatusUnprocessableEntity, Envelope{
+		Success: false,
+		Error: &ErrorBody{
+			Code:    CodeValidation,
+			Message: "request validation failed",
+			Details: details,
+		},
+	})
+}
+
+// writeServiceError memetakan error dari layer service ke HTTP status.
+// Error sentinel dari domain dipetakan secara eksplisit, termasuk
+// domain.ValidationError untuk 400. Error lain dianggap error internal dan
+// pesannya tidak diteruskan ke client.
+func writeServiceError(w http.ResponseWriter, err error) {
+	var seriesConflict *domain.SeriesConflictError
+	if errors.As(err, &seriesConflict) {
+		writeSeriesConflict(w, seriesConflict)
+		return
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrFieldNotFound),
+		errors.Is(err, domain.ErrBookingNotFound),
+		errors.Is(err, domain.ErrPaymentNotFound),
+		errors.Is(err, domain.ErrScheduleNotFound),
+		errors.Is(err, domain.ErrSessionNotFound),
+		errors.Is(err, domain.ErrRefundNotFound),
+		errors.Is(err, domain.ErrSeriesNotFound),
+		errors.Is(err, domain.ErrWaitlistNotFound),
+		errors.Is(err, domain.ErrPromoNotFound),
+		errors.Is(err, domain.ErrVenueNotFound):
+		writeError(w, http.StatusNotFound, CodeNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidCredentials),
+		errors.Is(err, domain.ErrInvalidToken),
+		errors.Is(err, domain.ErrSessionRevoked),
+		errors.Is(err, domain.ErrInvalidSignature):
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, err.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		writeError(w, http.StatusForbidden, CodeForbidden, err.Error())
+	case errors.Is(err, domain.ErrEmailAlreadyRegistered),
+		errors.Is(err, domain.ErrSlotNotAvailable),
+		errors.Is(err, domain.ErrInvalidTransition),
+		errors.Is(err, domain.ErrAlreadyOnWaitlist),
+		errors.Is(err, domain.ErrPromoCodeExists):
+		writeError(w, http.StatusConflict, CodeConflict, err.Error())
+	case errors.Is(err, domain.ErrAmountMismatch):
+		// Notifikasi valid tapi nominalnya tidak cocok; sudah dicatat di riwayat
+		// payment untuk ditindaklanjuti operator.
+		log.Printf("payment amount mismatch: %v", err)
+		writeError(w, http.StatusUnprocessableEntity, CodeAmountMismatch, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, CodeTimeout, "request timed out")
+	case errors.Is(err, context.Canceled):
+		// Client sudah menutup koneksi, response tidak akan terbaca
+		log.Printf("request cancelled: %v", err)
+	case errors.Is(err, domain.ErrValidation):
+		writeError(w, http.StatusBadRequest, CodeBadRequest, err.Error())
+	default:
+		log.Printf("internal error: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "internal server error")
+	}
+}
+
+// writeSeriesConflict mengembalikan 409 dengan setiap tanggal occurrence yang
+// bentrok di details sehingga client bisa menampilkan tanggal mana yang penuh.
+func writeSeriesConflict(w http.ResponseWriter, err *domain.SeriesConflictError) {
+	details := make(map[string]string, len(err.Conflicts))
+	for _, start := range err.Conflicts {
+		details[start.Format(time.RFC3339)] = "slot not available"
+	}
+
+	writeJSON(w, http.StatusConflict, Envelope{
+		Success: false,
+		Error: &ErrorBody{
+			Code:    CodeConflict,
+			Message: err.Error(),
+			Details: details,
+		},
+	})
+}
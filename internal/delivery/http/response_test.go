@@ -0,0 +1,54 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteServiceErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"validation", domain.Invalidf("invalid field ID"), http.StatusBadRequest},
+		{"wrapped validation", fmt.Errorf("error creating booking: %w", domain.Invalidf("duration must be positive")), http.StatusBadRequest},
+		{"plain error", errors.New("connection refused"), http.StatusInternalServerError},
+		{"wrapped infrastructure error", fmt.Errorf("error finding booking: %w", errors.New("connection refused")), http.StatusInternalServerError},
+		{"not found", fmt.Errorf("error fetching booking: %w", domain.ErrBookingNotFound), http.StatusNotFound},
+		{"promo not found", domain.ErrPromoNotFound, http.StatusNotFound},
+		{"venue not found", domain.ErrVenueNotFound, http.StatusNotFound},
+		{"forbidden", fmt.Errorf("%w: booking:cancel", domain.ErrForbidden), http.StatusForbidden},
+		{"slot taken", &domain.SlotTakenError{}, http.StatusConflict},
+		{"already on waitlist", domain.ErrAlreadyOnWaitlist, http.StatusConflict},
+		{"duplicate promo code", domain.ErrPromoCodeExists, http.StatusConflict},
+		{"series conflict", &domain.SeriesConflictError{}, http.StatusConflict},
+		{"invalid transition", fmt.Errorf("%w: CANCELLED -> CONFIRMED", domain.ErrInvalidTransition), http.StatusConflict},
+		{"amount mismatch", &domain.AmountMismatchError{}, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeServiceError(rec, tt.err)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteServiceErrorHidesInternalMessage(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeServiceError(rec, errors.New("pq: password authentication failed"))
+
+	if body := rec.Body.String(); !strings.Contains(body, "internal server error") || strings.Contains(body, "pq:") {
+		t.Fatalf("internal error leaked to client: %s", body)
+	}
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"futsal-booking-app/internal/domain"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type Handlers struct {
+	Auth     *AuthHandler
+	Venue    *VenueHandler
+	Field    *FieldHandler
+	Booking  *BookingHandler
+	Series   *SeriesHandler
+	Waitlist *WaitlistHandler
+	Promo    *PromoHandler
+	Payment  *PaymentHandler
+	Job      *JobHandler
+}
+
+// NewRouter mendaftarkan semua endpoint JSON API.
+func NewRouter(h Handlers, mw *Middleware) http.Handler {
+	router := httprouter.New()
+
+	router.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, CodeNotFound, "route not found")
+	})
+	router.MethodNotAllowed = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusMethodNotAllowed, CodeBadRequest, "method not allowed")
+	})
+	router.PanicHandler = func(w http.ResponseWriter, r *http.Request, v interface{}) {
+		writeError(w, http.StatusInternalServerError, CodeInternal, "internal server error")
+	}
+
+	router.GET("/health", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		writeSuccess(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	// Auth
+	router.POST("/api/auth/register", h.Auth.Register)
+	router.POST("/api/auth/login", h.Auth.Login)
+	router.POST("/api/auth/refresh", h.Auth.Refresh)
+	router.POST("/api/auth/logout", mw.Authenticate(h.Auth.Logout))
+	router.POST("/api/auth/logout-all", mw.Authenticate(h.Auth.LogoutAll))
+	router.GET("/api/auth/me", mw.Authenticate(h.Auth.Me))
+
+	// Venues (public)
+	router.GET("/api/venues", h.Venue.List)
+	router.GET("/api/venues/:id", h.Venue.Get)
+	router.GET("/api/venues/:id/schedules", h.Venue.Schedules)
+	router.GET("/api/venues/:id/availability", h.Venue.Availability)
+
+	// Venues (owner)
+	router.GET("/api/owner/venues", mw.RequireRole(domain.RoleOwner, h.Venue.ListMine))
+	router.POST("/api/venues", mw.RequireRole(domain.RoleOwner, h.Venue.Create))
+	router.PUT("/api/venues/:id", mw.RequireRole(domain.RoleOwner, h.Venue.Update))
+	router.DELETE("/api/venues/:id", mw.RequireRole(domain.RoleOwner, h.Venue.Delete))
+	router.PUT("/api/venues/:id/schedules", mw.RequireRole(domain.RoleOwner, h.Venue.SetupSchedules))
+
+	// Fields (public)
+	router.GET("/api/fields", h.Field.List)
+	router.GET("/api/fields/:id", h.Field.Get)
+	router.GET("/api/fields/:id/schedules", h.Field.Schedules)
+	router.GET("/api/fields/:id/slots", h.Field.Slots)
+	router.GET("/api/calendar", h.Field.Calendar)
+	router.GET("/api/search/fields", h.Field.SearchFields)
+	router.GET("/api/geo/fields/nearby", h.Field.NearbyFields)
+	router.GET("/api/geo/fields/bbox", h.Field.FieldsInBounds)
+	router.GET("/api/fields/:id/pricing-rules", h.Field.PricingRules)
+	router.GET("/api/fields/:id/schedule-exceptions", h.Field.ScheduleExceptions)
+
+	// Fields (owner)
+	router.GET("/api/owner/fields", mw.RequireRole(domain.RoleOwner, h.Field.ListMine))
+	router.POST("/api/fields", mw.RequireRole(domain.RoleOwner, h.Field.Create))
+	router.PUT("/api/fields/:id", mw.RequireRole(domain.RoleOwner, h.Field.Update))
+	router.DELETE("/api/fields/:id", mw.RequireRole(domain.RoleOwner, h.Field.Delete))
+	router.PUT("/api/fields/:id/schedules", mw.RequireRole(domain.RoleOwner, h.Field.SetupSchedules))
+	router.PUT("/api/fields/:id/pricing-rules", mw.RequireRole(domain.RoleOwner, h.Field.SetPricingRules))
+	router.POST("/api/fields/:id/schedule-exceptions", mw.RequireRole(domain.RoleOwner, h.Field.AddScheduleException))
+	router.DELETE("/api/fields/:id/schedule-exceptions/:exception_id", mw.RequireRole(domain.RoleOwner, h.Field.DeleteScheduleException))
+	router.GET("/api/fields/:id/schedule-exceptions/:exception_id/conflicts", mw.RequireRole(domain.RoleOwner, h.Field.ScheduleExceptionConflicts))
+	router.GET("/api/fields/:id/bookings", mw.RequireRole(domain.RoleOwner, h.Field.Bookings))
+
+	// Bookings (customer)
+	router.POST("/api/bookings", mw.Authenticate(h.Booking.Create))
+	router.GET("/api/bookings", mw.Authenticate(h.Booking.ListMine))
+	router.GET("/api/bookings/:id", mw.Authenticate(h.Booking.Get))
+	router.GET("/api/bookings/:id/payment", mw.Authenticate(h.Booking.Payment))
+	router.GET("/api/bookings/:id/refunds", mw.Authenticate(h.Booking.Refunds))
+	router.GET("/api/bookings/:id/history", mw.Authenticate(h.Booking.History))
+	router.GET("/api/bookings/:id/reschedules", mw.Authenticate(h.Booking.Reschedules))
+	router.GET("/api/bookings/:id/adjustments", mw.Authenticate(h.Booking.Adjustments))
+	router.POST("/api/bookings/:id/cancel", mw.Authenticate(h.Booking.Cancel))
+	router.POST("/api/bookings/:id/reschedule", mw.Authenticate(h.Booking.Reschedule))
+
+	// Bookings (owner)
+	router.POST("/api/bookings/:id/confirm", mw.RequireRole(domain.RoleOwner, h.Booking.Confirm))
+	router.POST("/api/bookings/:id/complete", mw.RequireRole(domain.RoleOwner, h.Booking.Complete))
+	router.POST("/api/bookings/:id/no-show", mw.RequireRole(domain.RoleOwner, h.Booking.NoShow))
+
+	// Booking series (customer; owner lapangan boleh melihat)
+	router.POST("/api/booking-series", mw.Authenticate(h.Series.Create))
+	router.GET("/api/booking-series/:id", mw.Authenticate(h.Series.Get))
+	router.POST("/api/booking-series/:id/cancel", mw.Authenticate(h.Series.Cancel))
+
+	// Waitlist (customer)
+	router.POST("/api/waitlist", mw.Authenticate(h.Waitlist.Join))
+	router.GET("/api/waitlist", mw.Authenticate(h.Waitlist.ListMine))
+	router.POST("/api/waitlist/:id/cancel", mw.Authenticate(h.Waitlist.Cancel))
+	router.POST("/api/waitlist/:id/accept", mw.Authenticate(h.Waitlist.Accept))
+
+	// Promo codes (owner dan admin)
+	router.POST("/api/promo-codes", mw.RequireRole(domain.RoleOwner, h.Promo.Create))
+	router.GET("/api/promo-codes", mw.RequireRole(domain.RoleOwner, h.Promo.ListMine))
+	router.GET("/api/promo-codes/:id", mw.RequireRole(domain.RoleOwner, h.Promo.Get))
+	router.PUT("/api/promo-codes/:id", mw.RequireRole(domain.RoleOwner, h.Promo.Update))
+	router.GET("/api/promo-codes/:id/redemptions", mw.RequireRole(domain.RoleOwner, h.Promo.Redemptions))
+
+	// Payments (webhook, diverifikasi lewat signature gateway)
+	router.POST("/api/payments/notifications", h.Payment.Notification)
+
+	// Admin
+	router.GET("/api/admin/job-runs", mw.RequireRole(domain.RoleAdmin, h.Job.Runs))
+
+	return Logging(router)
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/service"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// defaultExceptionRangeDays adalah rentang default GET schedule exception
+// jika query to tidak diisi.
+const defaultExceptionRangeDays = 30
+
+type scheduleExceptionRequest struct {
+	Date      string `json:"date"`
+	Type      string `json:"type"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Reason    string `json:"reason"`
+}
+
+func (req *scheduleExceptionRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+		errs["date"] = "must use YYYY-MM-DD format"
+	}
+
+	switch domain.ScheduleExceptionType(req.Type) {
+	case domain.ExceptionClosed:
+	case domain.ExceptionClosedWindow, domain.ExceptionSpecialHours:
+		for name, value := range map[string]string{"start_time": req.StartTime, "end_time": req.EndTime} {
+			if _, err := time.Parse("15:04", value); value != "24:00" && err != nil {
+				errs[name] = "must use HH:MM format"
+			}
+		}
+	default:
+		errs["type"] = "must be CLOSED, CLOSED_WINDOW, or SPECIAL_HOURS"
+	}
+
+	return errs
+}
+
+func (req *scheduleExceptionRequest) toInput() service.ScheduleExceptionInput {
+	date, _ := time.Parse("2006-01-02", req.Date)
+
+	return service.ScheduleExceptionInput{
+		Date:      date,
+		Type:      domain.ScheduleExceptionType(req.Type),
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Reason:    req.Reason,
+	}
+}
+
+// AddScheduleException handles POST /api/fields/:id/schedule-exceptions
+func (h *FieldHandler) AddScheduleException(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	var req scheduleExceptionRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	result, err := h.fieldService.AddScheduleException(r.Context(), currentUser(r), fieldID, req.toInput())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, scheduleExceptionResultResponse{
+		Exception: newScheduleExceptionResponse(result.Exception),
+		Conflicts: newBookingResponses(result.Conflicts),
+	})
+}
+
+// ScheduleExceptions handles GET /api/fields/:id/schedule-exceptions?from=YYYY-MM-DD&to=YYYY-MM-DD
+func (h *FieldHandler) ScheduleExceptions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	errs := map[string]string{}
+
+	from := time.Now()
+	if value := query.Get("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			errs["from"] = "must use YYYY-MM-DD format"
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, defaultExceptionRangeDays)
+	if value := query.Get("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			errs["to"] = "must use YYYY-MM-DD format"
+		}
+		to = parsed
+	}
+
+	if len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	exceptions, err := h.fieldService.GetScheduleExceptions(r.Context(), fieldID, from, to)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newScheduleExceptionResponses(exceptions))
+}
+
+// DeleteScheduleException handles DELETE /api/fields/:id/schedule-exceptions/:exception_id
+func (h *FieldHandler) DeleteScheduleException(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	exceptionID, ok := paramID(w, ps, "exception_id")
+	if !ok {
+		return
+	}
+
+	if err := h.fieldService.DeleteScheduleException(r.Context(), currentUser(r), fieldID, exceptionID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, map[string]int{"id": exceptionID})
+}
+
+// ScheduleExceptionConflicts handles GET /api/fields/:id/schedule-exceptions/:exception_id/conflicts
+func (h *FieldHandler) ScheduleExceptionConflicts(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	fieldID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	exceptionID, ok := paramID(w, ps, "exception_id")
+	if !ok {
+		return
+	}
+
+	bookings, err := h.fieldService.GetScheduleExceptionConflicts(r.Context(), currentUser(r), fieldID, exceptionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newBookingResponses(bookings))
+}
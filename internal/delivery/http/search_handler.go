@@ -0,0 +1,95 @@
+package http
+
+import (
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/service"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// defaultSearchDurationMinutes dipakai jika start diisi tanpa duration_minutes.
+const defaultSearchDurationMinutes = 60
+
+// SearchFields handles GET /api/search/fields?start=RFC3339&duration_minutes=&min_price=&max_price=&amenities=&lat=&lng=&radius_km=&sort=&page=&page_size=
+func (h *FieldHandler) SearchFields(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	errs := map[string]string{}
+
+	input := service.FieldSearchInput{Sort: service.FieldSearchSort(query.Get("sort"))}
+
+	if value := query.Get("start"); value != "" {
+		start, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errs["start"] = "must use RFC3339 format"
+		}
+		input.Start = start
+		input.DurationMinutes = defaultSearchDurationMinutes
+	}
+
+	intParams := map[string]*int{
+		"duration_minutes": &input.DurationMinutes,
+		"min_price":        &input.MinPrice,
+		"max_price":        &input.MaxPrice,
+		"page":             &input.Page,
+		"page_size":        &input.PageSize,
+	}
+	for name, target := range intParams {
+		if value := query.Get(name); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				errs[name] = "must be a non-negative integer"
+			}
+			*target = parsed
+		}
+	}
+
+	if value := query.Get("amenities"); value != "" {
+		input.Amenities = strings.Split(value, ",")
+	}
+
+	var latitude, longitude *float64
+	floatParams := map[string]**float64{"lat": &latitude, "lng": &longitude}
+	for name, target := range floatParams {
+		if value := query.Get(name); value != "" {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				errs[name] = "must be a number"
+			}
+			*target = &parsed
+		}
+	}
+
+	if (latitude == nil) != (longitude == nil) {
+		errs["lat"] = "lat and lng must be set together"
+	} else if latitude != nil {
+		input.Near = &domain.GeoPoint{Latitude: *latitude, Longitude: *longitude}
+		if !input.Near.IsValid() {
+			errs["lat"] = "coordinates are out of range"
+		}
+	}
+
+	if value := query.Get("radius_km"); value != "" {
+		radius, err := strconv.ParseFloat(value, 64)
+		if err != nil || radius < 0 {
+			errs["radius_km"] = "must be a non-negative number"
+		}
+		input.RadiusKm = radius
+	}
+
+	if len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	page, err := h.fieldService.SearchFields(r.Context(), input)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newFieldSearchResponse(page))
+}
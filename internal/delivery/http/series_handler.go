@@ -0,0 +1,136 @@
+package http
+
+import (
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/service"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type SeriesHandler struct {
+	bookingService service.BookingService
+}
+
+func NewSeriesHandler(bookingService service.BookingService) *SeriesHandler {
+	return &SeriesHandler{bookingService: bookingService}
+}
+
+type createSeriesRequest struct {
+	FieldID   int       `json:"field_id"`
+	StartTime time.Time `json:"start_time"`
+	bookingDuration
+	Frequency   string     `json:"frequency"`
+	Occurrences int        `json:"occurrences"`
+	EndDate     *time.Time `json:"end_date"`
+	PaymentMode string     `json:"payment_mode"`
+}
+
+func (req *createSeriesRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if req.FieldID <= 0 {
+		errs["field_id"] = "is required"
+	}
+
+	if req.StartTime.IsZero() {
+		errs["start_time"] = "is required (RFC3339)"
+	}
+
+	if req.minutes() <= 0 {
+		errs["duration_minutes"] = "must be positive"
+	}
+
+	if domain.RecurrenceFrequency(req.Frequency).IntervalDays() == 0 {
+		errs["frequency"] = "must be WEEKLY or BIWEEKLY"
+	}
+
+	if (req.Occurrences > 0) == (req.EndDate != nil) {
+		errs["occurrences"] = "set either occurrences or end_date"
+	}
+
+	return errs
+}
+
+type cancelSeriesRequest struct {
+	From *time.Time `json:"from"`
+}
+
+func (req *cancelSeriesRequest) Validate() map[string]string {
+	return map[string]string{}
+}
+
+// Create handles POST /api/booking-series
+func (h *SeriesHandler) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req createSeriesRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if req.PaymentMode == "" {
+		req.PaymentMode = string(domain.SeriesPayPerOccurrence)
+	}
+
+	detail, err := h.bookingService.CreateBookingSeries(r.Context(), currentUser(r), service.BookingSeriesInput{
+		FieldID:         req.FieldID,
+		FirstStart:      req.StartTime,
+		DurationMinutes: req.minutes(),
+		Frequency:       domain.RecurrenceFrequency(req.Frequency),
+		Occurrences:     req.Occurrences,
+		EndDate:         req.EndDate,
+		PaymentMode:     domain.SeriesPaymentMode(req.PaymentMode),
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, newSeriesResponse(detail))
+}
+
+// Get handles GET /api/booking-series/:id
+func (h *SeriesHandler) Get(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	seriesID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	h.respondWithSeries(w, r, seriesID)
+}
+
+// Cancel handles POST /api/booking-series/:id/cancel
+// Body opsional {"from": RFC3339}; tanpa from semua occurrence yang belum dimulai dibatalkan.
+func (h *SeriesHandler) Cancel(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	seriesID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	var req cancelSeriesRequest
+	if r.ContentLength != 0 && !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	var from time.Time
+	if req.From != nil {
+		from = *req.From
+	}
+
+	if err := h.bookingService.CancelBookingSeries(r.Context(), currentUser(r), seriesID, from); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	h.respondWithSeries(w, r, seriesID)
+}
+
+func (h *SeriesHandler) respondWithSeries(w http.ResponseWriter, r *http.Request, seriesID int) {
+	detail, err := h.bookingService.GetBookingSeries(r.Context(), currentUser(r), seriesID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newSeriesResponse(detail))
+}
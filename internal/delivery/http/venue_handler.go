@@ -0,0 +1,257 @@
+package http
+
+import (
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/service"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type VenueHandler struct {
+	venueService service.VenueService
+}
+
+func NewVenueHandler(venueService service.VenueService) *VenueHandler {
+	return &VenueHandler{venueService: venueService}
+}
+
+type venueRequest struct {
+	Name        string   `json:"name"`
+	Address     string   `json:"address"`
+	Description string   `json:"description"`
+	ImageURL    string   `json:"image_url"`
+	Amenities   []string `json:"amenities"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	TimeZone    string   `json:"time_zone"`
+}
+
+func (req *venueRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if strings.TrimSpace(req.Name) == "" {
+		errs["name"] = "is required"
+	}
+
+	if strings.TrimSpace(req.Address) == "" {
+		errs["address"] = "is required"
+	}
+
+	validateCoordinates(req.Latitude, req.Longitude, errs)
+
+	return errs
+}
+
+func (req *venueRequest) toInput() service.VenueInput {
+	return service.VenueInput{
+		Name:        req.Name,
+		Address:     req.Address,
+		Description: req.Description,
+		ImageURL:    req.ImageURL,
+		Amenities:   req.Amenities,
+		TimeZone:    req.TimeZone,
+		Coordinates: toGeoPoint(req.Latitude, req.Longitude),
+	}
+}
+
+// validateCoordinates memastikan latitude dan longitude diisi bersamaan dan
+// berada di rentangnya.
+func validateCoordinates(latitude, longitude *float64, errs map[string]string) {
+	if (latitude == nil) != (longitude == nil) {
+		errs["latitude"] = "latitude and longitude must be set together"
+		return
+	}
+
+	if latitude != nil && (*latitude < -90 || *latitude > 90) {
+		errs["latitude"] = "must be between -90 and 90"
+	}
+
+	if longitude != nil && (*longitude < -180 || *longitude > 180) {
+		errs["longitude"] = "must be between -180 and 180"
+	}
+}
+
+func toGeoPoint(latitude, longitude *float64) *domain.GeoPoint {
+	if latitude == nil || longitude == nil {
+		return nil
+	}
+
+	return &domain.GeoPoint{Latitude: *latitude, Longitude: *longitude}
+}
+
+// List handles GET /api/venues
+func (h *VenueHandler) List(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	venues, err := h.venueService.GetAllVenues(r.Context())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newVenueResponses(venues))
+}
+
+// ListMine handles GET /api/owner/venues
+func (h *VenueHandler) ListMine(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	venues, err := h.venueService.GetVenuesByOwnerID(r.Context(), currentUser(r).ID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newVenueResponses(venues))
+}
+
+// Get handles GET /api/venues/:id
+func (h *VenueHandler) Get(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	venueID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	venue, err := h.venueService.GetVenueByID(r.Context(), venueID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	courts, err := h.venueService.GetVenueCourts(r.Context(), venueID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, venueDetailResponse{
+		venueResponse: newVenueResponse(venue),
+		Courts:        newFieldResponses(courts),
+	})
+}
+
+// Create handles POST /api/venues
+func (h *VenueHandler) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req venueRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	venue, err := h.venueService.CreateVenue(r.Context(), currentUser(r), req.toInput())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, newVenueResponse(venue))
+}
+
+// Update handles PUT /api/venues/:id
+func (h *VenueHandler) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	venueID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	var req venueRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	venue, err := h.venueService.UpdateVenue(r.Context(), currentUser(r), venueID, req.toInput())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newVenueResponse(venue))
+}
+
+// Delete handles DELETE /api/venues/:id
+func (h *VenueHandler) Delete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	venueID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.venueService.DeleteVenue(r.Context(), currentUser(r), venueID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, map[string]int{"id": venueID})
+}
+
+// Schedules handles GET /api/venues/:id/schedules
+func (h *VenueHandler) Schedules(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	venueID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	schedules, err := h.venueService.GetVenueSchedules(r.Context(), venueID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newScheduleResponses(schedules))
+}
+
+// SetupSchedules handles PUT /api/venues/:id/schedules
+func (h *VenueHandler) SetupSchedules(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	venueID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	var req scheduleRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	schedules, err := h.venueService.SetupVenueSchedules(r.Context(), currentUser(r), venueID, req.toInputs())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newScheduleResponses(schedules))
+}
+
+// Availability handles GET /api/venues/:id/availability?start=RFC3339&duration_minutes=60
+func (h *VenueHandler) Availability(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	venueID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	errs := map[string]string{}
+
+	start, err := time.Parse(time.RFC3339, query.Get("start"))
+	if err != nil {
+		errs["start"] = "is required (RFC3339)"
+	}
+
+	duration := 60
+	if value := query.Get("duration_minutes"); value != "" {
+		duration, err = strconv.Atoi(value)
+		if err != nil || duration <= 0 {
+			errs["duration_minutes"] = "must be positive"
+		}
+	}
+
+	if len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	courts, err := h.venueService.FindAvailableCourts(r.Context(), venueID, start, duration)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newCourtAvailabilityResponses(courts))
+}
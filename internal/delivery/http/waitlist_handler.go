@@ -0,0 +1,99 @@
+package http
+
+import (
+	"futsal-booking-app/internal/service"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type WaitlistHandler struct {
+	waitlistService service.WaitlistService
+}
+
+func NewWaitlistHandler(waitlistService service.WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{waitlistService: waitlistService}
+}
+
+type joinWaitlistRequest struct {
+	FieldID   int       `json:"field_id"`
+	StartTime time.Time `json:"start_time"`
+	bookingDuration
+}
+
+func (req *joinWaitlistRequest) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if req.FieldID <= 0 {
+		errs["field_id"] = "is required"
+	}
+
+	if req.StartTime.IsZero() {
+		errs["start_time"] = "is required (RFC3339)"
+	}
+
+	if req.minutes() <= 0 {
+		errs["duration_minutes"] = "must be positive"
+	}
+
+	return errs
+}
+
+// Join handles POST /api/waitlist
+func (h *WaitlistHandler) Join(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req joinWaitlistRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	entry, err := h.waitlistService.JoinWaitlist(r.Context(), currentUser(r), req.FieldID, req.StartTime, req.minutes())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, newWaitlistEntryResponse(entry))
+}
+
+// ListMine handles GET /api/waitlist
+func (h *WaitlistHandler) ListMine(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	entries, err := h.waitlistService.GetMyWaitlist(r.Context(), currentUser(r).ID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, newWaitlistEntryResponses(entries))
+}
+
+// Cancel handles POST /api/waitlist/:id/cancel
+func (h *WaitlistHandler) Cancel(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	entryID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.waitlistService.LeaveWaitlist(r.Context(), currentUser(r), entryID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, map[string]int{"id": entryID})
+}
+
+// Accept handles POST /api/waitlist/:id/accept
+func (h *WaitlistHandler) Accept(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	entryID, ok := paramID(w, ps, "id")
+	if !ok {
+		return
+	}
+
+	booking, err := h.waitlistService.AcceptOffer(r.Context(), currentUser(r), entryID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, newBookingResponse(booking))
+}
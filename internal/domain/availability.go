@@ -0,0 +1,98 @@
+package domain
+
+import "time"
+
+// BlockedRange adalah rentang [Start, End) yang tidak bisa dibooking di satu
+// lapangan: booking aktif sampai blocked_until-nya, atau penawaran waitlist
+// yang masih berlaku.
+type BlockedRange struct {
+	FieldID int
+	Start   time.Time
+	End     time.Time
+}
+
+// TimeSlot adalah satu slot di grid lapangan.
+type TimeSlot struct {
+	StartTime time.Time
+	EndTime   time.Time
+	Available bool
+	// Price adalah harga slot sesuai pricing rule lapangan.
+	Price int
+}
+
+// FieldCalendar berisi semua data satu lapangan yang dibutuhkan untuk
+// menghitung slot banyak hari di memori, tanpa query per slot.
+type FieldCalendar struct {
+	Field      *Field
+	Schedules  []*Schedule
+	Exceptions []*ScheduleException
+	Rules      []*PricingRule
+	// Blocked harus urut berdasarkan Start dan mencakup seluruh rentang
+	// tanggal yang dihitung, termasuk jendela yang melewati tengah malam.
+	Blocked []BlockedRange
+}
+
+// SlotsOn membagi jam buka lapangan pada tanggal date (di zona waktu
+// lapangan) menjadi slot selebar SlotPolicy.SlotMinutes. Di setiap jendela
+// jam buka, slot pertama dimulai di grid pertama setelah jam buka, dan slot
+// yang melewati jam tutup tidak ditampilkan. Slot yang beririsan dengan
+// BlockedRange setelah ditambah buffer lapangan ditandai tidak tersedia, sama
+// seperti CanBook.
+func (c *FieldCalendar) SlotsOn(date time.Time) []TimeSlot {
+	loc := c.Field.Location()
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+
+	slots := []TimeSlot{}
+	slotLength := time.Duration(c.Field.SlotPolicy.SlotMinutes) * time.Minute
+
+	for _, window := range OpenWindowsOn(c.Schedules, c.Exceptions, date) {
+		currentSlot := window.Start
+		for !c.Field.SlotPolicy.IsAligned(currentSlot) {
+			currentSlot = currentSlot.Add(time.Minute)
+		}
+
+		for !currentSlot.Add(slotLength).After(window.End) {
+			slotEnd := currentSlot.Add(slotLength)
+
+			slots = append(slots, TimeSlot{
+				StartTime: currentSlot,
+				EndTime:   slotEnd,
+				Available: !c.isBlocked(currentSlot, slotEnd.Add(c.Field.SlotPolicy.Buffer())),
+				Price:     QuotePrice(c.Field, c.Rules, currentSlot, slotEnd).Total,
+			})
+
+			currentSlot = slotEnd
+		}
+	}
+
+	return slots
+}
+
+// CanBook mengecek apakah booking baru pada [start, end) bisa dibuat: rentang
+// berada di dalam jam buka lapangan dan tidak beririsan dengan BlockedRange
+// setelah ditambah buffer lapangan, sama seperti pengecekan saat checkout.
+func (c *FieldCalendar) CanBook(start, end time.Time) bool {
+	loc := c.Field.Location()
+	if !IsOpenBetween(c.Schedules, c.Exceptions, start.In(loc), end.In(loc)) {
+		return false
+	}
+
+	return !c.isBlocked(start, end.Add(c.Field.SlotPolicy.Buffer()))
+}
+
+// isBlocked mengecek apakah [start, end) beririsan dengan salah satu
+// BlockedRange. Blocked urut berdasarkan Start, jadi scan berhenti di range
+// pertama yang dimulai setelah end.
+func (c *FieldCalendar) isBlocked(start, end time.Time) bool {
+	for _, blocked := range c.Blocked {
+		if !blocked.Start.Before(end) {
+			return false
+		}
+
+		if blocked.End.After(start) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFieldCalendarSlotsOn(t *testing.T) {
+	// Jumat 18:00 sampai 22:00, slot 60 menit dengan buffer 30 menit
+	policy := DefaultSlotPolicy
+	policy.BufferMinutes = 30
+	field := &Field{ID: 1, PricePerHour: 100000, TimeZone: "UTC", SlotPolicy: policy}
+	schedules := []*Schedule{{DayOfWeek: Friday, OpenTime: clock(18, 0), CloseTime: clock(22, 0)}}
+	evening := &PricingRule{ID: 1, StartMinute: 20 * 60, EndMinute: 22 * 60, PricePerHour: intPtr(150000)}
+
+	type slot struct {
+		start     time.Time
+		available bool
+		price     int
+	}
+
+	tests := []struct {
+		name       string
+		blocked    []BlockedRange
+		exceptions []*ScheduleException
+		want       []slot
+	}{
+		{
+			name: "all slots free",
+			want: []slot{
+				{at(0, 18, 0), true, 100000},
+				{at(0, 19, 0), true, 100000},
+				{at(0, 20, 0), true, 150000},
+				{at(0, 21, 0), true, 150000},
+			},
+		},
+		{
+			name:    "blocked range and the buffer before it",
+			blocked: []BlockedRange{{FieldID: 1, Start: at(0, 20, 15), End: at(0, 21, 0)}},
+			want: []slot{
+				{at(0, 18, 0), true, 100000},
+				{at(0, 19, 0), false, 100000},
+				{at(0, 20, 0), false, 150000},
+				{at(0, 21, 0), true, 150000},
+			},
+		},
+		{
+			name:       "closed window removes slots",
+			exceptions: []*ScheduleException{{Date: at(0, 0, 0), Type: ExceptionClosedWindow, StartMinute: 18 * 60, EndMinute: 20 * 60}},
+			want: []slot{
+				{at(0, 20, 0), true, 150000},
+				{at(0, 21, 0), true, 150000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendar := &FieldCalendar{
+				Field:      field,
+				Schedules:  schedules,
+				Exceptions: tt.exceptions,
+				Rules:      []*PricingRule{evening},
+				Blocked:    tt.blocked,
+			}
+
+			got := calendar.SlotsOn(at(0, 0, 0))
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d slots, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				s := got[i]
+				if !s.StartTime.Equal(want.start) || s.Available != want.available || s.Price != want.price {
+					t.Errorf("slot %d = %s %v %d, want %s %v %d", i, s.StartTime, s.Available, s.Price, want.start, want.available, want.price)
+				}
+			}
+		})
+	}
+}
@@ -9,35 +9,67 @@ const (
 	BookingConfirmed BookingStatus = "CONFIRMED"
 	BookingCancelled BookingStatus = "CANCELLED"
 	BookingCompleted BookingStatus = "COMPLETED"
+	BookingExpired   BookingStatus = "EXPIRED"
+	BookingNoShow    BookingStatus = "NO_SHOW"
 )
 
 type Booking struct {
-	ID         int
-	UserID     int
-	FieldID    int
-	StartTime  time.Time
-	EndTime    time.Time
+	ID        int
+	UserID    int
+	FieldID   int
+	StartTime time.Time
+	EndTime   time.Time
+	// TotalPrice adalah harga yang harus dibayar: OriginalPrice dikurangi DiscountAmount.
 	TotalPrice int
-	Status     BookingStatus
-	PaymentID  *int
+	// OriginalPrice adalah harga sebelum diskon promo.
+	OriginalPrice  int
+	DiscountAmount int
+	Status         BookingStatus
+	PaymentID      *int
+	// SeriesID diisi jika booking adalah occurrence dari BookingSeries.
+	SeriesID *int
+	// ExpiresAt adalah batas waktu pembayaran untuk booking PENDING.
+	// Setelah lewat, booking di-expire dan slot dilepas.
+	ExpiresAt *time.Time
+	// RescheduleCount adalah berapa kali booking sudah dipindah jadwalnya.
+	RescheduleCount int
+	// BufferMinutes adalah jeda setelah EndTime yang ikut ditahan booking,
+	// disalin dari SlotPolicy lapangan saat booking dibuat.
+	BufferMinutes int
+	// PriceLines adalah rincian OriginalPrice per potongan tarif saat booking
+	// dibuat atau terakhir di-reschedule.
+	PriceLines []PriceLine
 	CreatedAt  time.Time
 }
 
-func (b *Booking) GetDuration() float64 {
-	duration := b.EndTime.Sub(b.StartTime)
-
-	return duration.Hours()
+// SetPrice mengisi harga dan PriceLines dari hasil QuotePrice. Nominal diskon
+// yang sudah ada dipertahankan; saat reschedule, diskon promo persentase
+// dihitung ulang oleh pemanggil.
+func (b *Booking) SetPrice(breakdown PriceBreakdown) {
+	b.OriginalPrice = breakdown.Total
+	b.PriceLines = breakdown.Lines
+	b.ApplyDiscount(b.DiscountAmount)
 }
 
-func (b *Booking) GetDurationHours() int {
-	duration := b.GetDuration()
-
-	hours := int(duration)
-	if duration > float64(hours) {
-		hours++
+// ApplyDiscount memotong OriginalPrice sebesar discount, tidak sampai negatif.
+func (b *Booking) ApplyDiscount(discount int) {
+	if discount > b.OriginalPrice {
+		discount = b.OriginalPrice
 	}
 
-	return hours
+	b.DiscountAmount = discount
+	b.TotalPrice = b.OriginalPrice - discount
+}
+
+// GetDurationMinutes mengembalikan durasi booking dalam menit.
+func (b *Booking) GetDurationMinutes() int {
+	return int(b.EndTime.Sub(b.StartTime) / time.Minute)
+}
+
+// BlockedUntil adalah akhir rentang yang ditahan booking, yaitu EndTime
+// ditambah buffer. Booking berikutnya baru boleh dimulai pada waktu ini.
+func (b *Booking) BlockedUntil() time.Time {
+	return b.EndTime.Add(time.Duration(b.BufferMinutes) * time.Minute)
 }
 
 func (b *Booking) IsPending() bool {
@@ -56,15 +88,33 @@ func (b *Booking) IsCompleted() bool {
 	return b.Status == BookingCompleted
 }
 
+func (b *Booking) IsExpired() bool {
+	return b.Status == BookingExpired
+}
+
+func (b *Booking) IsNoShow() bool {
+	return b.Status == BookingNoShow
+}
+
+// IsHoldExpired mengecek apakah booking PENDING sudah melewati batas waktu pembayaran,
+// walaupun statusnya belum diubah menjadi EXPIRED oleh worker.
+func (b *Booking) IsHoldExpired(now time.Time) bool {
+	return b.Status == BookingPending && b.ExpiresAt != nil && !now.Before(*b.ExpiresAt)
+}
+
+// MinCancellationNotice adalah batas waktu pembatalan: booking hanya bisa
+// dibatalkan lebih dari 2 jam sebelum dimulai.
+const MinCancellationNotice = 2 * time.Hour
+
+// CanBeCancelled mengecek apakah booking masih bisa dibatalkan, yaitu masih
+// aktif dan mulai lebih dari MinCancellationNotice lagi. Besar refund untuk
+// booking yang sudah dibayar ditentukan terpisah oleh CancellationPolicy lapangan.
 func (b *Booking) CanBeCancelled(now time.Time) bool {
 	if b.Status != BookingPending && b.Status != BookingConfirmed {
 		return false
 	}
 
-	timeUntilStart := b.StartTime.Sub(now)
-	minCancellationTime := 2 * time.Hour
-
-	return timeUntilStart > minCancellationTime
+	return b.StartTime.Sub(now) > MinCancellationNotice
 }
 
 func (b *Booking) IsActive(now time.Time) bool {
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxSeriesOccurrences membatasi jumlah booking dalam satu series.
+const MaxSeriesOccurrences = 52
+
+// SeriesPaymentLeadTime adalah batas bayar occurrence pada series dengan
+// pembayaran per occurrence: paling lambat sekian lama sebelum occurrence dimulai.
+const SeriesPaymentLeadTime = 24 * time.Hour
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceWeekly   RecurrenceFrequency = "WEEKLY"
+	RecurrenceBiweekly RecurrenceFrequency = "BIWEEKLY"
+)
+
+// IntervalDays mengembalikan jarak hari antar occurrence, atau 0 jika frekuensi tidak dikenal.
+func (f RecurrenceFrequency) IntervalDays() int {
+	switch f {
+	case RecurrenceWeekly:
+		return 7
+	case RecurrenceBiweekly:
+		return 14
+	default:
+		return 0
+	}
+}
+
+type SeriesPaymentMode string
+
+const (
+	// SeriesPayPerOccurrence membuat satu payment untuk setiap booking.
+	SeriesPayPerOccurrence SeriesPaymentMode = "PER_OCCURRENCE"
+	// SeriesPayUpfront membuat satu payment untuk seluruh series.
+	SeriesPayUpfront SeriesPaymentMode = "UPFRONT"
+)
+
+type SeriesStatus string
+
+const (
+	SeriesActive    SeriesStatus = "ACTIVE"
+	SeriesCancelled SeriesStatus = "CANCELLED"
+)
+
+// BookingSeries adalah booking berulang, misalnya tim liga yang main setiap
+// Selasa jam 20:00. Setiap occurrence disimpan sebagai Booking biasa dengan
+// SeriesID yang menunjuk ke series ini.
+type BookingSeries struct {
+	ID              int
+	UserID          int
+	FieldID         int
+	Frequency       RecurrenceFrequency
+	FirstStart      time.Time
+	DurationMinutes int
+	Occurrences     int
+	PaymentMode     SeriesPaymentMode
+	Status          SeriesStatus
+	CreatedAt       time.Time
+}
+
+func (s *BookingSeries) IsUpfront() bool {
+	return s.PaymentMode == SeriesPayUpfront
+}
+
+// OccurrenceStarts menghitung waktu mulai semua occurrence. Jam mulai
+// dipertahankan dalam zona waktu FirstStart walaupun melewati pergantian DST.
+func (s *BookingSeries) OccurrenceStarts() []time.Time {
+	starts := make([]time.Time, 0, s.Occurrences)
+	for i := 0; i < s.Occurrences; i++ {
+		starts = append(starts, s.FirstStart.AddDate(0, 0, i*s.Frequency.IntervalDays()))
+	}
+	return starts
+}
+
+// CountOccurrencesUntil menghitung jumlah occurrence yang dimulai paling
+// lambat pada tanggal endDate (inklusif). Hasilnya tidak dibatasi
+// MaxSeriesOccurrences; pemanggil yang menolak series yang terlalu panjang.
+func CountOccurrencesUntil(firstStart time.Time, frequency RecurrenceFrequency, endDate time.Time) int {
+	interval := frequency.IntervalDays()
+	if interval == 0 {
+		return 0
+	}
+
+	// Occurrence mempertahankan jam dinding FirstStart, jadi cukup selisih
+	// tanggal kalender yang dihitung, bebas dari pergantian DST.
+	fy, fm, fd := firstStart.Date()
+	ey, em, ed := endDate.Date()
+	first := time.Date(fy, fm, fd, 0, 0, 0, 0, time.UTC)
+	last := time.Date(ey, em, ed, 0, 0, 0, 0, time.UTC)
+
+	if last.Before(first) {
+		return 0
+	}
+
+	days := int(last.Sub(first).Hours() / 24)
+	return days/interval + 1
+}
+
+// SeriesPaymentDeadline menghitung batas bayar satu occurrence pada series
+// dengan pembayaran per occurrence. Occurrence yang sudah dekat memakai hold
+// normal lapangan.
+func SeriesPaymentDeadline(field *Field, start, now time.Time) time.Time {
+	hold := field.HoldDeadline(now)
+
+	deadline := start.Add(-SeriesPaymentLeadTime)
+	if deadline.Before(hold) {
+		return hold
+	}
+	return deadline
+}
+
+// SeriesConflictError dikembalikan jika sebagian occurrence bentrok dengan
+// booking lain. errors.Is(err, ErrSlotNotAvailable) bernilai true.
+type SeriesConflictError struct {
+	Conflicts []time.Time
+}
+
+func (e *SeriesConflictError) Error() string {
+	dates := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		dates = append(dates, c.Format("2006-01-02 15:04"))
+	}
+	sort.Strings(dates)
+
+	return fmt.Sprintf("time slot is not available on: %s", strings.Join(dates, ", "))
+}
+
+func (e *SeriesConflictError) Is(target error) bool {
+	return target == ErrSlotNotAvailable
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// at membuat waktu UTC pada 2026-03-06 (Jumat) ditambah offset hari.
+func at(dayOffset, hour, minute int) time.Time {
+	return time.Date(2026, 3, 6+dayOffset, hour, minute, 0, 0, time.UTC)
+}
+
+func TestCountOccurrencesUntil(t *testing.T) {
+	// Occurrence pertama Jumat 2026-03-06 20:00
+	first := at(0, 20, 0)
+
+	tests := []struct {
+		name      string
+		frequency RecurrenceFrequency
+		endDate   time.Time
+		want      int
+	}{
+		{"weekly end on first day", RecurrenceWeekly, at(0, 0, 0), 1},
+		{"weekly end before first day", RecurrenceWeekly, at(-1, 23, 59), 0},
+		{"weekly end on occurrence day", RecurrenceWeekly, at(21, 0, 0), 4},
+		{"weekly end on occurrence day before start time", RecurrenceWeekly, at(21, 8, 0), 4},
+		{"weekly end just before occurrence day", RecurrenceWeekly, at(20, 23, 59), 3},
+		{"biweekly end on occurrence day", RecurrenceBiweekly, at(28, 0, 0), 3},
+		{"biweekly end just before occurrence day", RecurrenceBiweekly, at(27, 0, 0), 2},
+		{"biweekly end on off week", RecurrenceBiweekly, at(21, 0, 0), 2},
+		{"weekly at the cap", RecurrenceWeekly, at((MaxSeriesOccurrences-1)*7, 0, 0), MaxSeriesOccurrences},
+		{"weekly past the cap is not truncated", RecurrenceWeekly, at(MaxSeriesOccurrences*7, 0, 0), MaxSeriesOccurrences + 1},
+		{"unknown frequency", RecurrenceFrequency("DAILY"), at(21, 0, 0), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountOccurrencesUntil(first, tt.frequency, tt.endDate); got != tt.want {
+				t.Fatalf("CountOccurrencesUntil(%s) = %d, want %d", tt.endDate.Format("2006-01-02"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountOccurrencesUntilAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// DST dimulai 2026-03-08; occurrence keempat tetap jatuh pada 2026-03-26
+	first := time.Date(2026, 3, 5, 20, 0, 0, 0, loc)
+	endDate := time.Date(2026, 3, 26, 0, 0, 0, 0, loc)
+
+	if got := CountOccurrencesUntil(first, RecurrenceWeekly, endDate); got != 4 {
+		t.Fatalf("CountOccurrencesUntil = %d, want 4", got)
+	}
+}
+
+func TestSeriesPaymentDeadline(t *testing.T) {
+	field := &Field{PaymentHoldMinutes: 30}
+	now := at(0, 10, 0)
+
+	tests := []struct {
+		name  string
+		field *Field
+		start time.Time
+		want  time.Time
+	}{
+		{"far occurrence pays a day before", field, at(14, 20, 0), at(13, 20, 0)},
+		{"lead time exactly at hold", field, at(1, 10, 30), at(0, 10, 30)},
+		{"near occurrence uses hold", field, at(0, 20, 0), at(0, 10, 30)},
+		{"default hold", &Field{}, at(0, 20, 0), at(0, 10, DefaultPaymentHoldMinutes)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SeriesPaymentDeadline(tt.field, tt.start, now); !got.Equal(tt.want) {
+				t.Fatalf("SeriesPaymentDeadline = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
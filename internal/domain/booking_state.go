@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// TransitionActor adalah pihak yang memicu perubahan status booking.
+// Berbeda dengan Role, ada ActorSystem untuk perubahan otomatis dari
+// worker atau notifikasi payment gateway.
+type TransitionActor string
+
+const (
+	ActorCustomer TransitionActor = "CUSTOMER"
+	ActorOwner    TransitionActor = "OWNER"
+	ActorAdmin    TransitionActor = "ADMIN"
+	ActorSystem   TransitionActor = "SYSTEM"
+)
+
+// bookingTransitions mendefinisikan transisi status yang diizinkan dan siapa
+// yang boleh memicunya. Admin boleh memicu semua transisi yang terdaftar.
+// Status yang tidak punya entri (CANCELLED, COMPLETED, EXPIRED, NO_SHOW) adalah status akhir.
+var bookingTransitions = map[BookingStatus]map[BookingStatus][]TransitionActor{
+	BookingPending: {
+		BookingConfirmed: {ActorOwner, ActorSystem},
+		BookingCancelled: {ActorCustomer, ActorSystem},
+		BookingExpired:   {ActorSystem},
+	},
+	BookingConfirmed: {
+		BookingCompleted: {ActorOwner, ActorSystem},
+		BookingNoShow:    {ActorOwner},
+		BookingCancelled: {ActorCustomer, ActorSystem},
+	},
+}
+
+// InvalidTransitionError dikembalikan jika transisi status tidak diizinkan
+// dari status saat ini. errors.Is(err, ErrInvalidTransition) bernilai true.
+type InvalidTransitionError struct {
+	From BookingStatus
+	To   BookingStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("booking cannot move from %s to %s", e.From, e.To)
+}
+
+func (e *InvalidTransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// CanTransition mengecek apakah booking boleh berpindah dari status from ke to.
+func CanTransition(from, to BookingStatus) bool {
+	_, ok := bookingTransitions[from][to]
+	return ok
+}
+
+// BookingStatusChange adalah satu baris riwayat perubahan status booking.
+type BookingStatusChange struct {
+	ID         int
+	BookingID  int
+	FromStatus BookingStatus
+	ToStatus   BookingStatus
+	// ActorID kosong untuk perubahan oleh ActorSystem.
+	ActorID   *int
+	ActorType TransitionActor
+	Reason    string
+	CreatedAt time.Time
+}
+
+// TransitionTo memindahkan booking ke status baru jika transisi tersebut
+// diizinkan untuk actor, lalu mengembalikan catatan riwayatnya.
+// Booking tidak diubah jika transisi ditolak.
+func (b *Booking) TransitionTo(to BookingStatus, actor TransitionActor, actorID *int, reason string, now time.Time) (*BookingStatusChange, error) {
+	allowed, ok := bookingTransitions[b.Status][to]
+	if !ok {
+		return nil, &InvalidTransitionError{From: b.Status, To: to}
+	}
+
+	if actor != ActorAdmin && !containsActor(allowed, actor) {
+		return nil, fmt.Errorf("%w: %s cannot move booking from %s to %s", ErrForbidden, actor, b.Status, to)
+	}
+
+	change := &BookingStatusChange{
+		BookingID:  b.ID,
+		FromStatus: b.Status,
+		ToStatus:   to,
+		ActorID:    actorID,
+		ActorType:  actor,
+		Reason:     reason,
+		CreatedAt:  now,
+	}
+
+	b.Status = to
+	return change, nil
+}
+
+func containsActor(actors []TransitionActor, actor TransitionActor) bool {
+	for _, a := range actors {
+		if a == actor {
+			return true
+		}
+	}
+	return false
+}
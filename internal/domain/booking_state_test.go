@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var (
+	allBookingStatuses = []BookingStatus{BookingPending, BookingConfirmed, BookingCancelled, BookingCompleted, BookingExpired, BookingNoShow}
+	allActors          = []TransitionActor{ActorSystem, ActorCustomer, ActorOwner, ActorAdmin}
+	allPaymentStatuses = []PaymentStatus{PaymentPending, PaymentSuccess, PaymentFailed, PaymentRefunded}
+)
+
+func TestBookingTransitionTo(t *testing.T) {
+	// Transisi yang diizinkan beserta actor yang boleh memicunya. Pasangan
+	// status yang tidak ada di sini harus ditolak untuk semua actor.
+	allowed := []struct {
+		from, to BookingStatus
+		actors   []TransitionActor
+	}{
+		{BookingPending, BookingConfirmed, []TransitionActor{ActorOwner, ActorSystem, ActorAdmin}},
+		{BookingPending, BookingCancelled, []TransitionActor{ActorCustomer, ActorSystem, ActorAdmin}},
+		{BookingPending, BookingExpired, []TransitionActor{ActorSystem, ActorAdmin}},
+		{BookingConfirmed, BookingCompleted, []TransitionActor{ActorOwner, ActorSystem, ActorAdmin}},
+		{BookingConfirmed, BookingNoShow, []TransitionActor{ActorOwner, ActorAdmin}},
+		{BookingConfirmed, BookingCancelled, []TransitionActor{ActorCustomer, ActorSystem, ActorAdmin}},
+	}
+
+	listed := map[[2]BookingStatus][]TransitionActor{}
+	for _, a := range allowed {
+		listed[[2]BookingStatus{a.from, a.to}] = a.actors
+	}
+
+	now := time.Date(2026, 3, 6, 10, 0, 0, 0, time.UTC)
+	actorID := 7
+
+	for _, from := range allBookingStatuses {
+		for _, to := range allBookingStatuses {
+			actors, isListed := listed[[2]BookingStatus{from, to}]
+
+			for _, actor := range allActors {
+				t.Run(fmt.Sprintf("%s to %s by %s", from, to, actor), func(t *testing.T) {
+					booking := &Booking{ID: 1, Status: from}
+					change, err := booking.TransitionTo(to, actor, &actorID, "reason", now)
+
+					switch {
+					case !isListed:
+						var invalid *InvalidTransitionError
+						if !errors.As(err, &invalid) || !errors.Is(err, ErrInvalidTransition) {
+							t.Fatalf("error = %v, want InvalidTransitionError", err)
+						}
+						if invalid.From != from || invalid.To != to {
+							t.Fatalf("InvalidTransitionError = %s -> %s, want %s -> %s", invalid.From, invalid.To, from, to)
+						}
+						if errors.Is(err, ErrForbidden) {
+							t.Fatalf("error %v must not be ErrForbidden", err)
+						}
+
+					case !containsActor(actors, actor):
+						if !errors.Is(err, ErrForbidden) {
+							t.Fatalf("error = %v, want ErrForbidden", err)
+						}
+						if errors.Is(err, ErrInvalidTransition) {
+							t.Fatalf("error %v must not be ErrInvalidTransition", err)
+						}
+
+					default:
+						if err != nil {
+							t.Fatalf("TransitionTo: %v", err)
+						}
+						if booking.Status != to {
+							t.Fatalf("status = %s, want %s", booking.Status, to)
+						}
+						if change.BookingID != 1 || change.FromStatus != from || change.ToStatus != to || change.ActorType != actor ||
+							change.ActorID != &actorID || change.Reason != "reason" || !change.CreatedAt.Equal(now) {
+							t.Fatalf("change = %+v", change)
+						}
+						if !CanTransition(from, to) {
+							t.Fatalf("CanTransition(%s, %s) = false, want true", from, to)
+						}
+						return
+					}
+
+					if change != nil {
+						t.Fatalf("change = %+v, want nil", change)
+					}
+					if booking.Status != from {
+						t.Fatalf("status = %s after rejected transition, want %s", booking.Status, from)
+					}
+					if got := CanTransition(from, to); got != isListed {
+						t.Fatalf("CanTransition(%s, %s) = %v, want %v", from, to, got, isListed)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestPaymentTransitionTo(t *testing.T) {
+	allowed := map[[2]PaymentStatus]bool{
+		{PaymentPending, PaymentSuccess}:  true,
+		{PaymentPending, PaymentFailed}:   true,
+		{PaymentFailed, PaymentSuccess}:   true,
+		{PaymentSuccess, PaymentRefunded}: true,
+	}
+
+	now := time.Date(2026, 3, 6, 10, 0, 0, 0, time.UTC)
+
+	for _, from := range allPaymentStatuses {
+		for _, to := range allPaymentStatuses {
+			t.Run(fmt.Sprintf("%s to %s", from, to), func(t *testing.T) {
+				p := &Payment{ID: 1, Status: from}
+				change, err := p.TransitionTo(to, "reason", now)
+
+				if !allowed[[2]PaymentStatus{from, to}] {
+					if !errors.Is(err, ErrInvalidPaymentStatus) {
+						t.Fatalf("error = %v, want ErrInvalidPaymentStatus", err)
+					}
+					if change != nil || p.Status != from {
+						t.Fatalf("rejected transition changed payment: status %s, change %+v", p.Status, change)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("TransitionTo: %v", err)
+				}
+				if p.Status != to || !p.UpdatedAt.Equal(now) {
+					t.Fatalf("payment = %s updated %s, want %s updated %s", p.Status, p.UpdatedAt, to, now)
+				}
+				if change.PaymentID != 1 || change.FromStatus != from || change.ToStatus != to || change.Reason != "reason" {
+					t.Fatalf("change = %+v", change)
+				}
+			})
+		}
+	}
+}
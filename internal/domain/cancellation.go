@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// CancellationPolicy menentukan besar refund saat booking yang sudah dibayar dibatalkan.
+// Pembatalan paling lambat FullRefundHours jam sebelum mulai mendapat refund penuh,
+// pembatalan setelahnya sampai batas MinCancellationNotice mendapat
+// PartialRefundPercent persen. Booking tidak bisa dibatalkan setelah batas itu,
+// jadi FullRefundHours tidak boleh kurang dari MinCancellationNotice.
+type CancellationPolicy struct {
+	FullRefundHours      int
+	PartialRefundPercent int
+}
+
+// DefaultCancellationPolicy dipakai jika owner tidak mengatur kebijakan sendiri.
+var DefaultCancellationPolicy = CancellationPolicy{
+	FullRefundHours:      24,
+	PartialRefundPercent: 50,
+}
+
+// RefundAmount menghitung refund untuk pembayaran sebesar paid jika booking
+// yang mulai pada startTime dibatalkan pada now. Pemanggil sudah memastikan
+// booking masih bisa dibatalkan (Booking.CanBeCancelled).
+func (p CancellationPolicy) RefundAmount(paid int, startTime, now time.Time) int {
+	if startTime.Sub(now) >= time.Duration(p.FullRefundHours)*time.Hour {
+		return paid
+	}
+
+	return paid * p.PartialRefundPercent / 100
+}
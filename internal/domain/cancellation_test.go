@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCancellationPolicyRefundAmount(t *testing.T) {
+	policy := CancellationPolicy{FullRefundHours: 24, PartialRefundPercent: 50}
+	start := time.Date(2026, 3, 7, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		policy CancellationPolicy
+		paid   int
+		now    time.Time
+		want   int
+	}{
+		{"well before full refund window", policy, 150000, start.Add(-72 * time.Hour), 150000},
+		{"exactly at full refund boundary", policy, 150000, start.Add(-24 * time.Hour), 150000},
+		{"just inside partial window", policy, 150000, start.Add(-24*time.Hour + time.Second), 75000},
+		{"partial refund is floored", policy, 150001, start.Add(-3 * time.Hour), 75000},
+		{"custom partial percent", CancellationPolicy{FullRefundHours: 48, PartialRefundPercent: 25}, 200000, start.Add(-47 * time.Hour), 50000},
+		{"zero partial percent", CancellationPolicy{FullRefundHours: 24}, 150000, start.Add(-3 * time.Hour), 0},
+		{"partial refund down to the cancellation notice", policy, 150000, start.Add(-MinCancellationNotice - time.Second), 75000},
+		{"full refund window at the cancellation notice", CancellationPolicy{FullRefundHours: 2, PartialRefundPercent: 50}, 150000, start.Add(-MinCancellationNotice - time.Second), 150000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.RefundAmount(tt.paid, start, tt.now); got != tt.want {
+				t.Fatalf("RefundAmount(%d) at %s = %d, want %d", tt.paid, tt.now, got, tt.want)
+			}
+		})
+	}
+}
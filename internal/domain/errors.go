@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Error sentinel yang dipakai lintas layer. Repository dan service
+// mengembalikan error ini (atau membungkusnya dengan %w) supaya layer
+// delivery bisa memetakan ke HTTP status tanpa mencocokkan string.
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrFieldNotFound    = errors.New("field not found")
+	ErrBookingNotFound  = errors.New("booking not found")
+	ErrPaymentNotFound  = errors.New("payment not found")
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrRefundNotFound   = errors.New("refund not found")
+	ErrSeriesNotFound   = errors.New("booking series not found")
+	ErrWaitlistNotFound = errors.New("waitlist entry not found")
+	ErrPromoNotFound    = errors.New("promo code not found")
+	ErrVenueNotFound    = errors.New("venue not found")
+
+	ErrEmailAlreadyRegistered = errors.New("email already registered")
+	ErrInvalidCredentials     = errors.New("invalid email or password")
+	ErrInvalidToken           = errors.New("invalid or expired token")
+	ErrSessionRevoked         = errors.New("session has been revoked")
+	ErrForbidden              = errors.New("forbidden: you are not allowed to perform this action")
+	ErrSlotNotAvailable       = errors.New("time slot is not available")
+	ErrInvalidSignature       = errors.New("invalid payment notification signature")
+	ErrInvalidTransition      = errors.New("invalid booking status transition")
+	ErrInvalidPaymentStatus   = errors.New("invalid payment status transition")
+	ErrAlreadyOnWaitlist      = errors.New("already on the waitlist for this time slot")
+	ErrPromoCodeExists        = errors.New("promo code already exists")
+	ErrAmountMismatch         = errors.New("payment notification amount does not match")
+	ErrValidation             = errors.New("validation failed")
+)
+
+// ValidationError dikembalikan ketika request melanggar aturan input atau
+// aturan bisnis. Pesannya aman ditampilkan ke client.
+// errors.Is(err, ErrValidation) bernilai true, juga jika dibungkus dengan %w.
+type ValidationError struct {
+	Message string
+}
+
+// Invalidf membuat ValidationError dengan pesan yang diformat seperti fmt.Sprintf.
+func Invalidf(format string, args ...interface{}) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// SlotTakenError dikembalikan ketika rentang waktu yang diminta sudah
+// dipakai booking aktif lain. errors.Is(err, ErrSlotNotAvailable) bernilai true.
+type SlotTakenError struct {
+	FieldID   int
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+func (e *SlotTakenError) Error() string {
+	return fmt.Sprintf("time slot %s - %s is already taken",
+		e.StartTime.Format("2006-01-02 15:04"), e.EndTime.Format("15:04"))
+}
+
+func (e *SlotTakenError) Is(target error) bool {
+	return target == ErrSlotNotAvailable
+}
+
+// AmountMismatchError dikembalikan ketika nominal notifikasi pembayaran berbeda
+// dari nominal transaksi yang tercatat. errors.Is(err, ErrAmountMismatch) bernilai true.
+type AmountMismatchError struct {
+	PaymentID     int
+	TransactionID string
+	Received      int
+	Expected      int
+}
+
+func (e *AmountMismatchError) Error() string {
+	return fmt.Sprintf("notification amount %d for transaction %s does not match expected amount %d",
+		e.Received, e.TransactionID, e.Expected)
+}
+
+func (e *AmountMismatchError) Is(target error) bool {
+	return target == ErrAmountMismatch
+}
@@ -2,21 +2,62 @@ package domain
 
 import "time"
 
-type Field struct {
-	ID           int
-	OwnerID      int
-	Name         string
-	Address      string
-	Description  string
-	PricePerHour int
-	ImageURL     string
-	CreatedAt    time.Time
-}
+// DefaultPaymentHoldMinutes adalah lama slot ditahan untuk booking PENDING
+// jika owner tidak mengatur nilainya sendiri.
+const DefaultPaymentHoldMinutes = 15
 
-func (f *Field) CalculatePrice(hours int) int {
-	return f.PricePerHour * hours
+// Field adalah court yang bisa dibooking di bawah sebuah Venue.
+type Field struct {
+	ID      int
+	VenueID int
+	// OwnerID, Address, ImageURL, TimeZone, Amenities, dan Coordinates
+	// dibaca dari venue court ini dan tidak disimpan di tabel fields. Ubah
+	// lewat venue.
+	OwnerID            int
+	Address            string
+	ImageURL           string
+	TimeZone           string
+	Amenities          []string
+	Coordinates        *GeoPoint
+	Name               string
+	Description        string
+	PricePerHour       int
+	SurfaceType        SurfaceType
+	Indoor             bool
+	PaymentHoldMinutes int
+	CancellationPolicy CancellationPolicy
+	WaitlistPolicy     WaitlistPolicy
+	ReschedulePolicy   ReschedulePolicy
+	SlotPolicy         SlotPolicy
+	CreatedAt          time.Time
 }
 
 func (f *Field) IsOwnedBy(userID int) bool {
 	return f.OwnerID == userID
 }
+
+// Location mengembalikan zona waktu lapangan. Semua perhitungan jam dinding
+// (jadwal, grid slot, pricing rule) harus memakai waktu di zona ini, bukan
+// zona server atau zona yang dibawa request.
+func (f *Field) Location() *time.Location {
+	name := f.TimeZone
+	if name == "" {
+		name = DefaultTimeZone
+	}
+
+	loc, err := LoadTimeZone(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// HoldDeadline menghitung batas waktu pembayaran booking baru di lapangan ini.
+func (f *Field) HoldDeadline(now time.Time) time.Time {
+	minutes := f.PaymentHoldMinutes
+	if minutes <= 0 {
+		minutes = DefaultPaymentHoldMinutes
+	}
+
+	return now.Add(time.Duration(minutes) * time.Minute)
+}
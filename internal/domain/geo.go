@@ -0,0 +1,103 @@
+package domain
+
+import "math"
+
+// earthRadiusKm adalah jari-jari rata-rata bumi yang dipakai rumus haversine.
+const earthRadiusKm = 6371.0
+
+// GeoPoint adalah koordinat WGS84 dalam derajat.
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// IsValid mengecek apakah koordinat berada di rentang latitude/longitude.
+func (p GeoPoint) IsValid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+// DistanceKm menghitung jarak lingkaran besar ke other dengan rumus haversine.
+func (p GeoPoint) DistanceKm(other GeoPoint) float64 {
+	lat1 := p.Latitude * math.Pi / 180
+	lat2 := other.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (other.Longitude - p.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox adalah area peta antara SouthWest dan NorthEast. Jika
+// SouthWest.Longitude lebih besar dari NorthEast.Longitude, area melewati
+// garis bujur 180°.
+type BoundingBox struct {
+	SouthWest GeoPoint
+	NorthEast GeoPoint
+}
+
+// IsValid mengecek apakah kedua sudut valid dan SouthWest berada di selatan NorthEast.
+func (b BoundingBox) IsValid() bool {
+	return b.SouthWest.IsValid() && b.NorthEast.IsValid() && b.SouthWest.Latitude <= b.NorthEast.Latitude
+}
+
+// CrossesAntimeridian menandai area yang melewati garis bujur 180°.
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.SouthWest.Longitude > b.NorthEast.Longitude
+}
+
+// Contains mengecek apakah p berada di dalam area.
+func (b BoundingBox) Contains(p GeoPoint) bool {
+	if p.Latitude < b.SouthWest.Latitude || p.Latitude > b.NorthEast.Latitude {
+		return false
+	}
+
+	if b.CrossesAntimeridian() {
+		return p.Longitude >= b.SouthWest.Longitude || p.Longitude <= b.NorthEast.Longitude
+	}
+
+	return p.Longitude >= b.SouthWest.Longitude && p.Longitude <= b.NorthEast.Longitude
+}
+
+// BoundingBoxAround menghitung area persegi yang memuat lingkaran berjari-jari
+// radiusKm di sekitar center, dipakai untuk menyaring kandidat sebelum jarak
+// haversine dihitung. Dekat kutub area diperluas ke semua bujur.
+func BoundingBoxAround(center GeoPoint, radiusKm float64) BoundingBox {
+	dLat := radiusKm / earthRadiusKm * 180 / math.Pi
+
+	box := BoundingBox{
+		SouthWest: GeoPoint{Latitude: math.Max(-90, center.Latitude-dLat), Longitude: -180},
+		NorthEast: GeoPoint{Latitude: math.Min(90, center.Latitude+dLat), Longitude: 180},
+	}
+
+	cosLat := math.Cos(center.Latitude * math.Pi / 180)
+	if box.SouthWest.Latitude <= -90 || box.NorthEast.Latitude >= 90 || cosLat <= 0 {
+		return box
+	}
+
+	dLng := dLat / cosLat
+	if dLng >= 180 {
+		return box
+	}
+
+	box.SouthWest.Longitude = wrapLongitude(center.Longitude - dLng)
+	box.NorthEast.Longitude = wrapLongitude(center.Longitude + dLng)
+
+	return box
+}
+
+func wrapLongitude(lng float64) float64 {
+	switch {
+	case lng < -180:
+		return lng + 360
+	case lng > 180:
+		return lng - 360
+	}
+	return lng
+}
+
+// FieldDistance adalah lapangan beserta jaraknya dari titik pencarian.
+type FieldDistance struct {
+	Field      *Field
+	DistanceKm float64
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBoundingBoxAround(t *testing.T) {
+	// Satu derajat lintang sekitar 111.19 km
+	degreeKm := earthRadiusKm * math.Pi / 180
+
+	tests := []struct {
+		name        string
+		center      GeoPoint
+		radiusKm    float64
+		wantSW      GeoPoint
+		wantNE      GeoPoint
+		wantCrosses bool
+	}{
+		{
+			name:     "equator",
+			center:   GeoPoint{0, 100},
+			radiusKm: degreeKm,
+			wantSW:   GeoPoint{-1, 99},
+			wantNE:   GeoPoint{1, 101},
+		},
+		{
+			name:     "longitude widens away from the equator",
+			center:   GeoPoint{60, 10},
+			radiusKm: degreeKm,
+			wantSW:   GeoPoint{59, 8},
+			wantNE:   GeoPoint{61, 12},
+		},
+		{
+			name:        "crosses antimeridian eastward",
+			center:      GeoPoint{0, 179.5},
+			radiusKm:    degreeKm,
+			wantSW:      GeoPoint{-1, 178.5},
+			wantNE:      GeoPoint{1, -179.5},
+			wantCrosses: true,
+		},
+		{
+			name:        "crosses antimeridian westward",
+			center:      GeoPoint{0, -179.5},
+			radiusKm:    degreeKm,
+			wantSW:      GeoPoint{-1, 179.5},
+			wantNE:      GeoPoint{1, -178.5},
+			wantCrosses: true,
+		},
+		{
+			name:     "near the pole covers every longitude",
+			center:   GeoPoint{89.5, 10},
+			radiusKm: degreeKm,
+			wantSW:   GeoPoint{88.5, -180},
+			wantNE:   GeoPoint{90, 180},
+		},
+		{
+			name:     "huge radius covers every longitude",
+			center:   GeoPoint{0, 0},
+			radiusKm: 100 * degreeKm,
+			wantSW:   GeoPoint{-90, -180},
+			wantNE:   GeoPoint{90, 180},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := BoundingBoxAround(tt.center, tt.radiusKm)
+
+			if !closeTo(box.SouthWest, tt.wantSW) || !closeTo(box.NorthEast, tt.wantNE) {
+				t.Fatalf("BoundingBoxAround = %+v, want SW %+v NE %+v", box, tt.wantSW, tt.wantNE)
+			}
+			if box.CrossesAntimeridian() != tt.wantCrosses {
+				t.Fatalf("CrossesAntimeridian = %v, want %v", box.CrossesAntimeridian(), tt.wantCrosses)
+			}
+			if !box.IsValid() {
+				t.Fatalf("box %+v is not valid", box)
+			}
+			if !box.Contains(tt.center) {
+				t.Fatalf("box %+v does not contain its center", box)
+			}
+		})
+	}
+}
+
+func TestBoundingBoxContainsAcrossAntimeridian(t *testing.T) {
+	box := BoundingBox{SouthWest: GeoPoint{-10, 170}, NorthEast: GeoPoint{10, -170}}
+
+	tests := []struct {
+		name  string
+		point GeoPoint
+		want  bool
+	}{
+		{"west of the line", GeoPoint{0, 175}, true},
+		{"east of the line", GeoPoint{0, -175}, true},
+		{"on the line", GeoPoint{0, 180}, true},
+		{"outside in the middle", GeoPoint{0, 0}, false},
+		{"too far north", GeoPoint{11, 175}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := box.Contains(tt.point); got != tt.want {
+				t.Fatalf("Contains(%+v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistanceKm(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b GeoPoint
+		want float64
+	}{
+		{"same point", GeoPoint{-6.2, 106.8}, GeoPoint{-6.2, 106.8}, 0},
+		{"one degree of latitude", GeoPoint{0, 0}, GeoPoint{1, 0}, earthRadiusKm * math.Pi / 180},
+		{"across the antimeridian", GeoPoint{0, 179.5}, GeoPoint{0, -179.5}, earthRadiusKm * math.Pi / 180},
+		{"antipodes", GeoPoint{0, 0}, GeoPoint{0, 180}, earthRadiusKm * math.Pi},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.DistanceKm(tt.b); math.Abs(got-tt.want) > 1e-6 {
+				t.Fatalf("DistanceKm = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func closeTo(a, b GeoPoint) bool {
+	return math.Abs(a.Latitude-b.Latitude) < 1e-6 && math.Abs(a.Longitude-b.Longitude) < 1e-6
+}
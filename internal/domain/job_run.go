@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+type JobRunStatus string
+
+const (
+	JobRunRunning JobRunStatus = "RUNNING"
+	JobRunSuccess JobRunStatus = "SUCCESS"
+	JobRunFailed  JobRunStatus = "FAILED"
+)
+
+// JobRun adalah catatan satu kali eksekusi job terjadwal.
+type JobRun struct {
+	ID         int
+	JobName    string
+	InstanceID string
+	Status     JobRunStatus
+	// Processed adalah jumlah item yang diproses job, misalnya booking yang di-expire.
+	Processed  int
+	Error      string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+}
+
+func (j *JobRun) Finish(processed int, err error, now time.Time) {
+	j.Processed = processed
+	j.FinishedAt = &now
+	j.Status = JobRunSuccess
+	j.Error = ""
+
+	if err != nil {
+		j.Status = JobRunFailed
+		j.Error = err.Error()
+	}
+}
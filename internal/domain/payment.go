@@ -1,21 +1,29 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type PaymentStatus string
 
 const (
-	PaymentPending PaymentStatus = "PENDING"
-	PaymentSuccess PaymentStatus = "SUCCESS"
-	PaymentFailed  PaymentStatus = "FAILED"
+	PaymentPending  PaymentStatus = "PENDING"
+	PaymentSuccess  PaymentStatus = "SUCCESS"
+	PaymentFailed   PaymentStatus = "FAILED"
+	PaymentRefunded PaymentStatus = "REFUNDED"
 )
 
+// Payment dimiliki tepat satu booking, atau satu BookingSeries jika series
+// dibayar sekaligus di awal.
 type Payment struct {
 	ID             int
-	BookingID      int
+	BookingID      *int
+	SeriesID       *int
 	Amount         int
 	PaymentGateway string
 	TransactionID  string
+	PaymentURL     string
 	Status         PaymentStatus
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
@@ -33,12 +41,105 @@ func (p *Payment) IsFailed() bool {
 	return p.Status == PaymentFailed
 }
 
-func (p *Payment) MarkAsSuccess() {
-	p.Status = PaymentSuccess
-	p.UpdatedAt = time.Now()
+func (p *Payment) IsRefunded() bool {
+	return p.Status == PaymentRefunded
+}
+
+// paymentTransitions mendefinisikan transisi status payment yang diizinkan.
+// FAILED -> SUCCESS terjadi jika dana diterima gateway setelah payment
+// dianggap gagal (hold kadaluarsa atau booking dibatalkan); dananya tetap
+// dicatat lalu di-refund. REFUNDED adalah status akhir.
+var paymentTransitions = map[PaymentStatus][]PaymentStatus{
+	PaymentPending: {PaymentSuccess, PaymentFailed},
+	PaymentFailed:  {PaymentSuccess},
+	PaymentSuccess: {PaymentRefunded},
+}
+
+// PaymentStatusChange adalah satu baris riwayat perubahan status payment.
+type PaymentStatusChange struct {
+	ID         int
+	PaymentID  int
+	FromStatus PaymentStatus
+	ToStatus   PaymentStatus
+	Reason     string
+	CreatedAt  time.Time
+}
+
+// TransitionTo memindahkan payment ke status baru jika transisi tersebut
+// diizinkan, lalu mengembalikan catatan riwayatnya. Payment tidak diubah
+// jika transisi ditolak.
+func (p *Payment) TransitionTo(to PaymentStatus, reason string, now time.Time) (*PaymentStatusChange, error) {
+	allowed := false
+	for _, status := range paymentTransitions[p.Status] {
+		if status == to {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		return nil, fmt.Errorf("%w: payment cannot move from %s to %s", ErrInvalidPaymentStatus, p.Status, to)
+	}
+
+	change := &PaymentStatusChange{
+		PaymentID:  p.ID,
+		FromStatus: p.Status,
+		ToStatus:   to,
+		Reason:     reason,
+		CreatedAt:  now,
+	}
+
+	p.Status = to
+	p.UpdatedAt = now
+	return change, nil
+}
+
+// PaymentAdjustment adalah tagihan tambahan atas payment booking yang sudah
+// dibayar, misalnya selisih harga saat booking dipindah ke slot yang lebih
+// mahal. Punya transaksi gateway sendiri; kredit (selisih negatif) dicatat
+// sebagai Refund.
+type PaymentAdjustment struct {
+	ID            int
+	PaymentID     int
+	BookingID     int
+	Amount        int
+	Reason        string
+	TransactionID string
+	PaymentURL    string
+	Status        PaymentStatus
+	// ExpiresAt adalah batas pembayaran adjustment. Adjustment yang belum
+	// dibayar sampai batas ini membatalkan booking-nya.
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (a *PaymentAdjustment) IsPending() bool {
+	return a.Status == PaymentPending
+}
+
+func (a *PaymentAdjustment) IsSuccess() bool {
+	return a.Status == PaymentSuccess
+}
+
+func (a *PaymentAdjustment) MarkAsSuccess() {
+	a.Status = PaymentSuccess
+	a.UpdatedAt = time.Now()
+}
+
+func (a *PaymentAdjustment) MarkAsFailed() {
+	a.Status = PaymentFailed
+	a.UpdatedAt = time.Now()
 }
 
-func (p *Payment) MarkAsFailed() {
-	p.Status = PaymentFailed
-	p.UpdatedAt = time.Now()
+// SupersededCharge adalah charge gateway milik payment PENDING yang sudah
+// diganti charge baru, misalnya karena harga booking berubah saat reschedule.
+// Charge lama bisa tetap dibayar di gateway, jadi notifikasinya tetap dikenali
+// dan dananya dikembalikan.
+type SupersededCharge struct {
+	TransactionID string
+	PaymentID     int
+	BookingID     int
+	Amount        int
+	CreatedAt     time.Time
 }
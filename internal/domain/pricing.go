@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// MinutesPerDay adalah batas atas jendela waktu PricingRule (24:00).
+const MinutesPerDay = 24 * 60
+
+// PricingRule mengubah tarif per jam lapangan pada hari, jam, atau rentang
+// tanggal tertentu, misalnya tarif malam hari atau akhir pekan.
+type PricingRule struct {
+	ID      int
+	FieldID int
+	Name    string
+	// DayOfWeek membatasi rule ke satu hari. Nil berarti berlaku setiap hari.
+	DayOfWeek *DayOfWeek
+	// StartMinute dan EndMinute adalah jendela [StartMinute, EndMinute) dalam
+	// menit sejak tengah malam. Jendela tidak bisa melewati tengah malam.
+	StartMinute int
+	EndMinute   int
+	// StartDate dan EndDate membatasi rule ke rentang tanggal (inklusif).
+	// Nil berarti tanpa batas.
+	StartDate *time.Time
+	EndDate   *time.Time
+	// Tepat satu dari PricePerHour (tarif tetap) atau Multiplier (kali tarif
+	// dasar lapangan) diisi.
+	PricePerHour *int
+	Multiplier   *float64
+	// Priority menentukan rule yang menang jika beberapa rule berlaku
+	// bersamaan: nilai lebih besar menang, seri dimenangkan rule terbaru.
+	Priority  int
+	CreatedAt time.Time
+}
+
+// Matches mengecek apakah rule berlaku pada waktu t.
+func (r *PricingRule) Matches(t time.Time) bool {
+	if r.DayOfWeek != nil && *r.DayOfWeek != DayOfWeek(t.Weekday()) {
+		return false
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	if minute < r.StartMinute || minute >= r.EndMinute {
+		return false
+	}
+
+	date := civilDate(t)
+	if r.StartDate != nil && date.Before(civilDate(*r.StartDate)) {
+		return false
+	}
+
+	if r.EndDate != nil && date.After(civilDate(*r.EndDate)) {
+		return false
+	}
+
+	return true
+}
+
+// HourlyRate menghitung tarif per jam rule dari tarif dasar lapangan.
+func (r *PricingRule) HourlyRate(basePricePerHour int) int {
+	if r.PricePerHour != nil {
+		return *r.PricePerHour
+	}
+
+	return int(math.Round(float64(basePricePerHour) * *r.Multiplier))
+}
+
+// outranks mengecek apakah r menang atas other saat keduanya berlaku.
+func (r *PricingRule) outranks(other *PricingRule) bool {
+	if r.Priority != other.Priority {
+		return r.Priority > other.Priority
+	}
+
+	return r.ID > other.ID
+}
+
+// civilDate membuang jam dari t sehingga tanggal bisa dibandingkan tanpa
+// terpengaruh zona waktu penyimpanan.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// PriceLine adalah satu potongan booking yang dihargai dengan tarif yang sama.
+type PriceLine struct {
+	StartTime    time.Time
+	EndTime      time.Time
+	PricePerHour int
+	// RuleID nil berarti potongan ini memakai tarif dasar lapangan.
+	RuleID   *int
+	RuleName string
+	Amount   int
+}
+
+// Minutes mengembalikan panjang potongan dalam menit.
+func (l PriceLine) Minutes() int {
+	return int(l.EndTime.Sub(l.StartTime) / time.Minute)
+}
+
+// PriceBreakdown adalah rincian harga booking per potongan tarif.
+type PriceBreakdown struct {
+	Lines []PriceLine
+	Total int
+}
+
+// QuotePrice menghitung harga rentang [start, end) di lapangan field.
+// Rentang dipotong di setiap batas jendela rule dan tengah malam; tiap
+// potongan memakai rule berlaku dengan prioritas tertinggi, atau tarif dasar
+// lapangan jika tidak ada. Potongan berurutan dengan tarif yang sama digabung,
+// lalu harga tiap potongan dihitung prorata per menit dan dibulatkan ke bawah.
+// Rule dicocokkan dengan jam dinding di zona waktu lapangan.
+func QuotePrice(field *Field, rules []*PricingRule, start, end time.Time) PriceBreakdown {
+	breakdown := PriceBreakdown{Lines: []PriceLine{}}
+
+	loc := field.Location()
+	start, end = start.In(loc), end.In(loc)
+
+	for cursor := start; cursor.Before(end); {
+		next := nextPriceBoundary(rules, cursor, end)
+
+		line := PriceLine{StartTime: cursor, EndTime: next, PricePerHour: field.PricePerHour}
+		if rule := matchRule(rules, cursor); rule != nil {
+			line.PricePerHour = rule.HourlyRate(field.PricePerHour)
+			line.RuleID = &rule.ID
+			line.RuleName = rule.Name
+		}
+
+		if n := len(breakdown.Lines); n > 0 && breakdown.Lines[n-1].sameRate(line) {
+			breakdown.Lines[n-1].EndTime = next
+		} else {
+			breakdown.Lines = append(breakdown.Lines, line)
+		}
+
+		cursor = next
+	}
+
+	for i := range breakdown.Lines {
+		line := &breakdown.Lines[i]
+		line.Amount = line.PricePerHour * line.Minutes() / 60
+		breakdown.Total += line.Amount
+	}
+
+	return breakdown
+}
+
+func (l PriceLine) sameRate(other PriceLine) bool {
+	if l.PricePerHour != other.PricePerHour || (l.RuleID == nil) != (other.RuleID == nil) {
+		return false
+	}
+
+	return l.RuleID == nil || *l.RuleID == *other.RuleID
+}
+
+// matchRule mengembalikan rule berlaku dengan prioritas tertinggi pada t.
+func matchRule(rules []*PricingRule, t time.Time) *PricingRule {
+	var best *PricingRule
+
+	for _, rule := range rules {
+		if rule.Matches(t) && (best == nil || rule.outranks(best)) {
+			best = rule
+		}
+	}
+
+	return best
+}
+
+// nextPriceBoundary mencari waktu terdekat setelah cursor di mana rule yang
+// berlaku bisa berubah: awal/akhir jendela rule, tengah malam, atau end.
+func nextPriceBoundary(rules []*PricingRule, cursor, end time.Time) time.Time {
+	year, month, day := cursor.Date()
+
+	boundary := time.Date(year, month, day+1, 0, 0, 0, 0, cursor.Location())
+	if end.Before(boundary) {
+		boundary = end
+	}
+
+	for _, rule := range rules {
+		for _, minute := range []int{rule.StartMinute, rule.EndMinute} {
+			t := time.Date(year, month, day, 0, minute, 0, 0, cursor.Location())
+			if t.After(cursor) && t.Before(boundary) {
+				boundary = t
+			}
+		}
+	}
+
+	return boundary
+}
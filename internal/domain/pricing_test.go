@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func dayPtr(d DayOfWeek) *DayOfWeek {
+	return &d
+}
+
+type wantLine struct {
+	start, end time.Time
+	rate       int
+	ruleID     int
+	amount     int
+}
+
+func TestQuotePrice(t *testing.T) {
+	field := &Field{ID: 1, PricePerHour: 100000, TimeZone: "UTC"}
+
+	evening := &PricingRule{ID: 1, Name: "evening", StartMinute: 18 * 60, EndMinute: 22 * 60, PricePerHour: intPtr(150000)}
+	saturday := &PricingRule{ID: 2, Name: "weekend", DayOfWeek: dayPtr(DayOfWeek(time.Saturday)), StartMinute: 0, EndMinute: MinutesPerDay, PricePerHour: intPtr(200000)}
+	discount := &PricingRule{ID: 3, Name: "discount", StartMinute: 0, EndMinute: MinutesPerDay, PricePerHour: intPtr(80000), Priority: 1}
+	tieOlder := &PricingRule{ID: 4, Name: "older", StartMinute: 0, EndMinute: MinutesPerDay, PricePerHour: intPtr(90000), Priority: 5}
+	tieNewer := &PricingRule{ID: 5, Name: "newer", StartMinute: 0, EndMinute: MinutesPerDay, PricePerHour: intPtr(95000), Priority: 5}
+	halfHour := &PricingRule{ID: 6, Name: "half hour", StartMinute: 10*60 + 30, EndMinute: 11 * 60, PricePerHour: intPtr(103)}
+
+	tests := []struct {
+		name      string
+		field     *Field
+		rules     []*PricingRule
+		start     time.Time
+		end       time.Time
+		wantLines []wantLine
+		wantTotal int
+	}{
+		{
+			name:      "no rules",
+			field:     field,
+			start:     at(0, 10, 0),
+			end:       at(0, 12, 0),
+			wantLines: []wantLine{{at(0, 10, 0), at(0, 12, 0), 100000, 0, 200000}},
+			wantTotal: 200000,
+		},
+		{
+			name:  "split at rule start",
+			field: field,
+			rules: []*PricingRule{evening},
+			start: at(0, 17, 0),
+			end:   at(0, 19, 0),
+			wantLines: []wantLine{
+				{at(0, 17, 0), at(0, 18, 0), 100000, 0, 100000},
+				{at(0, 18, 0), at(0, 19, 0), 150000, 1, 150000},
+			},
+			wantTotal: 250000,
+		},
+		{
+			name:  "split at rule end",
+			field: field,
+			rules: []*PricingRule{evening},
+			start: at(0, 21, 30),
+			end:   at(0, 22, 30),
+			wantLines: []wantLine{
+				{at(0, 21, 30), at(0, 22, 0), 150000, 1, 75000},
+				{at(0, 22, 0), at(0, 22, 30), 100000, 0, 50000},
+			},
+			wantTotal: 125000,
+		},
+		{
+			name:      "inside rule window",
+			field:     field,
+			rules:     []*PricingRule{evening},
+			start:     at(0, 19, 0),
+			end:       at(0, 20, 30),
+			wantLines: []wantLine{{at(0, 19, 0), at(0, 20, 30), 150000, 1, 225000}},
+			wantTotal: 225000,
+		},
+		{
+			name:  "higher priority wins",
+			field: field,
+			rules: []*PricingRule{evening, discount},
+			start: at(0, 18, 0),
+			end:   at(0, 19, 0),
+			wantLines: []wantLine{
+				{at(0, 18, 0), at(0, 19, 0), 80000, 3, 80000},
+			},
+			wantTotal: 80000,
+		},
+		{
+			name:      "priority tie goes to newest rule",
+			field:     field,
+			rules:     []*PricingRule{tieNewer, tieOlder},
+			start:     at(0, 10, 0),
+			end:       at(0, 11, 0),
+			wantLines: []wantLine{{at(0, 10, 0), at(0, 11, 0), 95000, 5, 95000}},
+			wantTotal: 95000,
+		},
+		{
+			name:  "midnight crossing into weekday rule",
+			field: field,
+			rules: []*PricingRule{saturday},
+			start: at(0, 23, 0),
+			end:   at(1, 1, 0),
+			wantLines: []wantLine{
+				{at(0, 23, 0), at(1, 0, 0), 100000, 0, 100000},
+				{at(1, 0, 0), at(1, 1, 0), 200000, 2, 200000},
+			},
+			wantTotal: 300000,
+		},
+		{
+			name:      "midnight crossing with same rate is merged",
+			field:     field,
+			start:     at(0, 23, 0),
+			end:       at(1, 1, 0),
+			wantLines: []wantLine{{at(0, 23, 0), at(1, 1, 0), 100000, 0, 200000}},
+			wantTotal: 200000,
+		},
+		{
+			// 101*30/60 = 50.5 dan 103*30/60 = 51.5, masing-masing dibulatkan ke bawah
+			name:  "each line is floored",
+			field: &Field{ID: 2, PricePerHour: 101, TimeZone: "UTC"},
+			rules: []*PricingRule{halfHour},
+			start: at(0, 10, 0),
+			end:   at(0, 11, 0),
+			wantLines: []wantLine{
+				{at(0, 10, 0), at(0, 10, 30), 101, 0, 50},
+				{at(0, 10, 30), at(0, 11, 0), 103, 6, 51},
+			},
+			wantTotal: 101,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := QuotePrice(tt.field, tt.rules, tt.start, tt.end)
+
+			if got.Total != tt.wantTotal {
+				t.Errorf("Total = %d, want %d", got.Total, tt.wantTotal)
+			}
+
+			if len(got.Lines) != len(tt.wantLines) {
+				t.Fatalf("got %d lines, want %d: %+v", len(got.Lines), len(tt.wantLines), got.Lines)
+			}
+
+			for i, want := range tt.wantLines {
+				line := got.Lines[i]
+
+				if !line.StartTime.Equal(want.start) || !line.EndTime.Equal(want.end) {
+					t.Errorf("line %d = %s-%s, want %s-%s", i, line.StartTime, line.EndTime, want.start, want.end)
+				}
+
+				if line.PricePerHour != want.rate {
+					t.Errorf("line %d rate = %d, want %d", i, line.PricePerHour, want.rate)
+				}
+
+				ruleID := 0
+				if line.RuleID != nil {
+					ruleID = *line.RuleID
+				}
+				if ruleID != want.ruleID {
+					t.Errorf("line %d rule = %d, want %d", i, ruleID, want.ruleID)
+				}
+
+				if line.Amount != want.amount {
+					t.Errorf("line %d amount = %d, want %d", i, line.Amount, want.amount)
+				}
+			}
+		})
+	}
+}
+
+func TestPricingRuleHourlyRate(t *testing.T) {
+	tests := []struct {
+		name string
+		rule PricingRule
+		base int
+		want int
+	}{
+		{"fixed price", PricingRule{PricePerHour: intPtr(120000)}, 100000, 120000},
+		{"multiplier", PricingRule{Multiplier: floatPtr(1.5)}, 100000, 150000},
+		{"multiplier rounds to nearest", PricingRule{Multiplier: floatPtr(0.333)}, 100, 33},
+		{"tiny multiplier rounds to zero", PricingRule{Multiplier: floatPtr(0.001)}, 100, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.HourlyRate(tt.base); got != tt.want {
+				t.Fatalf("HourlyRate(%d) = %d, want %d", tt.base, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuotePriceUsesFieldTimeZone(t *testing.T) {
+	field := &Field{ID: 1, PricePerHour: 100000, TimeZone: "Asia/Jakarta"}
+	evening := &PricingRule{ID: 1, StartMinute: 18 * 60, EndMinute: 22 * 60, PricePerHour: intPtr(150000)}
+
+	// 11:00 UTC adalah 18:00 WIB
+	got := QuotePrice(field, []*PricingRule{evening}, at(0, 11, 0), at(0, 12, 0))
+
+	if got.Total != 150000 {
+		t.Fatalf("Total = %d, want 150000", got.Total)
+	}
+}
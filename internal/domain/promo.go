@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type DiscountType string
+
+const (
+	DiscountPercentage DiscountType = "PERCENTAGE"
+	DiscountFixed      DiscountType = "FIXED"
+)
+
+// PromoCode adalah kode diskon yang bisa dipakai customer saat membuat booking.
+type PromoCode struct {
+	ID   int
+	Code string
+	// CreatedBy adalah admin atau owner pembuat promo.
+	CreatedBy     int
+	DiscountType  DiscountType
+	DiscountValue int
+	// FieldIDs membatasi lapangan tempat promo berlaku. Kosong berarti semua lapangan.
+	FieldIDs []int
+	// MinSpend adalah harga booking minimal (sebelum diskon) agar promo berlaku.
+	MinSpend   int
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+	// MaxUses dan MaxUsesPerUser membatasi jumlah redemption aktif. 0 berarti tanpa batas.
+	MaxUses        int
+	MaxUsesPerUser int
+	// FirstBookingOnly membatasi promo untuk customer yang belum pernah booking.
+	FirstBookingOnly bool
+	Active           bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// NormalizePromoCode menyeragamkan kode promo supaya tidak case-sensitive.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsValidAt mengecek apakah promo aktif dan berada di periode berlakunya.
+func (p *PromoCode) IsValidAt(now time.Time) bool {
+	if !p.Active {
+		return false
+	}
+
+	if p.ValidFrom != nil && now.Before(*p.ValidFrom) {
+		return false
+	}
+
+	return p.ValidUntil == nil || now.Before(*p.ValidUntil)
+}
+
+func (p *PromoCode) AppliesToField(fieldID int) bool {
+	if len(p.FieldIDs) == 0 {
+		return true
+	}
+
+	for _, id := range p.FieldIDs {
+		if id == fieldID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Discount menghitung potongan untuk harga amount, tidak pernah melebihi amount.
+func (p *PromoCode) Discount(amount int) int {
+	discount := p.DiscountValue
+	if p.DiscountType == DiscountPercentage {
+		discount = amount * p.DiscountValue / 100
+	}
+
+	if discount > amount {
+		return amount
+	}
+
+	return discount
+}
+
+func (p *PromoCode) IsCreatedBy(userID int) bool {
+	return p.CreatedBy == userID
+}
+
+type PromoRedemptionStatus string
+
+const (
+	RedemptionActive   PromoRedemptionStatus = "ACTIVE"
+	RedemptionReleased PromoRedemptionStatus = "RELEASED"
+)
+
+// PromoRedemption mencatat pemakaian promo oleh satu booking. Redemption
+// dilepas (RELEASED) saat booking dibatalkan atau expired sehingga kuota
+// promo kembali tersedia.
+type PromoRedemption struct {
+	ID             int
+	PromoCodeID    int
+	BookingID      int
+	UserID         int
+	DiscountAmount int
+	Status         PromoRedemptionStatus
+	CreatedAt      time.Time
+	ReleasedAt     *time.Time
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+type RefundStatus string
+
+const (
+	RefundPending RefundStatus = "PENDING"
+	RefundSuccess RefundStatus = "SUCCESS"
+	RefundFailed  RefundStatus = "FAILED"
+)
+
+type Refund struct {
+	ID        int
+	BookingID int
+	PaymentID int
+	Amount    int
+	Reason    string
+	// RefundKey dikirim ke payment gateway sebagai idempotency key,
+	// sehingga refund yang diulang tidak dicairkan dua kali.
+	RefundKey string
+	// TransactionID diisi jika refund mengembalikan transaksi gateway selain
+	// transaksi utama payment (charge lama yang sudah diganti atau
+	// PaymentAdjustment). Refund seperti ini tidak mengurangi nominal payment.
+	TransactionID string
+	Status        RefundStatus
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (r *Refund) IsPending() bool {
+	return r.Status == RefundPending
+}
+
+func (r *Refund) IsSuccess() bool {
+	return r.Status == RefundSuccess
+}
+
+func (r *Refund) MarkAsSuccess() {
+	r.Status = RefundSuccess
+	r.FailureReason = ""
+	r.UpdatedAt = time.Now()
+}
+
+func (r *Refund) MarkAsFailed(reason string) {
+	r.Status = RefundFailed
+	r.FailureReason = reason
+	r.UpdatedAt = time.Now()
+}
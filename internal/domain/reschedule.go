@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// ReschedulePolicy menentukan kapan dan berapa kali booking boleh dipindah
+// ke slot lain tanpa dibatalkan.
+type ReschedulePolicy struct {
+	// MinHoursBefore adalah batas reschedule: paling lambat sekian jam sebelum booking dimulai.
+	MinHoursBefore int
+	// MaxReschedules adalah jumlah maksimal reschedule per booking. 0 berarti reschedule tidak diizinkan.
+	MaxReschedules int
+}
+
+// DefaultReschedulePolicy dipakai jika owner tidak mengatur kebijakan sendiri.
+var DefaultReschedulePolicy = ReschedulePolicy{
+	MinHoursBefore: 2,
+	MaxReschedules: 1,
+}
+
+// AllowsAt mengecek apakah booking yang mulai pada startTime masih boleh
+// di-reschedule pada now.
+func (p ReschedulePolicy) AllowsAt(startTime, now time.Time) bool {
+	return !now.Add(time.Duration(p.MinHoursBefore) * time.Hour).After(startTime)
+}
+
+// BookingReschedule mencatat satu perpindahan jadwal booking beserta perubahan harganya.
+type BookingReschedule struct {
+	ID           int
+	BookingID    int
+	ActorID      *int
+	OldStartTime time.Time
+	OldEndTime   time.Time
+	NewStartTime time.Time
+	NewEndTime   time.Time
+	OldPrice     int
+	NewPrice     int
+	CreatedAt    time.Time
+}
+
+// PriceDelta adalah selisih harga setelah reschedule: positif berarti
+// customer perlu membayar tambahan, negatif berarti customer mendapat kredit.
+func (r *BookingReschedule) PriceDelta() int {
+	return r.NewPrice - r.OldPrice
+}
@@ -22,23 +22,78 @@ type Schedule struct {
 	CloseTime time.Time
 }
 
+// minutesPerWeek dipakai untuk membandingkan jendela jadwal lintas hari.
+const minutesPerWeek = 7 * MinutesPerDay
+
+// IsOvernight menandai jendela yang tutup setelah tengah malam, misalnya
+// 15:00-02:00. CloseTime yang tidak lebih besar dari OpenTime berarti jam
+// tutup jatuh pada hari berikutnya.
+func (s *Schedule) IsOvernight() bool {
+	return clockMinutes(s.CloseTime) <= clockMinutes(s.OpenTime)
+}
+
+// WindowOn mengembalikan jendela jam buka jadwal yang dimulai pada tanggal
+// date, di zona waktu date. Hari jadwal tidak dicek.
+func (s *Schedule) WindowOn(date time.Time) OpenWindow {
+	midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+
+	end := midnight.Add(time.Duration(clockMinutes(s.CloseTime)) * time.Minute)
+	if s.IsOvernight() {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return OpenWindow{
+		Start: midnight.Add(time.Duration(clockMinutes(s.OpenTime)) * time.Minute),
+		End:   end,
+	}
+}
+
+// IsOpen mengecek apakah t berada di jendela jadwal, termasuk bagian jendela
+// hari sebelumnya yang melewati tengah malam.
 func (s *Schedule) IsOpen(t time.Time) bool {
-	day := DayOfWeek(t.Weekday())
+	for _, date := range []time.Time{t, t.AddDate(0, 0, -1)} {
+		if DayOfWeek(date.Weekday()) != s.DayOfWeek {
+			continue
+		}
 
-	if day != s.DayOfWeek {
-		return false
+		window := s.WindowOn(date)
+		if !t.Before(window.Start) && !t.After(window.End) {
+			return true
+		}
 	}
 
-	checkHour, checkMin, checkSec := t.Clock()
-	checkTime := checkHour*3600 + checkMin*60 + checkSec
+	return false
+}
+
+// Overlaps mengecek apakah dua jendela jadwal beririsan dalam satu minggu,
+// termasuk jendela Sabtu malam yang berlanjut ke Minggu dini hari.
+func (s *Schedule) Overlaps(other *Schedule) bool {
+	start, end := s.weekRange()
+	otherStart, otherEnd := other.weekRange()
+
+	for _, shift := range []int{-minutesPerWeek, 0, minutesPerWeek} {
+		if start < otherEnd+shift && otherStart+shift < end {
+			return true
+		}
+	}
 
-	openHour, openMin, openSec := s.OpenTime.Clock()
-	openTimeInSec := openHour*3600 + openMin*60 + openSec
+	return false
+}
+
+// weekRange mengembalikan jendela jadwal dalam menit sejak Minggu 00:00.
+func (s *Schedule) weekRange() (int, int) {
+	start := int(s.DayOfWeek)*MinutesPerDay + clockMinutes(s.OpenTime)
+	end := int(s.DayOfWeek)*MinutesPerDay + clockMinutes(s.CloseTime)
+	if s.IsOvernight() {
+		end += MinutesPerDay
+	}
 
-	closeHour, closeMin, closeSec := s.CloseTime.Clock()
-	closeTimeInSec := closeHour*3600 + closeMin*60 + closeSec
+	return start, end
+}
 
-	return checkTime >= openTimeInSec && checkTime <= closeTimeInSec
+func clockMinutes(t time.Time) int {
+	hour, min, _ := t.Clock()
+	return hour*60 + min
 }
 
 func (s *Schedule) GetDayName() string {
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+type ScheduleExceptionType string
+
+const (
+	// ExceptionClosed menutup lapangan sepanjang hari, misalnya saat Lebaran.
+	ExceptionClosed ScheduleExceptionType = "CLOSED"
+	// ExceptionClosedWindow menutup lapangan pada jendela StartMinute-EndMinute,
+	// misalnya untuk maintenance.
+	ExceptionClosedWindow ScheduleExceptionType = "CLOSED_WINDOW"
+	// ExceptionSpecialHours mengganti jam buka mingguan pada tanggal tersebut
+	// dengan StartMinute-EndMinute, misalnya untuk hari turnamen.
+	ExceptionSpecialHours ScheduleExceptionType = "SPECIAL_HOURS"
+)
+
+// ScheduleException mengubah jadwal mingguan lapangan pada satu tanggal.
+type ScheduleException struct {
+	ID      int
+	FieldID int
+	// Date adalah tanggal yang terpengaruh; jamnya diabaikan.
+	Date time.Time
+	Type ScheduleExceptionType
+	// StartMinute dan EndMinute adalah jendela [StartMinute, EndMinute) dalam
+	// menit sejak tengah malam. Tidak dipakai untuk ExceptionClosed.
+	StartMinute int
+	EndMinute   int
+	Reason      string
+	CreatedAt   time.Time
+}
+
+// AppliesTo mengecek apakah exception berlaku pada tanggal t.
+func (e *ScheduleException) AppliesTo(t time.Time) bool {
+	return civilDate(e.Date).Equal(civilDate(t))
+}
+
+// OpenWindow adalah rentang jam buka [Start, End) pada satu tanggal.
+type OpenWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// OpenWindowsOn menghitung jam buka lapangan yang dimulai pada tanggal date,
+// di zona waktu date. Jendela yang melewati tengah malam tetap milik tanggal
+// mulainya, sehingga exception pada tanggal tersebut juga berlaku untuk
+// bagian setelah tengah malam. Jadwal mingguan dipakai kecuali ada
+// ExceptionSpecialHours pada tanggal tersebut; lalu ExceptionClosed dan
+// ExceptionClosedWindow mengurangi jam buka yang tersisa. ExceptionClosed dan
+// ExceptionClosedWindow pada tanggal berikutnya juga memotong bagian jendela
+// yang berlanjut ke tanggal tersebut, misalnya maintenance 00:00-02:00.
+func OpenWindowsOn(schedules []*Schedule, exceptions []*ScheduleException, date time.Time) []OpenWindow {
+	midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	at := func(minute int) time.Time {
+		return midnight.Add(time.Duration(minute) * time.Minute)
+	}
+
+	windows := []OpenWindow{}
+	special := false
+
+	for _, e := range exceptions {
+		if e.Type == ExceptionSpecialHours && e.AppliesTo(date) {
+			windows = append(windows, OpenWindow{Start: at(e.StartMinute), End: at(e.EndMinute)})
+			special = true
+		}
+	}
+
+	if !special {
+		for _, s := range schedules {
+			if s.DayOfWeek != DayOfWeek(date.Weekday()) {
+				continue
+			}
+
+			windows = append(windows, s.WindowOn(midnight))
+		}
+	}
+
+	for _, e := range exceptions {
+		if !e.AppliesTo(date) {
+			continue
+		}
+
+		switch e.Type {
+		case ExceptionClosed:
+			return []OpenWindow{}
+		case ExceptionClosedWindow:
+			windows = subtractWindow(windows, OpenWindow{Start: at(e.StartMinute), End: at(e.EndMinute)})
+		}
+	}
+
+	next := midnight.AddDate(0, 0, 1)
+	atNext := func(minute int) time.Time {
+		return next.Add(time.Duration(minute) * time.Minute)
+	}
+
+	for _, e := range exceptions {
+		if !e.AppliesTo(next) {
+			continue
+		}
+
+		switch e.Type {
+		case ExceptionClosed:
+			windows = subtractWindow(windows, OpenWindow{Start: next, End: next.AddDate(0, 0, 1)})
+		case ExceptionClosedWindow:
+			windows = subtractWindow(windows, OpenWindow{Start: atNext(e.StartMinute), End: atNext(e.EndMinute)})
+		}
+	}
+
+	return mergeWindows(windows)
+}
+
+// mergeWindows mengurutkan windows berdasarkan Start lalu menggabungkan
+// jendela yang beririsan atau bersambung, misalnya 08:00-12:00 dan
+// 12:00-22:00 menjadi 08:00-22:00, supaya booking yang melewati batasnya
+// tetap diterima.
+func mergeWindows(windows []OpenWindow) []OpenWindow {
+	sort.Slice(windows, func(i, j int) bool { return windows[i].Start.Before(windows[j].Start) })
+
+	merged := make([]OpenWindow, 0, len(windows))
+	for _, w := range windows {
+		last := len(merged) - 1
+		if last >= 0 && !w.Start.After(merged[last].End) {
+			if w.End.After(merged[last].End) {
+				merged[last].End = w.End
+			}
+			continue
+		}
+
+		merged = append(merged, w)
+	}
+
+	return merged
+}
+
+// subtractWindow membuang bagian windows yang beririsan dengan closed.
+func subtractWindow(windows []OpenWindow, closed OpenWindow) []OpenWindow {
+	result := make([]OpenWindow, 0, len(windows)+1)
+
+	for _, w := range windows {
+		if !closed.Start.Before(w.End) || !closed.End.After(w.Start) {
+			result = append(result, w)
+			continue
+		}
+
+		if w.Start.Before(closed.Start) {
+			result = append(result, OpenWindow{Start: w.Start, End: closed.Start})
+		}
+
+		if closed.End.Before(w.End) {
+			result = append(result, OpenWindow{Start: closed.End, End: w.End})
+		}
+	}
+
+	return result
+}
+
+// IsOpenBetween mengecek apakah rentang [start, end) berada di dalam satu
+// jendela jam buka lapangan setelah exception diterapkan. Jendela tanggal
+// sebelumnya ikut dicek karena bisa berlanjut melewati tengah malam, dan
+// digabung dengan jendela tanggal start jika keduanya bersambung.
+func IsOpenBetween(schedules []*Schedule, exceptions []*ScheduleException, start, end time.Time) bool {
+	windows := OpenWindowsOn(schedules, exceptions, start.AddDate(0, 0, -1))
+	windows = append(windows, OpenWindowsOn(schedules, exceptions, start)...)
+
+	for _, w := range mergeWindows(windows) {
+		if !start.Before(w.Start) && !end.After(w.End) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// clock membuat jam jadwal seperti yang dibaca dari kolom TIME.
+func clock(hour, minute int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestOpenWindowsOnNextDayExceptions(t *testing.T) {
+	// Jumat 18:00 sampai Sabtu 02:00
+	friday := &Schedule{DayOfWeek: Friday, OpenTime: clock(18, 0), CloseTime: clock(2, 0)}
+	saturday := at(1, 0, 0)
+
+	tests := []struct {
+		name       string
+		exceptions []*ScheduleException
+		want       []OpenWindow
+	}{
+		{
+			name: "no exceptions",
+			want: []OpenWindow{{at(0, 18, 0), at(1, 2, 0)}},
+		},
+		{
+			name: "closed window on next day cuts the overnight tail",
+			exceptions: []*ScheduleException{
+				{Date: saturday, Type: ExceptionClosedWindow, StartMinute: 0, EndMinute: 60},
+			},
+			want: []OpenWindow{
+				{at(0, 18, 0), at(1, 0, 0)},
+				{at(1, 1, 0), at(1, 2, 0)},
+			},
+		},
+		{
+			name: "closed next day ends the window at midnight",
+			exceptions: []*ScheduleException{
+				{Date: saturday, Type: ExceptionClosed},
+			},
+			want: []OpenWindow{{at(0, 18, 0), at(1, 0, 0)}},
+		},
+		{
+			name: "next day closed window after the tail is ignored",
+			exceptions: []*ScheduleException{
+				{Date: saturday, Type: ExceptionClosedWindow, StartMinute: 2 * 60, EndMinute: 4 * 60},
+			},
+			want: []OpenWindow{{at(0, 18, 0), at(1, 2, 0)}},
+		},
+		{
+			name: "next day special hours do not replace the tail",
+			exceptions: []*ScheduleException{
+				{Date: saturday, Type: ExceptionSpecialHours, StartMinute: 8 * 60, EndMinute: 12 * 60},
+			},
+			want: []OpenWindow{{at(0, 18, 0), at(1, 2, 0)}},
+		},
+		{
+			name: "same day closed window still applies after midnight",
+			exceptions: []*ScheduleException{
+				{Date: at(0, 0, 0), Type: ExceptionClosedWindow, StartMinute: 23 * 60, EndMinute: 25 * 60},
+			},
+			want: []OpenWindow{
+				{at(0, 18, 0), at(0, 23, 0)},
+				{at(1, 1, 0), at(1, 2, 0)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OpenWindowsOn([]*Schedule{friday}, tt.exceptions, at(0, 0, 0))
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d windows, want %d: %+v", len(got), len(tt.want), got)
+			}
+
+			for i, want := range tt.want {
+				if !got[i].Start.Equal(want.Start) || !got[i].End.Equal(want.End) {
+					t.Errorf("window %d = %s-%s, want %s-%s", i, got[i].Start, got[i].End, want.Start, want.End)
+				}
+			}
+		})
+	}
+}
+
+func TestIsOpenBetweenNextDayMaintenance(t *testing.T) {
+	friday := &Schedule{DayOfWeek: Friday, OpenTime: clock(18, 0), CloseTime: clock(2, 0)}
+	maintenance := &ScheduleException{Date: at(1, 0, 0), Type: ExceptionClosedWindow, StartMinute: 0, EndMinute: 60}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       bool
+	}{
+		{"before midnight", at(0, 22, 0), at(1, 0, 0), true},
+		{"across midnight into maintenance", at(0, 23, 0), at(1, 0, 30), false},
+		{"inside maintenance", at(1, 0, 0), at(1, 1, 0), false},
+		{"after maintenance", at(1, 1, 0), at(1, 2, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsOpenBetween([]*Schedule{friday}, []*ScheduleException{maintenance}, tt.start, tt.end)
+			if got != tt.want {
+				t.Fatalf("IsOpenBetween(%s, %s) = %v, want %v", tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenWindowsOnMergesTouchingWindows(t *testing.T) {
+	tests := []struct {
+		name       string
+		schedules  []*Schedule
+		exceptions []*ScheduleException
+		want       []OpenWindow
+	}{
+		{
+			name: "touching windows",
+			schedules: []*Schedule{
+				{DayOfWeek: Friday, OpenTime: clock(12, 0), CloseTime: clock(22, 0)},
+				{DayOfWeek: Friday, OpenTime: clock(8, 0), CloseTime: clock(12, 0)},
+			},
+			want: []OpenWindow{{at(0, 8, 0), at(0, 22, 0)}},
+		},
+		{
+			name: "separate windows",
+			schedules: []*Schedule{
+				{DayOfWeek: Friday, OpenTime: clock(8, 0), CloseTime: clock(12, 0)},
+				{DayOfWeek: Friday, OpenTime: clock(15, 0), CloseTime: clock(2, 0)},
+			},
+			want: []OpenWindow{
+				{at(0, 8, 0), at(0, 12, 0)},
+				{at(0, 15, 0), at(1, 2, 0)},
+			},
+		},
+		{
+			name: "overlapping special hours",
+			exceptions: []*ScheduleException{
+				{Date: at(0, 0, 0), Type: ExceptionSpecialHours, StartMinute: 8 * 60, EndMinute: 14 * 60},
+				{Date: at(0, 0, 0), Type: ExceptionSpecialHours, StartMinute: 13 * 60, EndMinute: 18 * 60},
+			},
+			want: []OpenWindow{{at(0, 8, 0), at(0, 18, 0)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OpenWindowsOn(tt.schedules, tt.exceptions, at(0, 0, 0))
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d windows, want %d: %+v", len(got), len(tt.want), got)
+			}
+
+			for i, want := range tt.want {
+				if !got[i].Start.Equal(want.Start) || !got[i].End.Equal(want.End) {
+					t.Errorf("window %d = %s-%s, want %s-%s", i, got[i].Start, got[i].End, want.Start, want.End)
+				}
+			}
+		})
+	}
+}
+
+func TestIsOpenBetweenTouchingWindows(t *testing.T) {
+	schedules := []*Schedule{
+		{DayOfWeek: Friday, OpenTime: clock(8, 0), CloseTime: clock(12, 0)},
+		{DayOfWeek: Friday, OpenTime: clock(12, 0), CloseTime: clock(2, 0)},
+		{DayOfWeek: Saturday, OpenTime: clock(2, 0), CloseTime: clock(6, 0)},
+	}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       bool
+	}{
+		{"across same day boundary", at(0, 11, 0), at(0, 13, 0), true},
+		{"across overnight tail into next day window", at(1, 1, 0), at(1, 3, 0), true},
+		{"before opening", at(0, 7, 0), at(0, 9, 0), false},
+		{"past closing", at(1, 5, 0), at(1, 7, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsOpenBetween(schedules, nil, tt.start, tt.end)
+			if got != tt.want {
+				t.Fatalf("IsOpenBetween(%s, %s) = %v, want %v", tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleIsOvernight(t *testing.T) {
+	tests := []struct {
+		name        string
+		open, close time.Time
+		want        bool
+	}{
+		{"same day", clock(8, 0), clock(22, 0), false},
+		{"closes after midnight", clock(15, 0), clock(2, 0), true},
+		{"closes at midnight", clock(18, 0), clock(0, 0), true},
+		{"open all day", clock(6, 0), clock(6, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Schedule{DayOfWeek: Friday, OpenTime: tt.open, CloseTime: tt.close}
+			if got := s.IsOvernight(); got != tt.want {
+				t.Fatalf("IsOvernight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleWindowOn(t *testing.T) {
+	tests := []struct {
+		name        string
+		open, close time.Time
+		want        OpenWindow
+	}{
+		{"same day", clock(8, 0), clock(22, 0), OpenWindow{at(0, 8, 0), at(0, 22, 0)}},
+		{"overnight", clock(15, 0), clock(2, 0), OpenWindow{at(0, 15, 0), at(1, 2, 0)}},
+		{"closes at midnight", clock(18, 0), clock(0, 0), OpenWindow{at(0, 18, 0), at(1, 0, 0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Schedule{DayOfWeek: Friday, OpenTime: tt.open, CloseTime: tt.close}
+			got := s.WindowOn(at(0, 13, 0))
+
+			if !got.Start.Equal(tt.want.Start) || !got.End.Equal(tt.want.End) {
+				t.Fatalf("WindowOn() = %s-%s, want %s-%s", got.Start, got.End, tt.want.Start, tt.want.End)
+			}
+		})
+	}
+}
+
+func TestScheduleIsOpen(t *testing.T) {
+	overnight := &Schedule{DayOfWeek: Friday, OpenTime: clock(15, 0), CloseTime: clock(2, 0)}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"before opening", at(0, 14, 59), false},
+		{"at opening", at(0, 15, 0), true},
+		{"before midnight", at(0, 23, 30), true},
+		{"after midnight on next day", at(1, 1, 30), true},
+		{"after closing on next day", at(1, 2, 1), false},
+		{"after midnight on the schedule day", at(0, 1, 30), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overnight.IsOpen(tt.t); got != tt.want {
+				t.Fatalf("IsOpen(%s) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleOverlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *Schedule
+		want bool
+	}{
+		{
+			name: "different days",
+			a:    &Schedule{DayOfWeek: Monday, OpenTime: clock(8, 0), CloseTime: clock(22, 0)},
+			b:    &Schedule{DayOfWeek: Tuesday, OpenTime: clock(8, 0), CloseTime: clock(22, 0)},
+			want: false,
+		},
+		{
+			name: "same day overlapping",
+			a:    &Schedule{DayOfWeek: Monday, OpenTime: clock(8, 0), CloseTime: clock(12, 0)},
+			b:    &Schedule{DayOfWeek: Monday, OpenTime: clock(11, 0), CloseTime: clock(15, 0)},
+			want: true,
+		},
+		{
+			name: "same day touching",
+			a:    &Schedule{DayOfWeek: Monday, OpenTime: clock(8, 0), CloseTime: clock(12, 0)},
+			b:    &Schedule{DayOfWeek: Monday, OpenTime: clock(12, 0), CloseTime: clock(22, 0)},
+			want: false,
+		},
+		{
+			name: "overnight tail overlaps next day",
+			a:    &Schedule{DayOfWeek: Friday, OpenTime: clock(18, 0), CloseTime: clock(2, 0)},
+			b:    &Schedule{DayOfWeek: Saturday, OpenTime: clock(1, 0), CloseTime: clock(10, 0)},
+			want: true,
+		},
+		{
+			name: "overnight tail touches next day",
+			a:    &Schedule{DayOfWeek: Friday, OpenTime: clock(18, 0), CloseTime: clock(2, 0)},
+			b:    &Schedule{DayOfWeek: Saturday, OpenTime: clock(2, 0), CloseTime: clock(10, 0)},
+			want: false,
+		},
+		{
+			name: "saturday night overlaps sunday morning",
+			a:    &Schedule{DayOfWeek: Saturday, OpenTime: clock(20, 0), CloseTime: clock(3, 0)},
+			b:    &Schedule{DayOfWeek: Sunday, OpenTime: clock(2, 0), CloseTime: clock(8, 0)},
+			want: true,
+		},
+		{
+			name: "saturday night clear of sunday morning",
+			a:    &Schedule{DayOfWeek: Saturday, OpenTime: clock(20, 0), CloseTime: clock(1, 0)},
+			b:    &Schedule{DayOfWeek: Sunday, OpenTime: clock(6, 0), CloseTime: clock(12, 0)},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Overlaps(tt.b); got != tt.want {
+				t.Fatalf("a.Overlaps(b) = %v, want %v", got, tt.want)
+			}
+			if got := tt.b.Overlaps(tt.a); got != tt.want {
+				t.Fatalf("b.Overlaps(a) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// RevokeReason mencatat kenapa session dicabut.
+type RevokeReason string
+
+const (
+	// RevokeRotated berarti refresh token sudah ditukar dengan pasangan token baru.
+	RevokeRotated RevokeReason = "ROTATED"
+	// RevokeLogout berarti user logout, dari satu atau semua perangkat.
+	RevokeLogout RevokeReason = "LOGOUT"
+	// RevokeReuseDetected berarti session ikut dicabut karena refresh token
+	// yang sudah dirotasi dipakai ulang.
+	RevokeReuseDetected RevokeReason = "REUSE_DETECTED"
+)
+
+// Session merepresentasikan satu refresh token yang aktif.
+// Setiap kali refresh token dipakai, session lama dicabut dan
+// session baru dibuat (rotasi).
+type Session struct {
+	ID               int
+	UserID           int
+	RefreshTokenHash string
+	ExpiresAt        time.Time
+	RevokedAt        *time.Time
+	RevokeReason     RevokeReason
+	CreatedAt        time.Time
+}
+
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// WasRotated menandakan refresh token session ini sudah pernah ditukar,
+// sehingga pemakaian berikutnya berarti token bocor.
+func (s *Session) WasRotated() bool {
+	return s.IsRevoked() && s.RevokeReason == RevokeRotated
+}
+
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+func (s *Session) IsValid(now time.Time) bool {
+	return !s.IsRevoked() && !s.IsExpired(now)
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// SlotPolicy mengatur granularitas jam mulai, batas durasi, dan jeda
+// antar booking di satu lapangan.
+type SlotPolicy struct {
+	// SlotMinutes adalah granularitas jam mulai dan durasi booking, misalnya 30 menit.
+	// Nilainya harus membagi habis 1440 supaya grid slot sama setiap hari.
+	SlotMinutes int
+	// MinDurationMinutes adalah durasi booking minimal.
+	MinDurationMinutes int
+	// MaxDurationMinutes adalah durasi booking maksimal. 0 berarti tanpa batas.
+	MaxDurationMinutes int
+	// BufferMinutes adalah jeda setelah booking selesai sebelum booking
+	// berikutnya boleh dimulai, misalnya untuk membersihkan lapangan.
+	BufferMinutes int
+}
+
+// DefaultSlotPolicy dipakai jika owner tidak mengatur slot sendiri:
+// booking per jam penuh tanpa buffer.
+var DefaultSlotPolicy = SlotPolicy{
+	SlotMinutes:        60,
+	MinDurationMinutes: 60,
+}
+
+// IsAligned mengecek apakah t jatuh tepat di grid slot, dihitung dari tengah malam.
+func (p SlotPolicy) IsAligned(t time.Time) bool {
+	if t.Second() != 0 || t.Nanosecond() != 0 {
+		return false
+	}
+
+	return (t.Hour()*60+t.Minute())%p.SlotMinutes == 0
+}
+
+// Buffer mengembalikan jeda antar booking sebagai time.Duration.
+func (p SlotPolicy) Buffer() time.Duration {
+	return time.Duration(p.BufferMinutes) * time.Minute
+}
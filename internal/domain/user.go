@@ -7,6 +7,7 @@ type Role string
 const (
 	RoleCustomer Role = "CUSTOMER"
 	RoleOwner    Role = "OWNER"
+	RoleAdmin    Role = "ADMIN"
 )
 
 type User struct {
@@ -25,3 +26,7 @@ func (u *User) IsOwner() bool {
 func (u *User) IsCustomer() bool {
 	return u.Role == RoleCustomer
 }
+
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"sync"
+	"time"
+)
+
+// Venue adalah lokasi fisik yang menaungi satu atau lebih court (Field).
+// Data yang sama untuk semua court di lokasi tersebut (owner, alamat, foto,
+// fasilitas, dan jam buka default) disimpan di sini.
+type Venue struct {
+	ID          int
+	OwnerID     int
+	Name        string
+	Address     string
+	Description string
+	ImageURL    string
+	// Amenities adalah daftar fasilitas venue, misalnya "parking" atau "shower".
+	Amenities []string
+	// Coordinates adalah titik peta venue; nil jika belum diisi owner.
+	Coordinates *GeoPoint
+	// TimeZone adalah nama zona waktu IANA venue, misalnya "Asia/Makassar".
+	// Jadwal, slot, dan pricing rule semua court di venue dibaca di zona ini.
+	TimeZone  string
+	CreatedAt time.Time
+}
+
+func (v *Venue) IsOwnedBy(userID int) bool {
+	return v.OwnerID == userID
+}
+
+// DefaultTimeZone dipakai jika owner tidak mengisi zona waktu venue.
+const DefaultTimeZone = "Asia/Jakarta"
+
+var timeZones sync.Map
+
+// LoadTimeZone memuat zona waktu IANA dan menyimpannya di cache, karena
+// time.LoadLocation membaca database zona waktu setiap kali dipanggil.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if loc, ok := timeZones.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	timeZones.Store(name, loc)
+	return loc, nil
+}
+
+type SurfaceType string
+
+const (
+	SurfaceSynthetic SurfaceType = "SYNTHETIC"
+	SurfaceVinyl     SurfaceType = "VINYL"
+	SurfaceInterlock SurfaceType = "INTERLOCK"
+	SurfaceParquet   SurfaceType = "PARQUET"
+	SurfaceCement    SurfaceType = "CEMENT"
+)
+
+// DefaultSurfaceType dipakai jika owner tidak mengisi jenis permukaan court.
+const DefaultSurfaceType = SurfaceSynthetic
+
+func (s SurfaceType) IsValid() bool {
+	switch s {
+	case SurfaceSynthetic, SurfaceVinyl, SurfaceInterlock, SurfaceParquet, SurfaceCement:
+		return true
+	}
+	return false
+}
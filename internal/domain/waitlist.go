@@ -0,0 +1,100 @@
+package domain
+
+import "time"
+
+type WaitlistOrder string
+
+const (
+	// WaitlistFIFO menawarkan slot ke customer yang paling dulu mengantre.
+	WaitlistFIFO WaitlistOrder = "FIFO"
+	// WaitlistLottery menawarkan slot ke customer yang dipilih acak.
+	WaitlistLottery WaitlistOrder = "LOTTERY"
+)
+
+// WaitlistPolicy mengatur antrean slot penuh di satu lapangan.
+type WaitlistPolicy struct {
+	Order WaitlistOrder
+	// OfferMinutes adalah lama slot ditahan untuk customer yang mendapat penawaran.
+	OfferMinutes int
+	// NotifyCustomer mengirim notifikasi saat customer mendapat penawaran atau penawarannya habis.
+	NotifyCustomer bool
+	// NotifyOwner mengirim notifikasi ke owner saat penawaran diterima menjadi booking.
+	NotifyOwner bool
+}
+
+// DefaultWaitlistPolicy dipakai jika owner tidak mengatur kebijakan sendiri.
+var DefaultWaitlistPolicy = WaitlistPolicy{
+	Order:          WaitlistFIFO,
+	OfferMinutes:   30,
+	NotifyCustomer: true,
+	NotifyOwner:    false,
+}
+
+// OfferDeadline menghitung batas waktu menerima penawaran. Penawaran tidak
+// pernah melewati waktu mulai slot.
+func (p WaitlistPolicy) OfferDeadline(slotStart, now time.Time) time.Time {
+	deadline := now.Add(time.Duration(p.OfferMinutes) * time.Minute)
+	if deadline.After(slotStart) {
+		return slotStart
+	}
+	return deadline
+}
+
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting   WaitlistStatus = "WAITING"
+	WaitlistOffered   WaitlistStatus = "OFFERED"
+	WaitlistAccepted  WaitlistStatus = "ACCEPTED"
+	WaitlistExpired   WaitlistStatus = "EXPIRED"
+	WaitlistCancelled WaitlistStatus = "CANCELLED"
+)
+
+// WaitlistEntry adalah antrean satu customer untuk rentang waktu tertentu di
+// satu lapangan. Saat rentang tersebut kosong, entry terdepan mendapat
+// penawaran (OFFERED) yang menahan slot sampai OfferExpiresAt.
+type WaitlistEntry struct {
+	ID             int
+	UserID         int
+	FieldID        int
+	StartTime      time.Time
+	EndTime        time.Time
+	Status         WaitlistStatus
+	OfferedAt      *time.Time
+	OfferExpiresAt *time.Time
+	BookingID      *int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (w *WaitlistEntry) IsOpen() bool {
+	return w.Status == WaitlistWaiting || w.Status == WaitlistOffered
+}
+
+// HasActiveOffer mengecek apakah penawaran masih bisa diterima pada now.
+func (w *WaitlistEntry) HasActiveOffer(now time.Time) bool {
+	return w.Status == WaitlistOffered && w.OfferExpiresAt != nil && now.Before(*w.OfferExpiresAt)
+}
+
+// DurationMinutes mengembalikan panjang rentang yang diantre dalam menit.
+func (w *WaitlistEntry) DurationMinutes() int {
+	return int(w.EndTime.Sub(w.StartTime) / time.Minute)
+}
+
+func (w *WaitlistEntry) Offer(expiresAt, now time.Time) {
+	w.Status = WaitlistOffered
+	w.OfferedAt = &now
+	w.OfferExpiresAt = &expiresAt
+	w.UpdatedAt = now
+}
+
+func (w *WaitlistEntry) Accept(bookingID int, now time.Time) {
+	w.Status = WaitlistAccepted
+	w.BookingID = &bookingID
+	w.UpdatedAt = now
+}
+
+func (w *WaitlistEntry) Cancel(now time.Time) {
+	w.Status = WaitlistCancelled
+	w.UpdatedAt = now
+}
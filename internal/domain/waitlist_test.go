@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWaitlistPolicyOfferDeadline(t *testing.T) {
+	policy := WaitlistPolicy{Order: WaitlistFIFO, OfferMinutes: 30}
+	now := at(0, 18, 0)
+
+	tests := []struct {
+		name      string
+		slotStart time.Time
+		want      time.Time
+	}{
+		{"slot far ahead gets full offer window", at(1, 20, 0), at(0, 18, 30)},
+		{"slot starting exactly at deadline", at(0, 18, 30), at(0, 18, 30)},
+		{"slot starting before deadline caps offer", at(0, 18, 10), at(0, 18, 10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.OfferDeadline(tt.slotStart, now); !got.Equal(tt.want) {
+				t.Fatalf("OfferDeadline(%s) = %s, want %s", tt.slotStart, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitlistEntryHasActiveOffer(t *testing.T) {
+	now := at(0, 18, 0)
+	later := at(0, 18, 30)
+	earlier := at(0, 17, 30)
+
+	tests := []struct {
+		name  string
+		entry WaitlistEntry
+		want  bool
+	}{
+		{"offer before deadline", WaitlistEntry{Status: WaitlistOffered, OfferExpiresAt: &later}, true},
+		{"offer at deadline", WaitlistEntry{Status: WaitlistOffered, OfferExpiresAt: &now}, false},
+		{"offer past deadline", WaitlistEntry{Status: WaitlistOffered, OfferExpiresAt: &earlier}, false},
+		{"offer without deadline", WaitlistEntry{Status: WaitlistOffered}, false},
+		{"still waiting", WaitlistEntry{Status: WaitlistWaiting, OfferExpiresAt: &later}, false},
+		{"already accepted", WaitlistEntry{Status: WaitlistAccepted, OfferExpiresAt: &later}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.HasActiveOffer(now); got != tt.want {
+				t.Fatalf("HasActiveOffer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package notification
+
+import (
+	"context"
+	"log"
+)
+
+// LogNotifier hanya menulis notifikasi ke log, untuk development.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Send(ctx context.Context, msg Message) error {
+	log.Printf("notification to user %d <%s>: %s - %s", msg.UserID, msg.Email, msg.Subject, msg.Body)
+	return nil
+}
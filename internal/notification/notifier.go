@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Message adalah notifikasi untuk satu penerima.
+type Message struct {
+	UserID  int
+	Email   string
+	Subject string
+	Body    string
+}
+
+// Notifier mengirim notifikasi ke user. Pengiriman bersifat best-effort:
+// pemanggil mencatat error tanpa membatalkan operasi yang memicunya.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type Config struct {
+	// Driver memilih implementasi: "log" atau "webhook".
+	Driver string
+
+	// WebhookURL menerima POST JSON untuk setiap notifikasi, misalnya
+	// service email/WhatsApp internal.
+	WebhookURL     string
+	WebhookTimeout time.Duration
+}
+
+// NewNotifier membuat Notifier sesuai konfigurasi.
+func NewNotifier(cfg Config) (Notifier, error) {
+	switch cfg.Driver {
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("notification webhook URL is required")
+		}
+		return NewWebhookNotifier(cfg.WebhookURL, cfg.WebhookTimeout), nil
+	case "log", "":
+		return NewLogNotifier(), nil
+	default:
+		return nil, fmt.Errorf("unknown notification driver: %s", cfg.Driver)
+	}
+}
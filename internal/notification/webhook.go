@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookNotifier meneruskan notifikasi sebagai POST JSON ke URL yang
+// dikonfigurasi. Channel pengiriman (email, WhatsApp, push) ditangani
+// service penerima.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type webhookPayload struct {
+	UserID  int    `json:"user_id"`
+	Email   string `json:"email"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+func (n *WebhookNotifier) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(webhookPayload{
+		UserID:  msg.UserID,
+		Email:   msg.Email,
+		Subject: msg.Subject,
+		Body:    msg.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error building notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
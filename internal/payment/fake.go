@@ -0,0 +1,183 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// FakeGateway adalah payment gateway in-process tanpa akses jaringan.
+// Status transaksi dikendalikan lewat SetStatus, dan notifikasi webhook
+// yang valid bisa dibuat dengan BuildNotification, sehingga alur pembayaran
+// end-to-end bisa diuji tanpa gateway sungguhan. Notifikasi yang dikirim
+// manual dan lolos verifikasi juga mengubah status transaksi, sehingga refund
+// setelahnya berperilaku seperti gateway sungguhan.
+type FakeGateway struct {
+	secret string
+
+	mu           sync.Mutex
+	transactions map[string]*fakeTransaction
+}
+
+type fakeTransaction struct {
+	amount   int
+	refunded int
+	status   Status
+	refunds  map[string]int
+}
+
+func NewFakeGateway(secret string) *FakeGateway {
+	return &FakeGateway{
+		secret:       secret,
+		transactions: map[string]*fakeTransaction{},
+	}
+}
+
+func (g *FakeGateway) Name() string {
+	return "Fake"
+}
+
+func (g *FakeGateway) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("charge amount must be positive")
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.transactions[req.TransactionID]; exists {
+		return nil, fmt.Errorf("transaction %s already exists", req.TransactionID)
+	}
+
+	g.transactions[req.TransactionID] = &fakeTransaction{
+		amount:  req.Amount,
+		status:  StatusPending,
+		refunds: map[string]int{},
+	}
+
+	return &Charge{
+		TransactionID: req.TransactionID,
+		PaymentURL:    "https://fake-gateway.local/pay/" + req.TransactionID,
+	}, nil
+}
+
+func (g *FakeGateway) GetStatus(ctx context.Context, transactionID string) (*TransactionStatus, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	trx, ok := g.transactions[transactionID]
+	if !ok {
+		return nil, ErrTransactionNotFound
+	}
+
+	return &TransactionStatus{TransactionID: transactionID, Status: trx.status, Amount: trx.amount}, nil
+}
+
+func (g *FakeGateway) Refund(ctx context.Context, req RefundRequest) (*RefundResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	trx, ok := g.transactions[req.TransactionID]
+	if !ok {
+		return nil, ErrTransactionNotFound
+	}
+
+	if amount, done := trx.refunds[req.RefundKey]; done {
+		return &RefundResult{RefundKey: req.RefundKey, Amount: amount, Status: StatusRefunded}, nil
+	}
+
+	if trx.status != StatusSuccess && trx.status != StatusRefunded {
+		return nil, fmt.Errorf("transaction %s is not refundable in status %s", req.TransactionID, trx.status)
+	}
+
+	if req.Amount <= 0 || trx.refunded+req.Amount > trx.amount {
+		return nil, fmt.Errorf("invalid refund amount %d", req.Amount)
+	}
+
+	trx.refunded += req.Amount
+	trx.refunds[req.RefundKey] = req.Amount
+	if trx.refunded == trx.amount {
+		trx.status = StatusRefunded
+	}
+
+	return &RefundResult{RefundKey: req.RefundKey, Amount: req.Amount, Status: StatusRefunded}, nil
+}
+
+// fakeNotification adalah format body webhook fake gateway. Signature adalah
+// hex HMAC-SHA256 dari "transaction_id|status|amount" dengan secret gateway.
+type fakeNotification struct {
+	TransactionID string `json:"transaction_id"`
+	Status        Status `json:"status"`
+	Amount        int    `json:"amount"`
+	Signature     string `json:"signature"`
+}
+
+func (g *FakeGateway) VerifyNotification(ctx context.Context, body []byte) (*Notification, error) {
+	var n fakeNotification
+	if err := json.Unmarshal(body, &n); err != nil {
+		return nil, fmt.Errorf("invalid notification body: %w", err)
+	}
+
+	expected := g.sign(n.TransactionID, n.Status, n.Amount)
+	if !hmac.Equal([]byte(expected), []byte(n.Signature)) {
+		return nil, ErrInvalidSignature
+	}
+
+	g.mu.Lock()
+	if trx, ok := g.transactions[n.TransactionID]; ok && trx.status != StatusRefunded {
+		trx.status = n.Status
+	}
+	g.mu.Unlock()
+
+	return &Notification{
+		TransactionID: n.TransactionID,
+		Status:        n.Status,
+		Amount:        n.Amount,
+		RawStatus:     string(n.Status),
+	}, nil
+}
+
+// SetStatus mengubah status transaksi, mensimulasikan customer membayar
+// (StatusSuccess) atau pembayaran gagal (StatusFailed).
+func (g *FakeGateway) SetStatus(transactionID string, status Status) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	trx, ok := g.transactions[transactionID]
+	if !ok {
+		return ErrTransactionNotFound
+	}
+
+	trx.status = status
+	return nil
+}
+
+// BuildNotification membuat body webhook bertanda tangan untuk transaksi
+// dengan status saat ini, siap dikirim ke endpoint notifikasi.
+func (g *FakeGateway) BuildNotification(transactionID string) ([]byte, error) {
+	g.mu.Lock()
+	trx, ok := g.transactions[transactionID]
+	if !ok {
+		g.mu.Unlock()
+		return nil, ErrTransactionNotFound
+	}
+	status, amount := trx.status, trx.amount
+	g.mu.Unlock()
+
+	return json.Marshal(fakeNotification{
+		TransactionID: transactionID,
+		Status:        status,
+		Amount:        amount,
+		Signature:     g.sign(transactionID, status, amount),
+	})
+}
+
+func (g *FakeGateway) sign(transactionID string, status Status, amount int) string {
+	mac := hmac.New(sha256.New, []byte(g.secret))
+	fmt.Fprintf(mac, "%s|%s|%d", transactionID, status, amount)
+	return hex.EncodeToString(mac.Sum(nil))
+}
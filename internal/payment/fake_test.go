@@ -0,0 +1,119 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func newFakeCharge(t *testing.T, g *FakeGateway, transactionID string, amount int) {
+	t.Helper()
+
+	if _, err := g.CreateCharge(context.Background(), ChargeRequest{TransactionID: transactionID, Amount: amount}); err != nil {
+		t.Fatalf("CreateCharge: %v", err)
+	}
+}
+
+func TestFakeGatewayNotificationRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	g := NewFakeGateway("secret")
+	newFakeCharge(t, g, "TRX-1", 150000)
+
+	if err := g.SetStatus("TRX-1", StatusSuccess); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	body, err := g.BuildNotification("TRX-1")
+	if err != nil {
+		t.Fatalf("BuildNotification: %v", err)
+	}
+
+	n, err := g.VerifyNotification(ctx, body)
+	if err != nil {
+		t.Fatalf("VerifyNotification: %v", err)
+	}
+
+	if n.TransactionID != "TRX-1" || n.Status != StatusSuccess || n.Amount != 150000 {
+		t.Fatalf("notification = %+v, want TRX-1 SUCCESS 150000", n)
+	}
+
+	status, err := g.GetStatus(ctx, "TRX-1")
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if status.Status != StatusSuccess {
+		t.Fatalf("status = %s, want %s", status.Status, StatusSuccess)
+	}
+}
+
+func TestFakeGatewayRejectsTamperedNotification(t *testing.T) {
+	g := NewFakeGateway("secret")
+	newFakeCharge(t, g, "TRX-1", 150000)
+
+	if err := g.SetStatus("TRX-1", StatusFailed); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	body, err := g.BuildNotification("TRX-1")
+	if err != nil {
+		t.Fatalf("BuildNotification: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(n *fakeNotification)
+	}{
+		{"status", func(n *fakeNotification) { n.Status = StatusSuccess }},
+		{"amount", func(n *fakeNotification) { n.Amount = 1000 }},
+		{"transaction", func(n *fakeNotification) { n.TransactionID = "TRX-2" }},
+		{"signature", func(n *fakeNotification) { n.Signature = "00" + n.Signature[2:] }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n fakeNotification
+			if err := json.Unmarshal(body, &n); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			tt.tamper(&n)
+
+			tampered, err := json.Marshal(n)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+
+			if _, err := g.VerifyNotification(context.Background(), tampered); !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("VerifyNotification error = %v, want %v", err, ErrInvalidSignature)
+			}
+		})
+	}
+
+	status, err := g.GetStatus(context.Background(), "TRX-1")
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if status.Status != StatusFailed {
+		t.Fatalf("status after rejected notifications = %s, want %s", status.Status, StatusFailed)
+	}
+}
+
+func TestFakeGatewayRejectsOtherSecret(t *testing.T) {
+	g := NewFakeGateway("secret")
+	newFakeCharge(t, g, "TRX-1", 150000)
+
+	body, err := NewFakeGateway("other").BuildNotification("TRX-1")
+	if !errors.Is(err, ErrTransactionNotFound) {
+		t.Fatalf("BuildNotification on other gateway error = %v, want %v", err, ErrTransactionNotFound)
+	}
+
+	other := NewFakeGateway("other")
+	newFakeCharge(t, other, "TRX-1", 150000)
+	if body, err = other.BuildNotification("TRX-1"); err != nil {
+		t.Fatalf("BuildNotification: %v", err)
+	}
+
+	if _, err := g.VerifyNotification(context.Background(), body); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("VerifyNotification error = %v, want %v", err, ErrInvalidSignature)
+	}
+}
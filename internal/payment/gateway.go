@@ -0,0 +1,112 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status adalah status transaksi yang sudah dinormalisasi dari
+// status spesifik masing-masing payment gateway.
+type Status string
+
+const (
+	StatusPending  Status = "PENDING"
+	StatusSuccess  Status = "SUCCESS"
+	StatusFailed   Status = "FAILED"
+	StatusRefunded Status = "REFUNDED"
+)
+
+var (
+	ErrInvalidSignature    = errors.New("invalid notification signature")
+	ErrTransactionNotFound = errors.New("transaction not found at payment gateway")
+)
+
+// Gateway adalah abstraksi payment gateway. TransactionID yang dipakai di
+// semua method adalah ID order yang kita kirim saat CreateCharge dan disimpan
+// di payments.transaction_id.
+type Gateway interface {
+	Name() string
+	CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	GetStatus(ctx context.Context, transactionID string) (*TransactionStatus, error)
+	Refund(ctx context.Context, req RefundRequest) (*RefundResult, error)
+	VerifyNotification(ctx context.Context, body []byte) (*Notification, error)
+}
+
+type ChargeRequest struct {
+	TransactionID string
+	Amount        int
+	ItemName      string
+	CustomerName  string
+	CustomerEmail string
+	ExpiresAt     time.Time
+}
+
+type Charge struct {
+	TransactionID string
+	PaymentURL    string
+}
+
+type TransactionStatus struct {
+	TransactionID string
+	Status        Status
+	Amount        int
+}
+
+type RefundRequest struct {
+	TransactionID string
+	// RefundKey harus unik per refund supaya request yang diulang tidak
+	// menghasilkan refund ganda di sisi gateway.
+	RefundKey string
+	Amount    int
+	Reason    string
+}
+
+type RefundResult struct {
+	RefundKey string
+	Amount    int
+	Status    Status
+}
+
+// Notification adalah isi webhook dari gateway yang sudah diverifikasi.
+type Notification struct {
+	TransactionID string
+	Status        Status
+	Amount        int
+	// RawStatus adalah status asli dari gateway, untuk keperluan log/audit.
+	RawStatus string
+}
+
+type Config struct {
+	// Provider memilih implementasi: "fake" atau "midtrans". Wajib diisi,
+	// supaya deployment tidak diam-diam memakai fake gateway.
+	Provider string
+
+	MidtransServerKey  string
+	MidtransProduction bool
+
+	// FakeSecret dipakai fake gateway untuk memverifikasi notifikasi.
+	// Wajib jika Provider "fake".
+	FakeSecret string
+}
+
+// NewGateway membuat Gateway sesuai konfigurasi.
+func NewGateway(cfg Config) (Gateway, error) {
+	switch cfg.Provider {
+	case "midtrans":
+		if cfg.MidtransServerKey == "" {
+			return nil, fmt.Errorf("midtrans server key is required")
+		}
+		return NewMidtransGateway(cfg.MidtransServerKey, cfg.MidtransProduction), nil
+	case "fake":
+		if cfg.FakeSecret == "" {
+			return nil, fmt.Errorf("fake gateway secret is required")
+		}
+		return NewFakeGateway(cfg.FakeSecret), nil
+	case "":
+		return nil, fmt.Errorf("payment gateway provider is required")
+	default:
+		return nil, fmt.Errorf("unknown payment gateway provider: %s", cfg.Provider)
+	}
+}
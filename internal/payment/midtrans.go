@@ -0,0 +1,275 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	midtransSnapSandboxURL    = "https://app.sandbox.midtrans.com"
+	midtransSnapProductionURL = "https://app.midtrans.com"
+	midtransAPISandboxURL     = "https://api.sandbox.midtrans.com"
+	midtransAPIProductionURL  = "https://api.midtrans.com"
+)
+
+// MidtransGateway memakai Snap API untuk membuat transaksi dan Core API
+// untuk cek status dan refund. TransactionID kita dikirim sebagai order_id.
+type MidtransGateway struct {
+	serverKey  string
+	snapURL    string
+	apiURL     string
+	httpClient *http.Client
+}
+
+func NewMidtransGateway(serverKey string, production bool) *MidtransGateway {
+	g := &MidtransGateway{
+		serverKey:  serverKey,
+		snapURL:    midtransSnapSandboxURL,
+		apiURL:     midtransAPISandboxURL,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+
+	if production {
+		g.snapURL = midtransSnapProductionURL
+		g.apiURL = midtransAPIProductionURL
+	}
+
+	return g
+}
+
+func (g *MidtransGateway) Name() string {
+	return "Midtrans"
+}
+
+type midtransSnapRequest struct {
+	TransactionDetails struct {
+		OrderID     string `json:"order_id"`
+		GrossAmount int    `json:"gross_amount"`
+	} `json:"transaction_details"`
+	ItemDetails []midtransItem `json:"item_details,omitempty"`
+	Customer    struct {
+		FirstName string `json:"first_name,omitempty"`
+		Email     string `json:"email,omitempty"`
+	} `json:"customer_details"`
+	Expiry *midtransExpiry `json:"expiry,omitempty"`
+}
+
+type midtransItem struct {
+	ID       string `json:"id"`
+	Price    int    `json:"price"`
+	Quantity int    `json:"quantity"`
+	Name     string `json:"name"`
+}
+
+type midtransExpiry struct {
+	StartTime string `json:"start_time"`
+	Unit      string `json:"unit"`
+	Duration  int    `json:"duration"`
+}
+
+type midtransSnapResponse struct {
+	Token         string   `json:"token"`
+	RedirectURL   string   `json:"redirect_url"`
+	ErrorMessages []string `json:"error_messages"`
+}
+
+func (g *MidtransGateway) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	body := midtransSnapRequest{}
+	body.TransactionDetails.OrderID = req.TransactionID
+	body.TransactionDetails.GrossAmount = req.Amount
+	body.Customer.FirstName = req.CustomerName
+	body.Customer.Email = req.CustomerEmail
+
+	if req.ItemName != "" {
+		body.ItemDetails = []midtransItem{{
+			ID:       req.TransactionID,
+			Price:    req.Amount,
+			Quantity: 1,
+			Name:     truncate(req.ItemName, 50),
+		}}
+	}
+
+	if !req.ExpiresAt.IsZero() {
+		now := time.Now()
+		minutes := int(math.Ceil(req.ExpiresAt.Sub(now).Minutes()))
+		if minutes > 0 {
+			body.Expiry = &midtransExpiry{
+				StartTime: now.Format("2006-01-02 15:04:05 -0700"),
+				Unit:      "minutes",
+				Duration:  minutes,
+			}
+		}
+	}
+
+	var res midtransSnapResponse
+	status, err := g.do(ctx, http.MethodPost, g.snapURL+"/snap/v1/transactions", body, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusCreated {
+		return nil, fmt.Errorf("midtrans create transaction failed (%d): %s", status, strings.Join(res.ErrorMessages, "; "))
+	}
+
+	return &Charge{TransactionID: req.TransactionID, PaymentURL: res.RedirectURL}, nil
+}
+
+// midtransTransaction adalah field yang sama-sama muncul di response status,
+// response refund, dan body notifikasi.
+type midtransTransaction struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	OrderID           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	SignatureKey      string `json:"signature_key"`
+	RefundAmount      string `json:"refund_amount"`
+}
+
+func (g *MidtransGateway) GetStatus(ctx context.Context, transactionID string) (*TransactionStatus, error) {
+	var res midtransTransaction
+	_, err := g.do(ctx, http.MethodGet, g.apiURL+"/v2/"+url.PathEscape(transactionID)+"/status", nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == "404" {
+		return nil, ErrTransactionNotFound
+	}
+
+	if res.TransactionStatus == "" {
+		return nil, fmt.Errorf("midtrans status check failed (%s): %s", res.StatusCode, res.StatusMessage)
+	}
+
+	return &TransactionStatus{
+		TransactionID: transactionID,
+		Status:        mapMidtransStatus(res.TransactionStatus, res.FraudStatus),
+		Amount:        parseMidtransAmount(res.GrossAmount),
+	}, nil
+}
+
+func (g *MidtransGateway) Refund(ctx context.Context, req RefundRequest) (*RefundResult, error) {
+	body := map[string]interface{}{
+		"refund_key": req.RefundKey,
+		"amount":     req.Amount,
+		"reason":     req.Reason,
+	}
+
+	var res midtransTransaction
+	_, err := g.do(ctx, http.MethodPost, g.apiURL+"/v2/"+url.PathEscape(req.TransactionID)+"/refund", body, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != "200" {
+		return nil, fmt.Errorf("midtrans refund failed (%s): %s", res.StatusCode, res.StatusMessage)
+	}
+
+	return &RefundResult{
+		RefundKey: req.RefundKey,
+		Amount:    parseMidtransAmount(res.RefundAmount),
+		Status:    StatusRefunded,
+	}, nil
+}
+
+// VerifyNotification memverifikasi signature_key sesuai dokumentasi Midtrans:
+// SHA512(order_id + status_code + gross_amount + server_key).
+func (g *MidtransGateway) VerifyNotification(ctx context.Context, body []byte) (*Notification, error) {
+	var n midtransTransaction
+	if err := json.Unmarshal(body, &n); err != nil {
+		return nil, fmt.Errorf("invalid notification body: %w", err)
+	}
+
+	sum := sha512.Sum512([]byte(n.OrderID + n.StatusCode + n.GrossAmount + g.serverKey))
+	expected := hex.EncodeToString(sum[:])
+
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(n.SignatureKey)) != 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	return &Notification{
+		TransactionID: n.OrderID,
+		Status:        mapMidtransStatus(n.TransactionStatus, n.FraudStatus),
+		Amount:        parseMidtransAmount(n.GrossAmount),
+		RawStatus:     n.TransactionStatus,
+	}, nil
+}
+
+func (g *MidtransGateway) do(ctx context.Context, method, endpoint string, payload interface{}, out interface{}) (int, error) {
+	var reader io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return 0, fmt.Errorf("error encoding midtrans request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return 0, fmt.Errorf("error creating midtrans request: %w", err)
+	}
+
+	req.SetBasicAuth(g.serverKey, "")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error calling midtrans: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("error decoding midtrans response (%d): %w", resp.StatusCode, err)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func mapMidtransStatus(transactionStatus, fraudStatus string) Status {
+	switch transactionStatus {
+	case "settlement":
+		return StatusSuccess
+	case "capture":
+		if fraudStatus == "" || fraudStatus == "accept" {
+			return StatusSuccess
+		}
+		return StatusPending
+	case "deny", "cancel", "expire", "failure":
+		return StatusFailed
+	case "refund", "partial_refund":
+		return StatusRefunded
+	default:
+		return StatusPending
+	}
+}
+
+func parseMidtransAmount(amount string) int {
+	f, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0
+	}
+	return int(math.Round(f))
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"time"
+)
+
+type BookingHistoryRepository interface {
+	Create(ctx context.Context, change *domain.BookingStatusChange) error
+	FindByBookingID(ctx context.Context, bookingID int) ([]*domain.BookingStatusChange, error)
+}
+
+type bookingHistoryRepository struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func NewBookingHistoryRepository(db DBTX, timeout time.Duration) BookingHistoryRepository {
+	return &bookingHistoryRepository{db: db, timeout: timeout}
+}
+
+func (r *bookingHistoryRepository) Create(ctx context.Context, change *domain.BookingStatusChange) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO booking_status_history (booking_id, from_status, to_status, actor_id, actor_type, reason, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	// from_status NULL menandai pembuatan booking.
+	var fromStatus sql.NullString
+	if change.FromStatus != "" {
+		fromStatus = sql.NullString{String: string(change.FromStatus), Valid: true}
+	}
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		change.BookingID,
+		fromStatus,
+		change.ToStatus,
+		change.ActorID,
+		change.ActorType,
+		change.Reason,
+		change.CreatedAt,
+	).Scan(&change.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating booking status history: %w", err)
+	}
+
+	return nil
+}
+
+func (r *bookingHistoryRepository) FindByBookingID(ctx context.Context, bookingID int) ([]*domain.BookingStatusChange, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, booking_id, from_status, to_status, actor_id, actor_type, reason, created_at FROM booking_status_history WHERE booking_id=$1 ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding booking status history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*domain.BookingStatusChange{}
+
+	for rows.Next() {
+		change := &domain.BookingStatusChange{}
+		var fromStatus sql.NullString
+
+		err := rows.Scan(
+			&change.ID,
+			&change.BookingID,
+			&fromStatus,
+			&change.ToStatus,
+			&change.ActorID,
+			&change.ActorType,
+			&change.Reason,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning booking status history: %w", err)
+		}
+
+		change.FromStatus = domain.BookingStatus(fromStatus.String)
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating booking status history: %w", err)
+	}
+
+	return changes, nil
+}
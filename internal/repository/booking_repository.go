@@ -1,101 +1,132 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"futsal-booking-app/internal/domain"
 	"time"
 )
 
 type BookingRepository interface {
-	Create(booking *domain.Booking) error
-	FindByID(id int) (*domain.Booking, error)
-	FindByUserID(userID int) ([]*domain.Booking, error)
-	FindByFieldID(fieldID int) ([]*domain.Booking, error)
-	Update(booking *domain.Booking) error
-	Delete(id int) error
-
-	CheckAvailability(fieldID int, startTime, endTime time.Time) (bool, error)
-	FindConflictingBookings(fieldID int, startTime, endTime time.Time) ([]*domain.Booking, error)
+	Create(ctx context.Context, booking *domain.Booking) error
+	FindByID(ctx context.Context, id int) (*domain.Booking, error)
+	FindByIDForUpdate(ctx context.Context, id int) (*domain.Booking, error)
+	FindByUserID(ctx context.Context, userID int) ([]*domain.Booking, error)
+	FindByFieldID(ctx context.Context, fieldID int) ([]*domain.Booking, error)
+	FindBySeriesID(ctx context.Context, seriesID int) ([]*domain.Booking, error)
+	LockBySeriesID(ctx context.Context, seriesID int) ([]*domain.Booking, error)
+	Update(ctx context.Context, booking *domain.Booking) error
+	UpdateStatus(ctx context.Context, id int, from, to domain.BookingStatus) error
+	Delete(ctx context.Context, id int) error
+
+	CountCommittedByUserID(ctx context.Context, userID int) (int, error)
+
+	CheckAvailability(ctx context.Context, fieldID int, startTime, endTime time.Time) (bool, error)
+	CheckAvailabilityExcept(ctx context.Context, fieldID int, startTime, endTime time.Time, bookingID int) (bool, error)
+	FindConflictingBookings(ctx context.Context, fieldID int, startTime, endTime time.Time) ([]*domain.Booking, error)
+	FindBlockedRanges(ctx context.Context, fieldIDs []int, startTime, endTime time.Time) ([]domain.BlockedRange, error)
+
+	ExpireHolds(ctx context.Context, now time.Time, limit int) ([]*domain.Booking, error)
+	ExpireOverlappingHolds(ctx context.Context, fieldID int, startTime, endTime, now time.Time) ([]*domain.Booking, error)
+	LockEndedConfirmed(ctx context.Context, now time.Time, limit int) ([]*domain.Booking, error)
 }
 
+// bookingColumns adalah urutan kolom yang dibaca oleh scanBooking.
+const bookingColumns = `id, user_id, field_id, series_id, start_time, end_time, total_price, original_price, discount_amount, status, expires_at, reschedule_count, buffer_minutes, price_breakdown, created_at`
+
+// activeBookingCondition memfilter booking yang masih memblokir slot:
+// CONFIRMED, atau PENDING yang hold pembayarannya belum kadaluarsa.
+// Parameter $4 adalah waktu sekarang.
+const activeBookingCondition = `(status = 'CONFIRMED' OR (status = 'PENDING' AND (expires_at IS NULL OR expires_at > $4)))`
+
 type bookingRepository struct {
-	db *sql.DB
+	db      DBTX
+	timeout time.Duration
 }
 
-func NewBookingRepository(db *sql.DB) BookingRepository {
-	return &bookingRepository{db: db}
+func NewBookingRepository(db DBTX, timeout time.Duration) BookingRepository {
+	return &bookingRepository{db: db, timeout: timeout}
 }
 
-func (r *bookingRepository) Create(booking *domain.Booking) error {
-	query := `INSERT INTO bookings (user_id, field_id, start_time, end_time, total_price, status, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
-
-	err := r.db.QueryRow(
-		query,
-		booking.UserID,
-		booking.FieldID,
-		booking.StartTime,
-		booking.EndTime,
-		booking.TotalPrice,
-		booking.Status,
-		booking.CreatedAt,
-	).Scan(&booking.ID)
+// priceLineRecord adalah bentuk JSON domain.PriceLine di kolom price_breakdown.
+type priceLineRecord struct {
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	PricePerHour int       `json:"price_per_hour"`
+	RuleID       *int      `json:"rule_id,omitempty"`
+	RuleName     string    `json:"rule_name,omitempty"`
+	Amount       int       `json:"amount"`
+}
 
-	if err != nil {
-		return fmt.Errorf("error creating booking: %w", err)
+func encodePriceLines(lines []domain.PriceLine) ([]byte, error) {
+	records := make([]priceLineRecord, 0, len(lines))
+	for _, l := range lines {
+		records = append(records, priceLineRecord(l))
 	}
 
-	return nil
+	return json.Marshal(records)
 }
 
-func (r *bookingRepository) FindByID(id int) (*domain.Booking, error) {
-	query := `SELECT id, user_id, field_id, start_time, end_time, total_price, status, created_at FROM bookings WHERE id=$1`
+func decodePriceLines(data []byte) ([]domain.PriceLine, error) {
+	var records []priceLineRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+
+	lines := make([]domain.PriceLine, 0, len(records))
+	for _, r := range records {
+		lines = append(lines, domain.PriceLine(r))
+	}
+
+	return lines, nil
+}
 
+func scanBooking(row rowScanner) (*domain.Booking, error) {
 	booking := &domain.Booking{}
-	err := r.db.QueryRow(query, id).Scan(
+	var priceBreakdown []byte
+
+	err := row.Scan(
 		&booking.ID,
 		&booking.UserID,
 		&booking.FieldID,
+		&booking.SeriesID,
 		&booking.StartTime,
 		&booking.EndTime,
 		&booking.TotalPrice,
+		&booking.OriginalPrice,
+		&booking.DiscountAmount,
 		&booking.Status,
+		&booking.ExpiresAt,
+		&booking.RescheduleCount,
+		&booking.BufferMinutes,
+		&priceBreakdown,
 		&booking.CreatedAt,
 	)
+	if err != nil {
+		return booking, err
+	}
 
+	booking.PriceLines, err = decodePriceLines(priceBreakdown)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("booking not found")
-		}
-		return nil, fmt.Errorf("error finding booking: %w", err)
+		return booking, fmt.Errorf("error decoding price breakdown: %w", err)
 	}
 
 	return booking, nil
 }
 
-func (r *bookingRepository) FindByUserID(userID int) ([]*domain.Booking, error) {
-	query := `SELECT id, user_id, field_id, start_time, end_time, total_price, status, created_at FROM bookings WHERE user_id=$1 ORDER BY created_at DESC`
-
-	rows, err := r.db.Query(query, userID)
+func (r *bookingRepository) queryBookings(ctx context.Context, query string, args ...interface{}) ([]*domain.Booking, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error finding bookings by user: %w", err)
+		return nil, err
 	}
 	defer rows.Close()
 
 	bookings := []*domain.Booking{}
 
 	for rows.Next() {
-		booking := &domain.Booking{}
-		err := rows.Scan(
-			&booking.ID,
-			&booking.UserID,
-			&booking.FieldID,
-			&booking.StartTime,
-			&booking.EndTime,
-			&booking.TotalPrice,
-			&booking.Status,
-			&booking.CreatedAt,
-		)
+		booking, err := scanBooking(rows)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning booking: %w", err)
 		}
@@ -109,54 +140,163 @@ func (r *bookingRepository) FindByUserID(userID int) ([]*domain.Booking, error)
 	return bookings, nil
 }
 
-func (r *bookingRepository) FindByFieldID(fieldID int) ([]*domain.Booking, error) {
-	query := `SELECT id, user_id, field_id, start_time, end_time, total_price, status, created_at FROM bookings WHERE field_id=$1 ORDER BY start_time DESC`
+func (r *bookingRepository) Create(ctx context.Context, booking *domain.Booking) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO bookings (user_id, field_id, series_id, start_time, end_time, total_price, original_price, discount_amount, status, expires_at, buffer_minutes, blocked_until, price_breakdown, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id`
 
-	rows, err := r.db.Query(query, fieldID)
+	priceBreakdown, err := encodePriceLines(booking.PriceLines)
 	if err != nil {
-		return nil, fmt.Errorf("error finding bookings by field: %w", err)
+		return fmt.Errorf("error encoding price breakdown: %w", err)
 	}
-	defer rows.Close()
 
-	bookings := []*domain.Booking{}
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		booking.UserID,
+		booking.FieldID,
+		booking.SeriesID,
+		booking.StartTime,
+		booking.EndTime,
+		booking.TotalPrice,
+		booking.OriginalPrice,
+		booking.DiscountAmount,
+		booking.Status,
+		booking.ExpiresAt,
+		booking.BufferMinutes,
+		booking.BlockedUntil(),
+		priceBreakdown,
+		booking.CreatedAt,
+	).Scan(&booking.ID)
 
-	for rows.Next() {
-		booking := &domain.Booking{}
-		err := rows.Scan(
-			&booking.ID,
-			&booking.UserID,
-			&booking.FieldID,
-			&booking.StartTime,
-			&booking.EndTime,
-			&booking.TotalPrice,
-			&booking.Status,
-			&booking.CreatedAt,
-		)
+	if err != nil {
+		return fmt.Errorf("error creating booking: %w", err)
+	}
 
-		if err != nil {
-			return nil, fmt.Errorf("error scanning booking: %w", err)
+	return nil
+}
+
+func (r *bookingRepository) FindByID(ctx context.Context, id int) (*domain.Booking, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE id=$1`
+
+	booking, err := scanBooking(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrBookingNotFound
 		}
-		bookings = append(bookings, booking)
+		return nil, fmt.Errorf("error finding booking: %w", err)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating bookings: %w", err)
+	return booking, nil
+}
+
+// FindByIDForUpdate sama dengan FindByID tapi mengunci baris booking sampai
+// transaksi selesai. Hanya bermakna jika dipanggil di dalam UnitOfWork.
+func (r *bookingRepository) FindByIDForUpdate(ctx context.Context, id int) (*domain.Booking, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE id=$1 FOR UPDATE`
+
+	booking, err := scanBooking(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrBookingNotFound
+		}
+		return nil, fmt.Errorf("error finding booking: %w", err)
+	}
+
+	return booking, nil
+}
+
+func (r *bookingRepository) FindByUserID(ctx context.Context, userID int) ([]*domain.Booking, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE user_id=$1 ORDER BY created_at DESC`
+
+	bookings, err := r.queryBookings(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding bookings by user: %w", err)
+	}
+
+	return bookings, nil
+}
+
+func (r *bookingRepository) FindByFieldID(ctx context.Context, fieldID int) ([]*domain.Booking, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE field_id=$1 ORDER BY start_time DESC`
+
+	bookings, err := r.queryBookings(ctx, query, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding bookings by field: %w", err)
+	}
+
+	return bookings, nil
+}
+
+func (r *bookingRepository) FindBySeriesID(ctx context.Context, seriesID int) ([]*domain.Booking, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE series_id=$1 ORDER BY start_time`
+
+	bookings, err := r.queryBookings(ctx, query, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding bookings by series: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// LockBySeriesID sama dengan FindBySeriesID tapi mengunci semua baris booking
+// series sampai transaksi selesai. Hanya bermakna jika dipanggil di dalam UnitOfWork.
+func (r *bookingRepository) LockBySeriesID(ctx context.Context, seriesID int) ([]*domain.Booking, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE series_id=$1 ORDER BY start_time FOR UPDATE`
+
+	bookings, err := r.queryBookings(ctx, query, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("error locking bookings by series: %w", err)
 	}
 
 	return bookings, nil
 }
 
-func (r *bookingRepository) Update(booking *domain.Booking) error {
-	query := `UPDATE bookings SET user_id=$1, field_id=$2, start_time=$3, end_time=$4, total_price=$5, status=$6 WHERE id=$7`
+func (r *bookingRepository) Update(ctx context.Context, booking *domain.Booking) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE bookings SET user_id=$1, field_id=$2, start_time=$3, end_time=$4, total_price=$5, original_price=$6, discount_amount=$7, expires_at=$8, reschedule_count=$9, buffer_minutes=$10, blocked_until=$11, price_breakdown=$12 WHERE id=$13`
+
+	priceBreakdown, err := encodePriceLines(booking.PriceLines)
+	if err != nil {
+		return fmt.Errorf("error encoding price breakdown: %w", err)
+	}
 
-	result, err := r.db.Exec(
+	result, err := r.db.ExecContext(
+		ctx,
 		query,
 		booking.UserID,
 		booking.FieldID,
 		booking.StartTime,
 		booking.EndTime,
 		booking.TotalPrice,
-		booking.Status,
+		booking.OriginalPrice,
+		booking.DiscountAmount,
+		booking.ExpiresAt,
+		booking.RescheduleCount,
+		booking.BufferMinutes,
+		booking.BlockedUntil(),
+		priceBreakdown,
 		booking.ID,
 	)
 
@@ -170,16 +310,45 @@ func (r *bookingRepository) Update(booking *domain.Booking) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("bboking not found")
+		return domain.ErrBookingNotFound
 	}
 
 	return nil
 }
 
-func (r *bookingRepository) Delete(id int) error {
+// UpdateStatus mengubah status booking hanya jika status saat ini masih from.
+// Update tidak menyentuh kolom status, jadi semua perubahan status lewat method
+// ini setelah divalidasi Booking.TransitionTo.
+func (r *bookingRepository) UpdateStatus(ctx context.Context, id int, from, to domain.BookingStatus) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE bookings SET status=$1 WHERE id=$2 AND status=$3`
+
+	result, err := r.db.ExecContext(ctx, query, to, id, from)
+	if err != nil {
+		return fmt.Errorf("error updating booking status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: booking %d is no longer %s", domain.ErrInvalidTransition, id, from)
+	}
+
+	return nil
+}
+
+func (r *bookingRepository) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `DELETE FROM bookings WHERE id=$1`
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("error deleting booking: %w", err)
 	}
@@ -190,18 +359,51 @@ func (r *bookingRepository) Delete(id int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("booking not found")
+		return domain.ErrBookingNotFound
 	}
 
 	return nil
 }
 
-func (r *bookingRepository) CheckAvailability(fieldID int, startTime, endTime time.Time) (bool, error) {
-	query := `SELECT COUNT(*) FROM bookings WHERE field_id=$1 AND status IN ('CONFIRMED', 'PENDING') AND start_time < $3, AND end_time > $2`
+// CountCommittedByUserID menghitung booking customer yang tidak berakhir
+// CANCELLED atau EXPIRED, dipakai untuk promo khusus booking pertama.
+func (r *bookingRepository) CountCommittedByUserID(ctx context.Context, userID int) (int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM bookings WHERE user_id=$1 AND status NOT IN ('CANCELLED', 'EXPIRED')`
 
 	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting user bookings: %w", err)
+	}
 
-	err := r.db.QueryRow(query, fieldID, startTime, endTime).Scan(&count)
+	return count, nil
+}
+
+// CheckAvailability mengecek apakah rentang [startTime, endTime) kosong.
+// Booking aktif menahan slot sampai blocked_until (end_time ditambah buffer),
+// jadi endTime untuk booking baru juga harus sudah termasuk buffer lapangan.
+func (r *bookingRepository) CheckAvailability(ctx context.Context, fieldID int, startTime, endTime time.Time) (bool, error) {
+	return r.CheckAvailabilityExcept(ctx, fieldID, startTime, endTime, 0)
+}
+
+// CheckAvailabilityExcept sama seperti CheckAvailability tetapi mengabaikan
+// booking dengan ID bookingID, dipakai saat booking dipindah ke slot yang
+// beririsan dengan slot lamanya.
+func (r *bookingRepository) CheckAvailabilityExcept(ctx context.Context, fieldID int, startTime, endTime time.Time, bookingID int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Penawaran waitlist yang masih aktif ikut menahan slot, supaya slot
+	// tidak diambil customer lain selama penerima penawaran memutuskan.
+	query := `SELECT
+		(SELECT COUNT(*) FROM bookings WHERE field_id=$1 AND id <> $5 AND ` + activeBookingCondition + ` AND start_time < $3 AND blocked_until > $2) +
+		(SELECT COUNT(*) FROM waitlist_entries WHERE field_id=$1 AND ` + activeOfferCondition + ` AND start_time < $3 AND end_time > $2)`
+
+	var count int
+
+	err := r.db.QueryRowContext(ctx, query, fieldID, startTime, endTime, time.Now(), bookingID).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("error checking availability: %w", err)
 	}
@@ -209,37 +411,104 @@ func (r *bookingRepository) CheckAvailability(fieldID int, startTime, endTime ti
 	return count == 0, nil
 }
 
-func (r *bookingRepository) FindConflictingBookings(fieldID int, startTime, endTime time.Time) ([]*domain.Booking, error) {
-	query := `SELECT id, user_id, field_id, start_time, end_time, total_price, status, created_at FROM bookings WHERE field_id=$1 AND status IN ('CONFIRMED','PENDING') AND start_time < $3 AND end_time < $2 ORDER BY start_time`
+func (r *bookingRepository) FindConflictingBookings(ctx context.Context, fieldID int, startTime, endTime time.Time) ([]*domain.Booking, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE field_id=$1 AND ` + activeBookingCondition + ` AND start_time < $3 AND blocked_until > $2 ORDER BY start_time`
 
-	rows, err := r.db.Query(query, fieldID, startTime, endTime)
+	bookings, err := r.queryBookings(ctx, query, fieldID, startTime, endTime, time.Now())
 	if err != nil {
 		return nil, fmt.Errorf("error finding conflicting bookings: %w", err)
 	}
+
+	return bookings, nil
+}
+
+// FindBlockedRanges mengambil semua rentang yang menahan slot di lapangan
+// fieldIDs dan beririsan dengan [startTime, endTime) dalam satu query:
+// booking aktif sampai blocked_until dan penawaran waitlist yang masih
+// berlaku, sama seperti yang dihitung CheckAvailability. Hasil urut
+// berdasarkan lapangan lalu waktu mulai.
+func (r *bookingRepository) FindBlockedRanges(ctx context.Context, fieldIDs []int, startTime, endTime time.Time) ([]domain.BlockedRange, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT field_id, start_time, blocked_until FROM bookings WHERE field_id = ANY($1) AND ` + activeBookingCondition + ` AND start_time < $3 AND blocked_until > $2
+		UNION ALL
+		SELECT field_id, start_time, end_time FROM waitlist_entries WHERE field_id = ANY($1) AND ` + activeOfferCondition + ` AND start_time < $3 AND end_time > $2
+		ORDER BY 1, 2`
+
+	rows, err := r.db.QueryContext(ctx, query, toInt64Array(fieldIDs), startTime, endTime, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error finding blocked ranges: %w", err)
+	}
 	defer rows.Close()
 
-	bookings := []*domain.Booking{}
+	ranges := []domain.BlockedRange{}
 
 	for rows.Next() {
-		booking := &domain.Booking{}
-		err := rows.Scan(
-			&booking.ID,
-			&booking.UserID,
-			&booking.FieldID,
-			&booking.StartTime,
-			&booking.EndTime,
-			&booking.TotalPrice,
-			&booking.Status,
-			&booking.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("error scanning booking: %w", err)
+		var blocked domain.BlockedRange
+		if err := rows.Scan(&blocked.FieldID, &blocked.Start, &blocked.End); err != nil {
+			return nil, fmt.Errorf("error scanning blocked range: %w", err)
 		}
-		bookings = append(bookings, booking)
+		ranges = append(ranges, blocked)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating bookings: %w", err)
+		return nil, fmt.Errorf("error iterating blocked ranges: %w", err)
+	}
+
+	return ranges, nil
+}
+
+// ExpireHolds mengubah booking PENDING yang hold-nya sudah lewat menjadi EXPIRED
+// dan mengembalikan booking yang diubah. Maksimal limit baris per panggilan.
+func (r *bookingRepository) ExpireHolds(ctx context.Context, now time.Time, limit int) ([]*domain.Booking, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE bookings SET status='EXPIRED' WHERE id IN (
+		SELECT id FROM bookings WHERE status='PENDING' AND expires_at <= $1 ORDER BY expires_at LIMIT $2 FOR UPDATE SKIP LOCKED
+	) RETURNING ` + bookingColumns
+
+	bookings, err := r.queryBookings(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error expiring bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// ExpireOverlappingHolds meng-expire hold kadaluarsa yang beririsan dengan rentang waktu.
+// Dipanggil sebelum insert booking baru, karena constraint bookings_no_overlap
+// masih menganggap booking PENDING aktif sampai statusnya diubah.
+func (r *bookingRepository) ExpireOverlappingHolds(ctx context.Context, fieldID int, startTime, endTime, now time.Time) ([]*domain.Booking, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE bookings SET status='EXPIRED' WHERE field_id=$1 AND status='PENDING' AND expires_at <= $4 AND start_time < $3 AND blocked_until > $2 RETURNING ` + bookingColumns
+
+	bookings, err := r.queryBookings(ctx, query, fieldID, startTime, endTime, now)
+	if err != nil {
+		return nil, fmt.Errorf("error expiring overlapping bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// LockEndedConfirmed mengunci dan mengembalikan booking CONFIRMED yang sudah
+// melewati end_time. Baris yang sedang dikunci transaksi lain dilewati,
+// jadi harus dipanggil di dalam UnitOfWork.
+func (r *bookingRepository) LockEndedConfirmed(ctx context.Context, now time.Time, limit int) ([]*domain.Booking, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE status='CONFIRMED' AND end_time <= $1 ORDER BY end_time LIMIT $2 FOR UPDATE SKIP LOCKED`
+
+	bookings, err := r.queryBookings(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error finding ended bookings: %w", err)
 	}
 
 	return bookings, nil
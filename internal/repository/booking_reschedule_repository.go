@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"time"
+)
+
+type BookingRescheduleRepository interface {
+	Create(ctx context.Context, reschedule *domain.BookingReschedule) error
+	FindByBookingID(ctx context.Context, bookingID int) ([]*domain.BookingReschedule, error)
+}
+
+type bookingRescheduleRepository struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func NewBookingRescheduleRepository(db DBTX, timeout time.Duration) BookingRescheduleRepository {
+	return &bookingRescheduleRepository{db: db, timeout: timeout}
+}
+
+func (r *bookingRescheduleRepository) Create(ctx context.Context, reschedule *domain.BookingReschedule) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO booking_reschedules (booking_id, actor_id, old_start_time, old_end_time, new_start_time, new_end_time, old_price, new_price, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		reschedule.BookingID,
+		reschedule.ActorID,
+		reschedule.OldStartTime,
+		reschedule.OldEndTime,
+		reschedule.NewStartTime,
+		reschedule.NewEndTime,
+		reschedule.OldPrice,
+		reschedule.NewPrice,
+		reschedule.CreatedAt,
+	).Scan(&reschedule.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating booking reschedule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *bookingRescheduleRepository) FindByBookingID(ctx context.Context, bookingID int) ([]*domain.BookingReschedule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, booking_id, actor_id, old_start_time, old_end_time, new_start_time, new_end_time, old_price, new_price, created_at FROM booking_reschedules WHERE booking_id=$1 ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding booking reschedules: %w", err)
+	}
+	defer rows.Close()
+
+	reschedules := []*domain.BookingReschedule{}
+
+	for rows.Next() {
+		reschedule := &domain.BookingReschedule{}
+
+		err := rows.Scan(
+			&reschedule.ID,
+			&reschedule.BookingID,
+			&reschedule.ActorID,
+			&reschedule.OldStartTime,
+			&reschedule.OldEndTime,
+			&reschedule.NewStartTime,
+			&reschedule.NewEndTime,
+			&reschedule.OldPrice,
+			&reschedule.NewPrice,
+			&reschedule.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning booking reschedule: %w", err)
+		}
+
+		reschedules = append(reschedules, reschedule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating booking reschedules: %w", err)
+	}
+
+	return reschedules, nil
+}
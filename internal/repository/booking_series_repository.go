@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"time"
+)
+
+type BookingSeriesRepository interface {
+	Create(ctx context.Context, series *domain.BookingSeries) error
+	FindByID(ctx context.Context, id int) (*domain.BookingSeries, error)
+	UpdateStatus(ctx context.Context, id int, status domain.SeriesStatus) error
+}
+
+type bookingSeriesRepository struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func NewBookingSeriesRepository(db DBTX, timeout time.Duration) BookingSeriesRepository {
+	return &bookingSeriesRepository{db: db, timeout: timeout}
+}
+
+func (r *bookingSeriesRepository) Create(ctx context.Context, series *domain.BookingSeries) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO booking_series (user_id, field_id, frequency, first_start, duration_minutes, occurrences, payment_mode, status, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		series.UserID,
+		series.FieldID,
+		series.Frequency,
+		series.FirstStart,
+		series.DurationMinutes,
+		series.Occurrences,
+		series.PaymentMode,
+		series.Status,
+		series.CreatedAt,
+	).Scan(&series.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating booking series: %w", err)
+	}
+
+	return nil
+}
+
+func (r *bookingSeriesRepository) FindByID(ctx context.Context, id int) (*domain.BookingSeries, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, user_id, field_id, frequency, first_start, duration_minutes, occurrences, payment_mode, status, created_at FROM booking_series WHERE id=$1`
+
+	series := &domain.BookingSeries{}
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&series.ID,
+		&series.UserID,
+		&series.FieldID,
+		&series.Frequency,
+		&series.FirstStart,
+		&series.DurationMinutes,
+		&series.Occurrences,
+		&series.PaymentMode,
+		&series.Status,
+		&series.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrSeriesNotFound
+		}
+		return nil, fmt.Errorf("error finding booking series: %w", err)
+	}
+
+	return series, nil
+}
+
+func (r *bookingSeriesRepository) UpdateStatus(ctx context.Context, id int, status domain.SeriesStatus) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE booking_series SET status=$1 WHERE id=$2`
+
+	result, err := r.db.ExecContext(ctx, query, status, id)
+	if err != nil {
+		return fmt.Errorf("error updating booking series: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrSeriesNotFound
+	}
+
+	return nil
+}
@@ -1,44 +1,157 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"futsal-booking-app/internal/domain"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 type FieldRepository interface {
-	Create(field *domain.Field) error
-	FindByID(id int) (*domain.Field, error)
-	FindByOwnerID(ownerID int) ([]*domain.Field, error)
-	FindAll() ([]*domain.Field, error)
-	Update(field *domain.Field) error
-	Delete(id int) error
-
-	CreateSchedule(schedule *domain.Schedule) error
-	FindScheduleByFieldID(fieldID int) ([]*domain.Schedule, error)
-	UpdateSchedule(schedule *domain.Schedule) error
-	DeleteScheduleByFieldID(fieldID int) error
+	Create(ctx context.Context, field *domain.Field) error
+	FindByID(ctx context.Context, id int) (*domain.Field, error)
+	FindByOwnerID(ctx context.Context, ownerID int) ([]*domain.Field, error)
+	FindByVenueID(ctx context.Context, venueID int) ([]*domain.Field, error)
+	FindAll(ctx context.Context) ([]*domain.Field, error)
+	FindWithinRadius(ctx context.Context, center domain.GeoPoint, radiusKm float64, limit int) ([]domain.FieldDistance, error)
+	FindInBoundingBox(ctx context.Context, box domain.BoundingBox, limit int) ([]*domain.Field, error)
+	Update(ctx context.Context, field *domain.Field) error
+	Delete(ctx context.Context, id int) error
+
+	CreateSchedule(ctx context.Context, schedule *domain.Schedule) error
+	FindScheduleByFieldID(ctx context.Context, fieldID int) ([]*domain.Schedule, error)
+	UpdateSchedule(ctx context.Context, schedule *domain.Schedule) error
+	DeleteScheduleByFieldID(ctx context.Context, fieldID int) error
+
+	CreatePricingRule(ctx context.Context, rule *domain.PricingRule) error
+	FindPricingRulesByFieldID(ctx context.Context, fieldID int) ([]*domain.PricingRule, error)
+	DeletePricingRulesByFieldID(ctx context.Context, fieldID int) error
+
+	CreateScheduleException(ctx context.Context, exception *domain.ScheduleException) error
+	FindScheduleExceptionByID(ctx context.Context, fieldID, id int) (*domain.ScheduleException, error)
+	FindScheduleExceptions(ctx context.Context, fieldID int, from, to time.Time) ([]*domain.ScheduleException, error)
+	DeleteScheduleException(ctx context.Context, fieldID, id int) error
 }
 
 type fieldRepository struct {
-	db *sql.DB
+	db      DBTX
+	timeout time.Duration
+	// earthdistance menandai ekstensi earthdistance terpasang, lihat HasEarthdistance.
+	earthdistance bool
+}
+
+func NewFieldRepository(db DBTX, timeout time.Duration, earthdistance bool) FieldRepository {
+	return &fieldRepository{db: db, timeout: timeout, earthdistance: earthdistance}
 }
 
-func NewFieldRepository(db *sql.DB) FieldRepository {
-	return &fieldRepository{db: db}
+// fieldColumns adalah urutan kolom yang dibaca oleh scanField. Owner, alamat,
+// foto, zona waktu, fasilitas, dan koordinat dibaca dari venue, jadi query harus memakai fieldFrom.
+const fieldColumns = `f.id, f.venue_id, v.owner_id, v.address, v.image_url, v.time_zone, v.amenities, v.latitude, v.longitude, f.name, f.description, f.price_per_hour, f.surface_type, f.indoor, f.payment_hold_minutes, f.full_refund_hours, f.partial_refund_percent, f.waitlist_order, f.waitlist_offer_minutes, f.waitlist_notify_customer, f.waitlist_notify_owner, f.reschedule_min_hours, f.reschedule_max_count, f.slot_minutes, f.min_duration_minutes, f.max_duration_minutes, f.buffer_minutes, f.created_at`
+
+const fieldFrom = ` FROM fields f JOIN venues v ON v.id = f.venue_id`
+
+func scanField(row rowScanner) (*domain.Field, error) {
+	field := &domain.Field{}
+	var amenities pq.StringArray
+	var latitude, longitude sql.NullFloat64
+
+	err := row.Scan(
+		&field.ID,
+		&field.VenueID,
+		&field.OwnerID,
+		&field.Address,
+		&field.ImageURL,
+		&field.TimeZone,
+		&amenities,
+		&latitude,
+		&longitude,
+		&field.Name,
+		&field.Description,
+		&field.PricePerHour,
+		&field.SurfaceType,
+		&field.Indoor,
+		&field.PaymentHoldMinutes,
+		&field.CancellationPolicy.FullRefundHours,
+		&field.CancellationPolicy.PartialRefundPercent,
+		&field.WaitlistPolicy.Order,
+		&field.WaitlistPolicy.OfferMinutes,
+		&field.WaitlistPolicy.NotifyCustomer,
+		&field.WaitlistPolicy.NotifyOwner,
+		&field.ReschedulePolicy.MinHoursBefore,
+		&field.ReschedulePolicy.MaxReschedules,
+		&field.SlotPolicy.SlotMinutes,
+		&field.SlotPolicy.MinDurationMinutes,
+		&field.SlotPolicy.MaxDurationMinutes,
+		&field.SlotPolicy.BufferMinutes,
+		&field.CreatedAt,
+	)
+
+	field.Amenities = []string(amenities)
+	if field.Amenities == nil {
+		field.Amenities = []string{}
+	}
+	field.Coordinates = toGeoPoint(latitude, longitude)
+
+	return field, err
 }
 
-func (r *fieldRepository) Create(field *domain.Field) error {
-	query := `INSERT INTO fields (owner_id, name, address, description, price_per_hour, image_url, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+func (r *fieldRepository) queryFields(ctx context.Context, query string, args ...interface{}) ([]*domain.Field, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields := []*domain.Field{}
 
-	err := r.db.QueryRow(
+	for rows.Next() {
+		field, err := scanField(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning field: %w", err)
+		}
+
+		fields = append(fields, field)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating fields: %w", err)
+	}
+
+	return fields, nil
+}
+
+func (r *fieldRepository) Create(ctx context.Context, field *domain.Field) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO fields (venue_id, name, description, price_per_hour, surface_type, indoor, payment_hold_minutes, full_refund_hours, partial_refund_percent, waitlist_order, waitlist_offer_minutes, waitlist_notify_customer, waitlist_notify_owner, reschedule_min_hours, reschedule_max_count, slot_minutes, min_duration_minutes, max_duration_minutes, buffer_minutes, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
 		query,
-		field.OwnerID,
+		field.VenueID,
 		field.Name,
-		field.Address,
 		field.Description,
 		field.PricePerHour,
-		field.ImageURL,
+		field.SurfaceType,
+		field.Indoor,
+		field.PaymentHoldMinutes,
+		field.CancellationPolicy.FullRefundHours,
+		field.CancellationPolicy.PartialRefundPercent,
+		field.WaitlistPolicy.Order,
+		field.WaitlistPolicy.OfferMinutes,
+		field.WaitlistPolicy.NotifyCustomer,
+		field.WaitlistPolicy.NotifyOwner,
+		field.ReschedulePolicy.MinHoursBefore,
+		field.ReschedulePolicy.MaxReschedules,
+		field.SlotPolicy.SlotMinutes,
+		field.SlotPolicy.MinDurationMinutes,
+		field.SlotPolicy.MaxDurationMinutes,
+		field.SlotPolicy.BufferMinutes,
 		field.CreatedAt,
 	).Scan(&field.ID)
 
@@ -49,25 +162,16 @@ func (r *fieldRepository) Create(field *domain.Field) error {
 	return nil
 }
 
-func (r *fieldRepository) FindByID(id int) (*domain.Field, error) {
-	query := `SELECT id, owner_id, address, description, price_per_hour, image_url, created_at FROM fields WHERE id=$1`
+func (r *fieldRepository) FindByID(ctx context.Context, id int) (*domain.Field, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
 
-	field := &domain.Field{}
-
-	err := r.db.QueryRow(query, id).Scan(
-		&field.ID,
-		&field.OwnerID,
-		&field.Name,
-		&field.Address,
-		&field.Description,
-		&field.PricePerHour,
-		&field.ImageURL,
-		&field.CreatedAt,
-	)
+	query := `SELECT ` + fieldColumns + fieldFrom + ` WHERE f.id=$1`
 
+	field, err := scanField(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("field not found")
+			return nil, domain.ErrFieldNotFound
 		}
 		return nil, fmt.Errorf("error finding field: %w", err)
 	}
@@ -75,93 +179,207 @@ func (r *fieldRepository) FindByID(id int) (*domain.Field, error) {
 	return field, nil
 }
 
-func (r *fieldRepository) FindByOwnerID(ownerID int) ([]*domain.Field, error) {
-	query := `SELECT id, owner_id, name, address, description, price_per_hour, image_url, created_at FROM fields WHERE owner_id=$1 ORDER BY created_at DESC`
+func (r *fieldRepository) FindByOwnerID(ctx context.Context, ownerID int) ([]*domain.Field, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
 
-	rows, err := r.db.Query(query, ownerID)
+	query := `SELECT ` + fieldColumns + fieldFrom + ` WHERE v.owner_id=$1 ORDER BY f.created_at DESC`
+
+	fields, err := r.queryFields(ctx, query, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("error finding fields by owner: %w", err)
 	}
 
-	defer rows.Close()
-
-	fields := []*domain.Field{}
+	return fields, nil
+}
 
-	for rows.Next() {
-		field := &domain.Field{}
-		err := rows.Scan(
-			&field.ID,
-			&field.OwnerID,
-			&field.Name,
-			&field.Address,
-			&field.Description,
-			&field.PricePerHour,
-			&field.ImageURL,
-			&field.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("error scanning field: %w", err)
-		}
+func (r *fieldRepository) FindByVenueID(ctx context.Context, venueID int) ([]*domain.Field, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
 
-		fields = append(fields, field)
-	}
+	query := `SELECT ` + fieldColumns + fieldFrom + ` WHERE f.venue_id=$1 ORDER BY f.id`
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating fields: %w", err)
+	fields, err := r.queryFields(ctx, query, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding fields by venue: %w", err)
 	}
 
 	return fields, nil
 }
 
-func (r *fieldRepository) FindAll() ([]*domain.Field, error) {
-	query := `SELECT id, owner_id, name, address, description, price_per_hour, image_url, created_at FROM fields ORDER BY created_at DESC`
+func (r *fieldRepository) FindAll(ctx context.Context) ([]*domain.Field, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
 
-	rows, err := r.db.Query(query)
+	query := `SELECT ` + fieldColumns + fieldFrom + ` ORDER BY f.created_at DESC`
+
+	fields, err := r.queryFields(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("error finding all fields: %w", err)
 	}
 
+	return fields, nil
+}
+
+// HasEarthdistance mengecek apakah ekstensi earthdistance terpasang. Dipanggil
+// sekali saat startup dan hasilnya diteruskan ke NewFieldRepository. Pengecekan
+// lewat katalog, bukan dengan mencoba query, supaya tidak membatalkan transaksi
+// yang sedang berjalan saat ekstensi tidak ada.
+func HasEarthdistance(ctx context.Context, db DBTX) (bool, error) {
+	var installed bool
+	query := `SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'earthdistance')`
+	if err := db.QueryRowContext(ctx, query).Scan(&installed); err != nil {
+		return false, fmt.Errorf("error checking earthdistance extension: %w", err)
+	}
+
+	return installed, nil
+}
+
+// FindWithinRadius mengambil lapangan yang venue-nya berada paling jauh
+// radiusKm dari center, urut dari yang terdekat. Limit 0 berarti tanpa batas.
+// Jarak dihitung dengan earthdistance jika ekstensinya terpasang, selain itu
+// kandidat disaring dengan bounding box lalu jaraknya dihitung dengan haversine.
+func (r *fieldRepository) FindWithinRadius(ctx context.Context, center domain.GeoPoint, radiusKm float64, limit int) ([]domain.FieldDistance, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if !r.earthdistance {
+		return r.findWithinRadiusHaversine(ctx, center, radiusKm, limit)
+	}
+
+	query := `SELECT ` + fieldColumns + `, earth_distance(ll_to_earth(v.latitude, v.longitude), ll_to_earth($1, $2)) / 1000 AS distance_km` + fieldFrom + `
+		WHERE v.latitude IS NOT NULL
+		AND earth_box(ll_to_earth($1, $2), $3::float8 * 1000) @> ll_to_earth(v.latitude, v.longitude)
+		AND earth_distance(ll_to_earth(v.latitude, v.longitude), ll_to_earth($1, $2)) <= $3::float8 * 1000
+		ORDER BY distance_km, f.id
+		LIMIT NULLIF($4, 0)`
+
+	rows, err := r.db.QueryContext(ctx, query, center.Latitude, center.Longitude, radiusKm, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error finding fields within radius: %w", err)
+	}
 	defer rows.Close()
 
-	fields := []*domain.Field{}
+	result := []domain.FieldDistance{}
 
 	for rows.Next() {
-		field := &domain.Field{}
-		err := rows.Scan(
-			&field.ID,
-			&field.OwnerID,
-			&field.Name,
-			&field.Address,
-			&field.Description,
-			&field.PricePerHour,
-			&field.ImageURL,
-			&field.CreatedAt,
-		)
+		var distance float64
 
+		field, err := scanField(extraColumnScanner{rowScanner: rows, extra: []interface{}{&distance}})
 		if err != nil {
 			return nil, fmt.Errorf("error scanning field: %w", err)
 		}
 
-		fields = append(fields, field)
+		result = append(result, domain.FieldDistance{Field: field, DistanceKm: distance})
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating fields: %w", err)
 	}
 
+	return result, nil
+}
+
+func (r *fieldRepository) findWithinRadiusHaversine(ctx context.Context, center domain.GeoPoint, radiusKm float64, limit int) ([]domain.FieldDistance, error) {
+	fields, err := r.FindInBoundingBox(ctx, domain.BoundingBoxAround(center, radiusKm), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return nearestFields(fields, center, radiusKm, limit), nil
+}
+
+// nearestFields menyaring kandidat dari bounding box dengan jarak haversine
+// lalu mengurutkannya dari yang terdekat. Limit 0 berarti tanpa batas.
+func nearestFields(fields []*domain.Field, center domain.GeoPoint, radiusKm float64, limit int) []domain.FieldDistance {
+	result := []domain.FieldDistance{}
+
+	for _, field := range fields {
+		if field.Coordinates == nil {
+			continue
+		}
+
+		distance := center.DistanceKm(*field.Coordinates)
+		if distance <= radiusKm {
+			result = append(result, domain.FieldDistance{Field: field, DistanceKm: distance})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].DistanceKm != result[j].DistanceKm {
+			return result[i].DistanceKm < result[j].DistanceKm
+		}
+		return result[i].Field.ID < result[j].Field.ID
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
+
+// FindInBoundingBox mengambil lapangan yang venue-nya berada di dalam area
+// peta box, termasuk area yang melewati garis bujur 180°. Limit 0 berarti
+// tanpa batas.
+func (r *fieldRepository) FindInBoundingBox(ctx context.Context, box domain.BoundingBox, limit int) ([]*domain.Field, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	longitude := `v.longitude BETWEEN $2 AND $4`
+	if box.CrossesAntimeridian() {
+		longitude = `(v.longitude >= $2 OR v.longitude <= $4)`
+	}
+
+	query := `SELECT ` + fieldColumns + fieldFrom + ` WHERE v.latitude BETWEEN $1 AND $3 AND ` + longitude + ` ORDER BY f.id LIMIT NULLIF($5, 0)`
+
+	fields, err := r.queryFields(ctx, query, box.SouthWest.Latitude, box.SouthWest.Longitude, box.NorthEast.Latitude, box.NorthEast.Longitude, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error finding fields in bounding box: %w", err)
+	}
+
 	return fields, nil
 }
 
-func (r *fieldRepository) Update(field *domain.Field) error {
-	query := `UPDATE fields SET name=$1, address=$2, description=$3, price_per_hour=$4, image_url=$5 WHERE id=$6`
+// extraColumnScanner menambahkan tujuan scan untuk kolom hitungan (misalnya
+// jarak) di belakang kolom yang dibaca fungsi scanX.
+type extraColumnScanner struct {
+	rowScanner
+	extra []interface{}
+}
+
+func (s extraColumnScanner) Scan(dest ...interface{}) error {
+	return s.rowScanner.Scan(append(dest, s.extra...)...)
+}
+
+func (r *fieldRepository) Update(ctx context.Context, field *domain.Field) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE fields SET venue_id=$1, name=$2, description=$3, price_per_hour=$4, surface_type=$5, indoor=$6, payment_hold_minutes=$7, full_refund_hours=$8, partial_refund_percent=$9, waitlist_order=$10, waitlist_offer_minutes=$11, waitlist_notify_customer=$12, waitlist_notify_owner=$13, reschedule_min_hours=$14, reschedule_max_count=$15, slot_minutes=$16, min_duration_minutes=$17, max_duration_minutes=$18, buffer_minutes=$19 WHERE id=$20`
 
-	result, err := r.db.Exec(
+	result, err := r.db.ExecContext(
+		ctx,
 		query,
+		field.VenueID,
 		field.Name,
-		field.Address,
 		field.Description,
 		field.PricePerHour,
-		field.ImageURL,
+		field.SurfaceType,
+		field.Indoor,
+		field.PaymentHoldMinutes,
+		field.CancellationPolicy.FullRefundHours,
+		field.CancellationPolicy.PartialRefundPercent,
+		field.WaitlistPolicy.Order,
+		field.WaitlistPolicy.OfferMinutes,
+		field.WaitlistPolicy.NotifyCustomer,
+		field.WaitlistPolicy.NotifyOwner,
+		field.ReschedulePolicy.MinHoursBefore,
+		field.ReschedulePolicy.MaxReschedules,
+		field.SlotPolicy.SlotMinutes,
+		field.SlotPolicy.MinDurationMinutes,
+		field.SlotPolicy.MaxDurationMinutes,
+		field.SlotPolicy.BufferMinutes,
 		field.ID,
 	)
 
@@ -175,16 +393,19 @@ func (r *fieldRepository) Update(field *domain.Field) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("field not found")
+		return domain.ErrFieldNotFound
 	}
 
 	return nil
 }
 
-func (r *fieldRepository) Delete(id int) error {
+func (r *fieldRepository) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `DELETE FROM fields WHERE id=$1`
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("error deleting field: %w", err)
 	}
@@ -195,16 +416,20 @@ func (r *fieldRepository) Delete(id int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("field not found")
+		return domain.ErrFieldNotFound
 	}
 
 	return nil
 }
 
-func (r *fieldRepository) CreateSchedule(schedule *domain.Schedule) error {
+func (r *fieldRepository) CreateSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `INSERT INTO schedules (field_id, day_of_week, open_time, close_time) VALUES ($1, $2, $3, $4) RETURNING id`
 
-	err := r.db.QueryRow(
+	err := r.db.QueryRowContext(
+		ctx,
 		query,
 		schedule.FieldID,
 		schedule.DayOfWeek,
@@ -219,10 +444,13 @@ func (r *fieldRepository) CreateSchedule(schedule *domain.Schedule) error {
 	return nil
 }
 
-func (r *fieldRepository) FindScheduleByFieldID(fieldID int) ([]*domain.Schedule, error) {
-	query := `SELECT id, field_id, day_of_week, open_time, close_time FROM schedules WHERE field_id=$1 ORDER BY day_of_week`
+func (r *fieldRepository) FindScheduleByFieldID(ctx context.Context, fieldID int) ([]*domain.Schedule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
 
-	rows, err := r.db.Query(query, fieldID)
+	query := `SELECT id, field_id, day_of_week, open_time, close_time FROM schedules WHERE field_id=$1 ORDER BY day_of_week, open_time`
+
+	rows, err := r.db.QueryContext(ctx, query, fieldID)
 	if err != nil {
 		return nil, fmt.Errorf("error finding schedules: %w", err)
 	}
@@ -252,10 +480,14 @@ func (r *fieldRepository) FindScheduleByFieldID(fieldID int) ([]*domain.Schedule
 	return schedules, nil
 }
 
-func (r *fieldRepository) UpdateSchedule(schedule *domain.Schedule) error {
+func (r *fieldRepository) UpdateSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `UPDATE schedules SET day_of_week=$1, open_time=$2, close_time=$3 WHERE id=$4`
 
-	result, err := r.db.Exec(
+	result, err := r.db.ExecContext(
+		ctx,
 		query,
 		schedule.DayOfWeek,
 		schedule.OpenTime,
@@ -273,19 +505,225 @@ func (r *fieldRepository) UpdateSchedule(schedule *domain.Schedule) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("schedule not found")
+		return domain.ErrScheduleNotFound
 	}
 
 	return nil
 }
 
-func (r *fieldRepository) DeleteScheduleByFieldID(fieldID int) error {
+func (r *fieldRepository) DeleteScheduleByFieldID(ctx context.Context, fieldID int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `DELETE FROM schedules WHERE field_id=$1`
 
-	_, err := r.db.Query(query, fieldID)
+	_, err := r.db.ExecContext(ctx, query, fieldID)
 	if err != nil {
 		return fmt.Errorf("error deleting schedules: %w", err)
 	}
 
 	return nil
 }
+
+func (r *fieldRepository) CreatePricingRule(ctx context.Context, rule *domain.PricingRule) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO pricing_rules (field_id, name, day_of_week, start_minute, end_minute, start_date, end_date, price_per_hour, multiplier, priority, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		rule.FieldID,
+		rule.Name,
+		rule.DayOfWeek,
+		rule.StartMinute,
+		rule.EndMinute,
+		rule.StartDate,
+		rule.EndDate,
+		rule.PricePerHour,
+		rule.Multiplier,
+		rule.Priority,
+		rule.CreatedAt,
+	).Scan(&rule.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating pricing rule: %w", err)
+	}
+
+	return nil
+}
+
+// FindPricingRulesByFieldID mengambil semua pricing rule lapangan, diurutkan
+// dari prioritas tertinggi.
+func (r *fieldRepository) FindPricingRulesByFieldID(ctx context.Context, fieldID int) ([]*domain.PricingRule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, field_id, name, day_of_week, start_minute, end_minute, start_date, end_date, price_per_hour, multiplier, priority, created_at FROM pricing_rules WHERE field_id=$1 ORDER BY priority DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding pricing rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []*domain.PricingRule{}
+
+	for rows.Next() {
+		rule := &domain.PricingRule{}
+		err := rows.Scan(
+			&rule.ID,
+			&rule.FieldID,
+			&rule.Name,
+			&rule.DayOfWeek,
+			&rule.StartMinute,
+			&rule.EndMinute,
+			&rule.StartDate,
+			&rule.EndDate,
+			&rule.PricePerHour,
+			&rule.Multiplier,
+			&rule.Priority,
+			&rule.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning pricing rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pricing rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *fieldRepository) DeletePricingRulesByFieldID(ctx context.Context, fieldID int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `DELETE FROM pricing_rules WHERE field_id=$1`
+
+	_, err := r.db.ExecContext(ctx, query, fieldID)
+	if err != nil {
+		return fmt.Errorf("error deleting pricing rules: %w", err)
+	}
+
+	return nil
+}
+
+const scheduleExceptionColumns = `id, field_id, date, type, start_minute, end_minute, reason, created_at`
+
+func scanScheduleException(row rowScanner) (*domain.ScheduleException, error) {
+	exception := &domain.ScheduleException{}
+
+	err := row.Scan(
+		&exception.ID,
+		&exception.FieldID,
+		&exception.Date,
+		&exception.Type,
+		&exception.StartMinute,
+		&exception.EndMinute,
+		&exception.Reason,
+		&exception.CreatedAt,
+	)
+
+	return exception, err
+}
+
+func (r *fieldRepository) CreateScheduleException(ctx context.Context, exception *domain.ScheduleException) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO schedule_exceptions (field_id, date, type, start_minute, end_minute, reason, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		exception.FieldID,
+		exception.Date,
+		exception.Type,
+		exception.StartMinute,
+		exception.EndMinute,
+		exception.Reason,
+		exception.CreatedAt,
+	).Scan(&exception.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating schedule exception: %w", err)
+	}
+
+	return nil
+}
+
+func (r *fieldRepository) FindScheduleExceptionByID(ctx context.Context, fieldID, id int) (*domain.ScheduleException, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + scheduleExceptionColumns + ` FROM schedule_exceptions WHERE field_id=$1 AND id=$2`
+
+	exception, err := scanScheduleException(r.db.QueryRowContext(ctx, query, fieldID, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrScheduleNotFound
+		}
+		return nil, fmt.Errorf("error finding schedule exception: %w", err)
+	}
+
+	return exception, nil
+}
+
+// FindScheduleExceptions mengambil exception lapangan pada rentang tanggal
+// from sampai to (inklusif).
+func (r *fieldRepository) FindScheduleExceptions(ctx context.Context, fieldID int, from, to time.Time) ([]*domain.ScheduleException, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + scheduleExceptionColumns + ` FROM schedule_exceptions WHERE field_id=$1 AND date BETWEEN $2::date AND $3::date ORDER BY date, start_minute, id`
+
+	rows, err := r.db.QueryContext(ctx, query, fieldID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error finding schedule exceptions: %w", err)
+	}
+	defer rows.Close()
+
+	exceptions := []*domain.ScheduleException{}
+
+	for rows.Next() {
+		exception, err := scanScheduleException(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning schedule exception: %w", err)
+		}
+		exceptions = append(exceptions, exception)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating schedule exceptions: %w", err)
+	}
+
+	return exceptions, nil
+}
+
+func (r *fieldRepository) DeleteScheduleException(ctx context.Context, fieldID, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `DELETE FROM schedule_exceptions WHERE field_id=$1 AND id=$2`
+
+	result, err := r.db.ExecContext(ctx, query, fieldID, id)
+	if err != nil {
+		return fmt.Errorf("error deleting schedule exception: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrScheduleNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"futsal-booking-app/internal/domain"
+	"testing"
+)
+
+func TestNearestFields(t *testing.T) {
+	center := domain.GeoPoint{Latitude: 0, Longitude: 179.95}
+
+	// Kandidat dari bounding box; sebagian berada di sudut box tapi di luar radius
+	point := func(lat, lng float64) *domain.GeoPoint { return &domain.GeoPoint{Latitude: lat, Longitude: lng} }
+	fields := []*domain.Field{
+		{ID: 1, Coordinates: point(0, -179.98)},
+		{ID: 2, Coordinates: point(0, 179.9)},
+		{ID: 3, Coordinates: point(0.4, -179.65)},
+		{ID: 4, Coordinates: point(0, 179.8)},
+		{ID: 5},
+		{ID: 6, Coordinates: point(0, 179.8)},
+	}
+
+	if box := domain.BoundingBoxAround(center, 50); !box.Contains(*fields[2].Coordinates) {
+		t.Fatalf("field 3 must lie in the 50 km bounding box %+v", box)
+	}
+
+	tests := []struct {
+		name     string
+		radiusKm float64
+		limit    int
+		wantIDs  []int
+	}{
+		// Field 4 dan 6 berjarak sama; ID terkecil duluan
+		{"sorted by distance then ID", 20, 0, []int{2, 1, 4, 6}},
+		{"limit", 20, 2, []int{2, 1}},
+		{"box corner outside radius is dropped", 50, 0, []int{2, 1, 4, 6}},
+		{"large radius includes the corner", 70, 0, []int{2, 1, 4, 6, 3}},
+		{"nothing within radius", 1, 0, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nearestFields(fields, center, tt.radiusKm, tt.limit)
+
+			ids := []int{}
+			for _, f := range got {
+				ids = append(ids, f.Field.ID)
+				if f.DistanceKm > tt.radiusKm {
+					t.Errorf("field %d distance %f exceeds radius %f", f.Field.ID, f.DistanceKm, tt.radiusKm)
+				}
+			}
+
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Fatalf("ids = %v, want %v", ids, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"strings"
+	"time"
+)
+
+// JobRunFilter membatasi hasil FindRecent. Field kosong berarti tanpa filter.
+type JobRunFilter struct {
+	JobName string
+	Status  domain.JobRunStatus
+	Limit   int
+}
+
+type JobRunRepository interface {
+	Create(ctx context.Context, run *domain.JobRun) error
+	Update(ctx context.Context, run *domain.JobRun) error
+	FindRecent(ctx context.Context, filter JobRunFilter) ([]*domain.JobRun, error)
+}
+
+type jobRunRepository struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func NewJobRunRepository(db DBTX, timeout time.Duration) JobRunRepository {
+	return &jobRunRepository{db: db, timeout: timeout}
+}
+
+func (r *jobRunRepository) Create(ctx context.Context, run *domain.JobRun) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO job_runs (job_name, instance_id, status, processed, error, started_at, finished_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		run.JobName,
+		run.InstanceID,
+		run.Status,
+		run.Processed,
+		run.Error,
+		run.StartedAt,
+		run.FinishedAt,
+	).Scan(&run.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating job run: %w", err)
+	}
+
+	return nil
+}
+
+func (r *jobRunRepository) Update(ctx context.Context, run *domain.JobRun) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE job_runs SET status=$1, processed=$2, error=$3, finished_at=$4 WHERE id=$5`
+
+	_, err := r.db.ExecContext(ctx, query, run.Status, run.Processed, run.Error, run.FinishedAt, run.ID)
+	if err != nil {
+		return fmt.Errorf("error updating job run: %w", err)
+	}
+
+	return nil
+}
+
+func (r *jobRunRepository) FindRecent(ctx context.Context, filter JobRunFilter) ([]*domain.JobRun, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+
+	if filter.JobName != "" {
+		args = append(args, filter.JobName)
+		conditions = append(conditions, fmt.Sprintf("job_name=$%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status=$%d", len(args)))
+	}
+
+	query := `SELECT id, job_name, instance_id, status, processed, error, started_at, finished_at FROM job_runs`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(` ORDER BY started_at DESC LIMIT $%d`, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error finding job runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := []*domain.JobRun{}
+
+	for rows.Next() {
+		run := &domain.JobRun{}
+
+		err := rows.Scan(
+			&run.ID,
+			&run.JobName,
+			&run.InstanceID,
+			&run.Status,
+			&run.Processed,
+			&run.Error,
+			&run.StartedAt,
+			&run.FinishedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning job run: %w", err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job runs: %w", err)
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"time"
+)
+
+type PaymentAdjustmentRepository interface {
+	Create(ctx context.Context, adjustment *domain.PaymentAdjustment) error
+	FindByBookingID(ctx context.Context, bookingID int) ([]*domain.PaymentAdjustment, error)
+	FindByTransactionIDForUpdate(ctx context.Context, transactionID string) (*domain.PaymentAdjustment, error)
+	Update(ctx context.Context, adjustment *domain.PaymentAdjustment) error
+	UpdatePaymentURL(ctx context.Context, id int, paymentURL string) error
+	LockExpiredPending(ctx context.Context, now time.Time, limit int) ([]*domain.PaymentAdjustment, error)
+}
+
+// paymentAdjustmentColumns adalah urutan kolom yang dibaca oleh scanPaymentAdjustment.
+const paymentAdjustmentColumns = `id, payment_id, booking_id, amount, reason, transaction_id, payment_url, status, expires_at, created_at, updated_at`
+
+type paymentAdjustmentRepository struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func NewPaymentAdjustmentRepository(db DBTX, timeout time.Duration) PaymentAdjustmentRepository {
+	return &paymentAdjustmentRepository{db: db, timeout: timeout}
+}
+
+func scanPaymentAdjustment(row rowScanner) (*domain.PaymentAdjustment, error) {
+	adjustment := &domain.PaymentAdjustment{}
+
+	err := row.Scan(
+		&adjustment.ID,
+		&adjustment.PaymentID,
+		&adjustment.BookingID,
+		&adjustment.Amount,
+		&adjustment.Reason,
+		&adjustment.TransactionID,
+		&adjustment.PaymentURL,
+		&adjustment.Status,
+		&adjustment.ExpiresAt,
+		&adjustment.CreatedAt,
+		&adjustment.UpdatedAt,
+	)
+
+	return adjustment, err
+}
+
+func (r *paymentAdjustmentRepository) Create(ctx context.Context, adjustment *domain.PaymentAdjustment) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO payment_adjustments (payment_id, booking_id, amount, reason, transaction_id, payment_url, status, expires_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		adjustment.PaymentID,
+		adjustment.BookingID,
+		adjustment.Amount,
+		adjustment.Reason,
+		adjustment.TransactionID,
+		adjustment.PaymentURL,
+		adjustment.Status,
+		adjustment.ExpiresAt,
+		adjustment.CreatedAt,
+		adjustment.UpdatedAt,
+	).Scan(&adjustment.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating payment adjustment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *paymentAdjustmentRepository) FindByBookingID(ctx context.Context, bookingID int) ([]*domain.PaymentAdjustment, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + paymentAdjustmentColumns + ` FROM payment_adjustments WHERE booking_id=$1 ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding payment adjustments: %w", err)
+	}
+	defer rows.Close()
+
+	adjustments := []*domain.PaymentAdjustment{}
+
+	for rows.Next() {
+		adjustment, err := scanPaymentAdjustment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning payment adjustment: %w", err)
+		}
+
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment adjustments: %w", err)
+	}
+
+	return adjustments, nil
+}
+
+// FindByTransactionIDForUpdate mengunci adjustment sampai transaksi selesai.
+// Harus dipanggil di dalam UnitOfWork.
+func (r *paymentAdjustmentRepository) FindByTransactionIDForUpdate(ctx context.Context, transactionID string) (*domain.PaymentAdjustment, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + paymentAdjustmentColumns + ` FROM payment_adjustments WHERE transaction_id=$1 FOR UPDATE`
+
+	adjustment, err := scanPaymentAdjustment(r.db.QueryRowContext(ctx, query, transactionID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("error finding payment adjustment: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+func (r *paymentAdjustmentRepository) Update(ctx context.Context, adjustment *domain.PaymentAdjustment) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE payment_adjustments SET status=$1, updated_at=$2 WHERE id=$3`
+
+	result, err := r.db.ExecContext(ctx, query, adjustment.Status, adjustment.UpdatedAt, adjustment.ID)
+	if err != nil {
+		return fmt.Errorf("error updating payment adjustment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrPaymentNotFound
+	}
+
+	return nil
+}
+
+// UpdatePaymentURL menyimpan URL pembayaran dari gateway tanpa menyentuh status,
+// karena charge dibuat setelah adjustment-nya di-commit.
+func (r *paymentAdjustmentRepository) UpdatePaymentURL(ctx context.Context, id int, paymentURL string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE payment_adjustments SET payment_url=$1, updated_at=$2 WHERE id=$3`
+
+	result, err := r.db.ExecContext(ctx, query, paymentURL, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("error updating payment adjustment URL: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrPaymentNotFound
+	}
+
+	return nil
+}
+
+// LockExpiredPending mengunci adjustment PENDING yang sudah melewati batas
+// pembayarannya dengan FOR UPDATE SKIP LOCKED, maksimal limit baris.
+// Harus dipanggil di dalam UnitOfWork.
+func (r *paymentAdjustmentRepository) LockExpiredPending(ctx context.Context, now time.Time, limit int) ([]*domain.PaymentAdjustment, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + paymentAdjustmentColumns + ` FROM payment_adjustments WHERE status='PENDING' AND expires_at <= $1 ORDER BY expires_at LIMIT $2 FOR UPDATE SKIP LOCKED`
+
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error finding expired payment adjustments: %w", err)
+	}
+	defer rows.Close()
+
+	adjustments := []*domain.PaymentAdjustment{}
+
+	for rows.Next() {
+		adjustment, err := scanPaymentAdjustment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning payment adjustment: %w", err)
+		}
+
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment adjustments: %w", err)
+	}
+
+	return adjustments, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"time"
+)
+
+type PaymentHistoryRepository interface {
+	Create(ctx context.Context, change *domain.PaymentStatusChange) error
+}
+
+type paymentHistoryRepository struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func NewPaymentHistoryRepository(db DBTX, timeout time.Duration) PaymentHistoryRepository {
+	return &paymentHistoryRepository{db: db, timeout: timeout}
+}
+
+func (r *paymentHistoryRepository) Create(ctx context.Context, change *domain.PaymentStatusChange) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO payment_status_history (payment_id, from_status, to_status, reason, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		change.PaymentID,
+		change.FromStatus,
+		change.ToStatus,
+		change.Reason,
+		change.CreatedAt,
+	).Scan(&change.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating payment status history: %w", err)
+	}
+
+	return nil
+}
@@ -1,68 +1,66 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"futsal-booking-app/internal/domain"
+	"time"
 )
 
 type PaymentRepository interface {
-	Create(payment *domain.Payment) error
-	FindByID(id int) (*domain.Payment, error)
-	FindByBookingID(bookingID int) (*domain.Payment, error)
-	FindByTransactionID(transactionID string) (*domain.Payment, error)
-	Update(payment *domain.Payment) error
-	Delete(id int) error
+	Create(ctx context.Context, payment *domain.Payment) error
+	FindByID(ctx context.Context, id int) (*domain.Payment, error)
+	FindByBookingID(ctx context.Context, bookingID int) (*domain.Payment, error)
+	FindBySeriesID(ctx context.Context, seriesID int) (*domain.Payment, error)
+	FindByTransactionID(ctx context.Context, transactionID string) (*domain.Payment, error)
+	FindByTransactionIDForUpdate(ctx context.Context, transactionID string) (*domain.Payment, error)
+	Update(ctx context.Context, payment *domain.Payment) error
+	UpdatePaymentURL(ctx context.Context, id int, paymentURL string) error
+	Delete(ctx context.Context, id int) error
+	CreateSupersededCharge(ctx context.Context, charge *domain.SupersededCharge) error
+	FindSupersededChargeForUpdate(ctx context.Context, transactionID string) (*domain.SupersededCharge, error)
 }
 
+// paymentColumns adalah urutan kolom yang dibaca oleh scanPayment.
+const paymentColumns = `id, booking_id, series_id, amount, payment_gateway, transaction_id, payment_url, status, created_at, updated_at`
+
 type paymentRepository struct {
-	db *sql.DB
+	db      DBTX
+	timeout time.Duration
 }
 
-func NewPaymentRepository(db *sql.DB) PaymentRepository {
-	return &paymentRepository{db: db}
+func NewPaymentRepository(db DBTX, timeout time.Duration) PaymentRepository {
+	return &paymentRepository{db: db, timeout: timeout}
 }
 
-func (r *paymentRepository) Create(payment *domain.Payment) error {
-	query := `INSERT INTO payments (booking_id, amount, payment_gateway, transaction_id, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
-
-	err := r.db.QueryRow(
-		query,
-		payment.BookingID,
-		payment.Amount,
-		payment.PaymentGateway,
-		payment.TransactionID,
-		payment.Status,
-		payment.CreatedAt,
-		payment.UpdatedAt,
-	).Scan(&payment.ID)
-
-	if err != nil {
-		return fmt.Errorf("error creating payment: %w", err)
-	}
-
-	return nil
-}
-
-func (r *paymentRepository) FindByID(id int) (*domain.Payment, error) {
-	query := `SELECT id, booking_id, amount, payment_gateway, transaction_id, status, created_at, updated_at FROM payments WHERE id=$1`
-
+func scanPayment(row rowScanner) (*domain.Payment, error) {
 	payment := &domain.Payment{}
 
-	err := r.db.QueryRow(query, id).Scan(
+	err := row.Scan(
 		&payment.ID,
 		&payment.BookingID,
+		&payment.SeriesID,
 		&payment.Amount,
 		&payment.PaymentGateway,
 		&payment.TransactionID,
+		&payment.PaymentURL,
 		&payment.Status,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
 	)
 
+	return payment, err
+}
+
+func (r *paymentRepository) findOne(ctx context.Context, query string, arg interface{}) (*domain.Payment, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	payment, err := scanPayment(r.db.QueryRowContext(ctx, query, arg))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("payment not found")
+			return nil, domain.ErrPaymentNotFound
 		}
 		return nil, fmt.Errorf("error finding payment: %w", err)
 	}
@@ -70,67 +68,68 @@ func (r *paymentRepository) FindByID(id int) (*domain.Payment, error) {
 	return payment, nil
 }
 
-func (r *paymentRepository) FindByBookingID(bookingID int) (*domain.Payment, error) {
-	query := `SELECT id, booking_id, amount, payment_gateway, transaction_Id, status, created_at, updated_at FROM payments WHERE booking_id=$1`
+func (r *paymentRepository) Create(ctx context.Context, payment *domain.Payment) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
 
-	payment := &domain.Payment{}
+	query := `INSERT INTO payments (booking_id, series_id, amount, payment_gateway, transaction_id, payment_url, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
 
-	err := r.db.QueryRow(query, bookingID).Scan(
-		&payment.ID,
-		&payment.BookingID,
-		&payment.Amount,
-		&payment.PaymentGateway,
-		&payment.TransactionID,
-		&payment.Status,
-		&payment.CreatedAt,
-		&payment.UpdatedAt,
-	)
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		payment.BookingID,
+		payment.SeriesID,
+		payment.Amount,
+		payment.PaymentGateway,
+		payment.TransactionID,
+		payment.PaymentURL,
+		payment.Status,
+		payment.CreatedAt,
+		payment.UpdatedAt,
+	).Scan(&payment.ID)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("payment not found")
-		}
-		return nil, fmt.Errorf("error finding payment: %w", err)
+		return fmt.Errorf("error creating payment: %w", err)
 	}
 
-	return payment, nil
+	return nil
 }
 
-func (r *paymentRepository) FindByTransactionID(transactionID string) (*domain.Payment, error) {
-	query := `SELECT id, booking_id, amount, payment_gateway, transaction_id, status, created_at, updated_at FROM payments WHERE transaction_id=$1`
+func (r *paymentRepository) FindByID(ctx context.Context, id int) (*domain.Payment, error) {
+	return r.findOne(ctx, `SELECT `+paymentColumns+` FROM payments WHERE id=$1`, id)
+}
 
-	payment := &domain.Payment{}
+func (r *paymentRepository) FindByBookingID(ctx context.Context, bookingID int) (*domain.Payment, error) {
+	return r.findOne(ctx, `SELECT `+paymentColumns+` FROM payments WHERE booking_id=$1`, bookingID)
+}
 
-	err := r.db.QueryRow(query, transactionID).Scan(
-		&payment.ID,
-		&payment.BookingID,
-		&payment.Amount,
-		&payment.PaymentGateway,
-		&payment.TransactionID,
-		&payment.Status,
-		&payment.CreatedAt,
-		&payment.UpdatedAt,
-	)
+func (r *paymentRepository) FindBySeriesID(ctx context.Context, seriesID int) (*domain.Payment, error) {
+	return r.findOne(ctx, `SELECT `+paymentColumns+` FROM payments WHERE series_id=$1`, seriesID)
+}
 
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("payment not found")
-		}
-		return nil, fmt.Errorf("error finding payment: %w", err)
-	}
+func (r *paymentRepository) FindByTransactionID(ctx context.Context, transactionID string) (*domain.Payment, error) {
+	return r.findOne(ctx, `SELECT `+paymentColumns+` FROM payments WHERE transaction_id=$1`, transactionID)
+}
 
-	return payment, nil
+// FindByTransactionIDForUpdate mengunci baris payment sampai transaksi selesai,
+// sehingga notifikasi gateway untuk transaksi yang sama diproses berurutan.
+func (r *paymentRepository) FindByTransactionIDForUpdate(ctx context.Context, transactionID string) (*domain.Payment, error) {
+	return r.findOne(ctx, `SELECT `+paymentColumns+` FROM payments WHERE transaction_id=$1 FOR UPDATE`, transactionID)
 }
 
-func (r *paymentRepository) Update(payment *domain.Payment) error {
-	query := `UPDATE payments SET booking_id=$1, amount=$2, payment_gateway=$3, transaction_id=$4, status=$5, updated_at=$6 WHERE id=$7`
+func (r *paymentRepository) Update(ctx context.Context, payment *domain.Payment) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE payments SET amount=$1, payment_gateway=$2, transaction_id=$3, payment_url=$4, status=$5, updated_at=$6 WHERE id=$7`
 
-	result, err := r.db.Exec(
+	result, err := r.db.ExecContext(
+		ctx,
 		query,
-		payment.BookingID,
 		payment.Amount,
 		payment.PaymentGateway,
 		payment.TransactionID,
+		payment.PaymentURL,
 		payment.Status,
 		payment.UpdatedAt,
 		payment.ID,
@@ -146,16 +145,44 @@ func (r *paymentRepository) Update(payment *domain.Payment) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("payment not found")
+		return domain.ErrPaymentNotFound
 	}
 
 	return nil
 }
 
-func (r *paymentRepository) Delete(id int) error {
+// UpdatePaymentURL menyimpan URL pembayaran dari gateway tanpa menyentuh status,
+// karena charge dibuat setelah payment-nya di-commit.
+func (r *paymentRepository) UpdatePaymentURL(ctx context.Context, id int, paymentURL string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE payments SET payment_url=$1, updated_at=$2 WHERE id=$3`
+
+	result, err := r.db.ExecContext(ctx, query, paymentURL, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("error updating payment URL: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrPaymentNotFound
+	}
+
+	return nil
+}
+
+func (r *paymentRepository) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `DELETE FROM payments WHERE id=$1`
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("error deleting payment: %w", err)
 	}
@@ -166,8 +193,51 @@ func (r *paymentRepository) Delete(id int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("payment not found")
+		return domain.ErrPaymentNotFound
+	}
+
+	return nil
+}
+
+// CreateSupersededCharge mencatat charge lama payment sebelum transaction ID
+// payment diganti charge baru.
+func (r *paymentRepository) CreateSupersededCharge(ctx context.Context, charge *domain.SupersededCharge) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO superseded_charges (transaction_id, payment_id, booking_id, amount, created_at) VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.db.ExecContext(ctx, query, charge.TransactionID, charge.PaymentID, charge.BookingID, charge.Amount, charge.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error creating superseded charge: %w", err)
 	}
 
 	return nil
 }
+
+// FindSupersededChargeForUpdate mengunci charge lama sampai transaksi selesai,
+// sehingga notifikasi duplikat untuk charge yang sama diproses berurutan.
+func (r *paymentRepository) FindSupersededChargeForUpdate(ctx context.Context, transactionID string) (*domain.SupersededCharge, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT transaction_id, payment_id, booking_id, amount, created_at FROM superseded_charges WHERE transaction_id=$1 FOR UPDATE`
+
+	charge := &domain.SupersededCharge{}
+
+	err := r.db.QueryRowContext(ctx, query, transactionID).Scan(
+		&charge.TransactionID,
+		&charge.PaymentID,
+		&charge.BookingID,
+		&charge.Amount,
+		&charge.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("error finding superseded charge: %w", err)
+	}
+
+	return charge, nil
+}
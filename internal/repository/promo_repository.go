@@ -0,0 +1,363 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type PromoRepository interface {
+	Create(ctx context.Context, promo *domain.PromoCode) error
+	FindByID(ctx context.Context, id int) (*domain.PromoCode, error)
+	FindByCodeForUpdate(ctx context.Context, code string) (*domain.PromoCode, error)
+	FindActiveByBookingID(ctx context.Context, bookingID int) (*domain.PromoCode, error)
+	FindByCreator(ctx context.Context, userID int) ([]*domain.PromoCode, error)
+	FindAll(ctx context.Context) ([]*domain.PromoCode, error)
+	Update(ctx context.Context, promo *domain.PromoCode) error
+
+	CreateRedemption(ctx context.Context, redemption *domain.PromoRedemption) error
+	FindRedemptionsByPromoID(ctx context.Context, promoID int) ([]*domain.PromoRedemption, error)
+	CountActiveRedemptions(ctx context.Context, promoID int) (int, error)
+	CountActiveRedemptionsByUser(ctx context.Context, promoID, userID int) (int, error)
+	ReleaseRedemption(ctx context.Context, bookingID int, now time.Time) error
+	UpdateRedemptionDiscount(ctx context.Context, bookingID, discountAmount int) error
+}
+
+type promoRepository struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func NewPromoRepository(db DBTX, timeout time.Duration) PromoRepository {
+	return &promoRepository{db: db, timeout: timeout}
+}
+
+// promoColumns adalah urutan kolom yang dibaca oleh scanPromo.
+const promoColumns = `id, code, created_by, discount_type, discount_value, field_ids, min_spend, valid_from, valid_until, max_uses, max_uses_per_user, first_booking_only, active, created_at, updated_at`
+
+// promoCodeIndex adalah unique index kode promo pada migrasi 0015.
+const promoCodeIndex = "idx_promo_codes_code"
+
+func scanPromo(row rowScanner) (*domain.PromoCode, error) {
+	promo := &domain.PromoCode{}
+	var createdBy sql.NullInt64
+	var fieldIDs pq.Int64Array
+
+	err := row.Scan(
+		&promo.ID,
+		&promo.Code,
+		&createdBy,
+		&promo.DiscountType,
+		&promo.DiscountValue,
+		&fieldIDs,
+		&promo.MinSpend,
+		&promo.ValidFrom,
+		&promo.ValidUntil,
+		&promo.MaxUses,
+		&promo.MaxUsesPerUser,
+		&promo.FirstBookingOnly,
+		&promo.Active,
+		&promo.CreatedAt,
+		&promo.UpdatedAt,
+	)
+
+	promo.CreatedBy = int(createdBy.Int64)
+	promo.FieldIDs = make([]int, 0, len(fieldIDs))
+	for _, id := range fieldIDs {
+		promo.FieldIDs = append(promo.FieldIDs, int(id))
+	}
+
+	return promo, err
+}
+
+func toInt64Array(ids []int) pq.Int64Array {
+	arr := make(pq.Int64Array, 0, len(ids))
+	for _, id := range ids {
+		arr = append(arr, int64(id))
+	}
+	return arr
+}
+
+func (r *promoRepository) queryPromos(ctx context.Context, query string, args ...interface{}) ([]*domain.PromoCode, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promos := []*domain.PromoCode{}
+
+	for rows.Next() {
+		promo, err := scanPromo(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning promo code: %w", err)
+		}
+		promos = append(promos, promo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating promo codes: %w", err)
+	}
+
+	return promos, nil
+}
+
+func (r *promoRepository) Create(ctx context.Context, promo *domain.PromoCode) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO promo_codes (code, created_by, discount_type, discount_value, field_ids, min_spend, valid_from, valid_until, max_uses, max_uses_per_user, first_booking_only, active, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		promo.Code,
+		promo.CreatedBy,
+		promo.DiscountType,
+		promo.DiscountValue,
+		toInt64Array(promo.FieldIDs),
+		promo.MinSpend,
+		promo.ValidFrom,
+		promo.ValidUntil,
+		promo.MaxUses,
+		promo.MaxUsesPerUser,
+		promo.FirstBookingOnly,
+		promo.Active,
+		promo.CreatedAt,
+		promo.UpdatedAt,
+	).Scan(&promo.ID)
+
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Constraint == promoCodeIndex {
+			return domain.ErrPromoCodeExists
+		}
+		return fmt.Errorf("error creating promo code: %w", err)
+	}
+
+	return nil
+}
+
+func (r *promoRepository) findOne(ctx context.Context, query string, args ...interface{}) (*domain.PromoCode, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	promo, err := scanPromo(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrPromoNotFound
+		}
+		return nil, fmt.Errorf("error finding promo code: %w", err)
+	}
+
+	return promo, nil
+}
+
+func (r *promoRepository) FindByID(ctx context.Context, id int) (*domain.PromoCode, error) {
+	return r.findOne(ctx, `SELECT `+promoColumns+` FROM promo_codes WHERE id=$1`, id)
+}
+
+// FindByCodeForUpdate mengunci baris promo sehingga pengecekan batas
+// pemakaian dan pembuatan redemption tidak balapan dengan checkout lain.
+// Harus dipanggil di dalam UnitOfWork.
+func (r *promoRepository) FindByCodeForUpdate(ctx context.Context, code string) (*domain.PromoCode, error) {
+	return r.findOne(ctx, `SELECT `+promoColumns+` FROM promo_codes WHERE code=$1 FOR UPDATE`, code)
+}
+
+// FindActiveByBookingID mengambil promo yang redemption-nya masih aktif pada
+// booking. Booking tanpa promo mengembalikan domain.ErrPromoNotFound.
+func (r *promoRepository) FindActiveByBookingID(ctx context.Context, bookingID int) (*domain.PromoCode, error) {
+	return r.findOne(ctx, `SELECT `+promoColumns+` FROM promo_codes WHERE id = (SELECT promo_code_id FROM promo_redemptions WHERE booking_id=$1 AND status='ACTIVE')`, bookingID)
+}
+
+func (r *promoRepository) FindByCreator(ctx context.Context, userID int) ([]*domain.PromoCode, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + promoColumns + ` FROM promo_codes WHERE created_by=$1 ORDER BY created_at DESC`
+
+	promos, err := r.queryPromos(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding promo codes by creator: %w", err)
+	}
+
+	return promos, nil
+}
+
+func (r *promoRepository) FindAll(ctx context.Context) ([]*domain.PromoCode, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + promoColumns + ` FROM promo_codes ORDER BY created_at DESC`
+
+	promos, err := r.queryPromos(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error finding all promo codes: %w", err)
+	}
+
+	return promos, nil
+}
+
+func (r *promoRepository) Update(ctx context.Context, promo *domain.PromoCode) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE promo_codes SET discount_type=$1, discount_value=$2, field_ids=$3, min_spend=$4, valid_from=$5, valid_until=$6, max_uses=$7, max_uses_per_user=$8, first_booking_only=$9, active=$10 WHERE id=$11`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		promo.DiscountType,
+		promo.DiscountValue,
+		toInt64Array(promo.FieldIDs),
+		promo.MinSpend,
+		promo.ValidFrom,
+		promo.ValidUntil,
+		promo.MaxUses,
+		promo.MaxUsesPerUser,
+		promo.FirstBookingOnly,
+		promo.Active,
+		promo.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("error updating promo code: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrPromoNotFound
+	}
+
+	return nil
+}
+
+func (r *promoRepository) CreateRedemption(ctx context.Context, redemption *domain.PromoRedemption) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO promo_redemptions (promo_code_id, booking_id, user_id, discount_amount, status, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		redemption.PromoCodeID,
+		redemption.BookingID,
+		redemption.UserID,
+		redemption.DiscountAmount,
+		redemption.Status,
+		redemption.CreatedAt,
+	).Scan(&redemption.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating promo redemption: %w", err)
+	}
+
+	return nil
+}
+
+func (r *promoRepository) FindRedemptionsByPromoID(ctx context.Context, promoID int) ([]*domain.PromoRedemption, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, promo_code_id, booking_id, user_id, discount_amount, status, created_at, released_at FROM promo_redemptions WHERE promo_code_id=$1 ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, promoID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding promo redemptions: %w", err)
+	}
+	defer rows.Close()
+
+	redemptions := []*domain.PromoRedemption{}
+
+	for rows.Next() {
+		redemption := &domain.PromoRedemption{}
+		err := rows.Scan(
+			&redemption.ID,
+			&redemption.PromoCodeID,
+			&redemption.BookingID,
+			&redemption.UserID,
+			&redemption.DiscountAmount,
+			&redemption.Status,
+			&redemption.CreatedAt,
+			&redemption.ReleasedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning promo redemption: %w", err)
+		}
+		redemptions = append(redemptions, redemption)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating promo redemptions: %w", err)
+	}
+
+	return redemptions, nil
+}
+
+func (r *promoRepository) CountActiveRedemptions(ctx context.Context, promoID int) (int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM promo_redemptions WHERE promo_code_id=$1 AND status='ACTIVE'`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, promoID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting promo redemptions: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *promoRepository) CountActiveRedemptionsByUser(ctx context.Context, promoID, userID int) (int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM promo_redemptions WHERE promo_code_id=$1 AND user_id=$2 AND status='ACTIVE'`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, promoID, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting promo redemptions by user: %w", err)
+	}
+
+	return count, nil
+}
+
+// ReleaseRedemption melepas redemption aktif milik booking. Booking tanpa
+// promo tidak memiliki redemption, jadi tidak adanya baris bukan error.
+func (r *promoRepository) ReleaseRedemption(ctx context.Context, bookingID int, now time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE promo_redemptions SET status='RELEASED', released_at=$1 WHERE booking_id=$2 AND status='ACTIVE'`
+
+	if _, err := r.db.ExecContext(ctx, query, now, bookingID); err != nil {
+		return fmt.Errorf("error releasing promo redemption: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRedemptionDiscount mengganti nominal diskon redemption aktif booking,
+// misalnya setelah harga booking berubah karena reschedule.
+func (r *promoRepository) UpdateRedemptionDiscount(ctx context.Context, bookingID, discountAmount int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE promo_redemptions SET discount_amount=$1 WHERE booking_id=$2 AND status='ACTIVE'`
+
+	if _, err := r.db.ExecContext(ctx, query, discountAmount, bookingID); err != nil {
+		return fmt.Errorf("error updating promo redemption: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"time"
+)
+
+type RefundRepository interface {
+	Create(ctx context.Context, refund *domain.Refund) error
+	FindByID(ctx context.Context, id int) (*domain.Refund, error)
+	FindByBookingID(ctx context.Context, bookingID int) ([]*domain.Refund, error)
+	FindByRefundKey(ctx context.Context, refundKey string) (*domain.Refund, error)
+	Update(ctx context.Context, refund *domain.Refund) error
+	TotalSucceededByPaymentID(ctx context.Context, paymentID int) (int, error)
+	FindRetryable(ctx context.Context, before time.Time, limit int) ([]*domain.Refund, error)
+}
+
+// refundColumns adalah urutan kolom yang dibaca oleh scanRefund.
+const refundColumns = `id, booking_id, payment_id, amount, reason, refund_key, transaction_id, status, failure_reason, created_at, updated_at`
+
+type refundRepository struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func NewRefundRepository(db DBTX, timeout time.Duration) RefundRepository {
+	return &refundRepository{db: db, timeout: timeout}
+}
+
+func scanRefund(row rowScanner) (*domain.Refund, error) {
+	refund := &domain.Refund{}
+
+	err := row.Scan(
+		&refund.ID,
+		&refund.BookingID,
+		&refund.PaymentID,
+		&refund.Amount,
+		&refund.Reason,
+		&refund.RefundKey,
+		&refund.TransactionID,
+		&refund.Status,
+		&refund.FailureReason,
+		&refund.CreatedAt,
+		&refund.UpdatedAt,
+	)
+
+	return refund, err
+}
+
+func (r *refundRepository) Create(ctx context.Context, refund *domain.Refund) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO refunds (booking_id, payment_id, amount, reason, refund_key, transaction_id, status, failure_reason, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		refund.BookingID,
+		refund.PaymentID,
+		refund.Amount,
+		refund.Reason,
+		refund.RefundKey,
+		refund.TransactionID,
+		refund.Status,
+		refund.FailureReason,
+		refund.CreatedAt,
+		refund.UpdatedAt,
+	).Scan(&refund.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating refund: %w", err)
+	}
+
+	return nil
+}
+
+func (r *refundRepository) FindByID(ctx context.Context, id int) (*domain.Refund, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE id=$1`
+
+	refund, err := scanRefund(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrRefundNotFound
+		}
+		return nil, fmt.Errorf("error finding refund: %w", err)
+	}
+
+	return refund, nil
+}
+
+// FindByRefundKey dipakai untuk mengecek apakah refund dengan idempotency key
+// yang sama sudah pernah dibuat.
+func (r *refundRepository) FindByRefundKey(ctx context.Context, refundKey string) (*domain.Refund, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE refund_key=$1`
+
+	refund, err := scanRefund(r.db.QueryRowContext(ctx, query, refundKey))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrRefundNotFound
+		}
+		return nil, fmt.Errorf("error finding refund: %w", err)
+	}
+
+	return refund, nil
+}
+
+func (r *refundRepository) FindByBookingID(ctx context.Context, bookingID int) ([]*domain.Refund, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE booking_id=$1 ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding refunds by booking: %w", err)
+	}
+	defer rows.Close()
+
+	refunds := []*domain.Refund{}
+
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning refund: %w", err)
+		}
+		refunds = append(refunds, refund)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating refunds: %w", err)
+	}
+
+	return refunds, nil
+}
+
+func (r *refundRepository) Update(ctx context.Context, refund *domain.Refund) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE refunds SET status=$1, failure_reason=$2, updated_at=$3 WHERE id=$4`
+
+	result, err := r.db.ExecContext(ctx, query, refund.Status, refund.FailureReason, refund.UpdatedAt, refund.ID)
+	if err != nil {
+		return fmt.Errorf("error updating refund: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrRefundNotFound
+	}
+
+	return nil
+}
+
+// TotalSucceededByPaymentID menjumlahkan nominal refund SUCCESS untuk satu payment.
+// Refund atas transaksi lain (charge lama atau adjustment) tidak ikut dihitung.
+func (r *refundRepository) TotalSucceededByPaymentID(ctx context.Context, paymentID int) (int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT COALESCE(SUM(amount), 0) FROM refunds WHERE payment_id=$1 AND status='SUCCESS' AND transaction_id=''`
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, query, paymentID).Scan(&total); err != nil {
+		return 0, fmt.Errorf("error summing refunds: %w", err)
+	}
+
+	return total, nil
+}
+
+// FindRetryable mengambil refund PENDING atau FAILED yang terakhir diubah
+// sebelum before, terlama lebih dulu, maksimal limit baris.
+func (r *refundRepository) FindRetryable(ctx context.Context, before time.Time, limit int) ([]*domain.Refund, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE status IN ('PENDING', 'FAILED') AND updated_at < $1 ORDER BY updated_at LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error finding retryable refunds: %w", err)
+	}
+	defer rows.Close()
+
+	refunds := []*domain.Refund{}
+
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning refund: %w", err)
+		}
+		refunds = append(refunds, refund)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating refunds: %w", err)
+	}
+
+	return refunds, nil
+}
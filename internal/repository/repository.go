@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// DBTX adalah subset method yang dimiliki *sql.DB maupun *sql.Tx,
+// sehingga repository yang sama bisa dipakai di dalam atau di luar transaksi.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// withTimeout membatasi durasi satu query. Timeout <= 0 berarti hanya
+// mengikuti deadline dari context pemanggil.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// rowScanner diimplementasikan oleh *sql.Row dan *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+const (
+	pqExclusionViolation = "23P01"
+
+	bookingOverlapConstraint = "bookings_no_overlap"
+)
+
+// IsBookingOverlap mengecek apakah error berasal dari constraint
+// bookings_no_overlap, yaitu slot sudah diambil booking aktif lain.
+func IsBookingOverlap(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	return pqErr.Code == pqExclusionViolation && pqErr.Constraint == bookingOverlapConstraint
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestIsBookingOverlap(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"overlap constraint", &pq.Error{Code: pqExclusionViolation, Constraint: bookingOverlapConstraint}, true},
+		{"wrapped overlap constraint", fmt.Errorf("error creating booking: %w", &pq.Error{Code: pqExclusionViolation, Constraint: bookingOverlapConstraint}), true},
+		{"other exclusion constraint", &pq.Error{Code: pqExclusionViolation, Constraint: "schedules_no_overlap"}, false},
+		{"unique violation", &pq.Error{Code: "23505", Constraint: bookingOverlapConstraint}, false},
+		{"non pq error", errors.New("connection refused"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBookingOverlap(tt.err); got != tt.want {
+				t.Fatalf("IsBookingOverlap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
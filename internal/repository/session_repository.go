@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"time"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *domain.Session) error
+	FindByID(ctx context.Context, id int) (*domain.Session, error)
+	FindByRefreshTokenHash(ctx context.Context, hash string) (*domain.Session, error)
+	Revoke(ctx context.Context, id int, reason domain.RevokeReason, revokedAt time.Time) error
+	RevokeAllByUserID(ctx context.Context, userID int, reason domain.RevokeReason, revokedAt time.Time) error
+}
+
+type sessionRepository struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func NewSessionRepository(db DBTX, timeout time.Duration) SessionRepository {
+	return &sessionRepository{db: db, timeout: timeout}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO sessions (user_id, refresh_token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		session.UserID,
+		session.RefreshTokenHash,
+		session.ExpiresAt,
+		session.CreatedAt,
+	).Scan(&session.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) FindByID(ctx context.Context, id int) (*domain.Session, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, user_id, refresh_token_hash, expires_at, revoked_at, COALESCE(revoke_reason, ''), created_at FROM sessions WHERE id=$1`
+
+	return r.findOne(ctx, query, id)
+}
+
+func (r *sessionRepository) FindByRefreshTokenHash(ctx context.Context, hash string) (*domain.Session, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, user_id, refresh_token_hash, expires_at, revoked_at, COALESCE(revoke_reason, ''), created_at FROM sessions WHERE refresh_token_hash=$1`
+
+	return r.findOne(ctx, query, hash)
+}
+
+func (r *sessionRepository) findOne(ctx context.Context, query string, arg interface{}) (*domain.Session, error) {
+	session := &domain.Session{}
+
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.RefreshTokenHash,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+		&session.RevokeReason,
+		&session.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("error finding session: %w", err)
+	}
+
+	return session, nil
+}
+
+// Revoke mencabut session yang masih aktif beserta alasannya. ErrSessionRevoked
+// dikembalikan jika session sudah dicabut sebelumnya.
+func (r *sessionRepository) Revoke(ctx context.Context, id int, reason domain.RevokeReason, revokedAt time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE sessions SET revoked_at=$1, revoke_reason=$2 WHERE id=$3 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, revokedAt, reason, id)
+	if err != nil {
+		return fmt.Errorf("error revoking session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	// Session sudah dicabut request lain, misalnya refresh token yang sama
+	// dipakai dua kali secara bersamaan.
+	if rowsAffected == 0 {
+		return domain.ErrSessionRevoked
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) RevokeAllByUserID(ctx context.Context, userID int, reason domain.RevokeReason, revokedAt time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE sessions SET revoked_at=$1, revoke_reason=$2 WHERE user_id=$3 AND revoked_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, revokedAt, reason, userID)
+	if err != nil {
+		return fmt.Errorf("error revoking sessions: %w", err)
+	}
+
+	return nil
+}
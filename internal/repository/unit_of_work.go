@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"futsal-booking-app/pkg/db"
+	"time"
+)
+
+// Repositories adalah kumpulan repository yang terikat ke koneksi yang sama.
+// Di dalam UnitOfWork.Do, semua repository ini memakai transaksi yang sama.
+type Repositories struct {
+	Users          UserRepository
+	Venues         VenueRepository
+	Fields         FieldRepository
+	Bookings       BookingRepository
+	BookingHistory BookingHistoryRepository
+	Reschedules    BookingRescheduleRepository
+	BookingSeries  BookingSeriesRepository
+	Payments       PaymentRepository
+	PaymentHistory PaymentHistoryRepository
+	Adjustments    PaymentAdjustmentRepository
+	Refunds        RefundRepository
+	Waitlist       WaitlistRepository
+	Promos         PromoRepository
+}
+
+// NewRepositories membuat semua repository di atas db. earthdistance adalah
+// hasil HasEarthdistance saat startup.
+func NewRepositories(db DBTX, queryTimeout time.Duration, earthdistance bool) *Repositories {
+	return &Repositories{
+		Users:          NewUserRepository(db, queryTimeout),
+		Venues:         NewVenueRepository(db, queryTimeout),
+		Fields:         NewFieldRepository(db, queryTimeout, earthdistance),
+		Bookings:       NewBookingRepository(db, queryTimeout),
+		BookingHistory: NewBookingHistoryRepository(db, queryTimeout),
+		Reschedules:    NewBookingRescheduleRepository(db, queryTimeout),
+		BookingSeries:  NewBookingSeriesRepository(db, queryTimeout),
+		Payments:       NewPaymentRepository(db, queryTimeout),
+		PaymentHistory: NewPaymentHistoryRepository(db, queryTimeout),
+		Adjustments:    NewPaymentAdjustmentRepository(db, queryTimeout),
+		Refunds:        NewRefundRepository(db, queryTimeout),
+		Waitlist:       NewWaitlistRepository(db, queryTimeout),
+		Promos:         NewPromoRepository(db, queryTimeout),
+	}
+}
+
+// UnitOfWork menjalankan beberapa operasi repository secara atomik.
+// Jika fn mengembalikan error, semua perubahan di dalamnya di-rollback.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(repos *Repositories) error) error
+}
+
+type unitOfWork struct {
+	txManager     *db.TxManager
+	queryTimeout  time.Duration
+	earthdistance bool
+}
+
+func NewUnitOfWork(txManager *db.TxManager, queryTimeout time.Duration, earthdistance bool) UnitOfWork {
+	return &unitOfWork{txManager: txManager, queryTimeout: queryTimeout, earthdistance: earthdistance}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos *Repositories) error) error {
+	return u.txManager.WithinTransaction(ctx, func(tx *sql.Tx) error {
+		return fn(NewRepositories(tx, u.queryTimeout, u.earthdistance))
+	})
+}
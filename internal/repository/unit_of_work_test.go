@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"futsal-booking-app/pkg/db"
+	"sync"
+	"testing"
+	"time"
+)
+
+// txRecorder adalah driver database/sql minimal yang hanya mencatat apakah
+// transaksi di-commit atau di-rollback.
+type txRecorder struct {
+	mu        sync.Mutex
+	commits   int
+	rollbacks int
+}
+
+func (r *txRecorder) Open(string) (driver.Conn, error) { return &recorderConn{r}, nil }
+
+type recorderConn struct{ r *txRecorder }
+
+func (c *recorderConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c *recorderConn) Close() error              { return nil }
+func (c *recorderConn) Begin() (driver.Tx, error) { return &recorderTx{c.r}, nil }
+
+type recorderTx struct{ r *txRecorder }
+
+func (t *recorderTx) Commit() error {
+	t.r.mu.Lock()
+	defer t.r.mu.Unlock()
+	t.r.commits++
+	return nil
+}
+
+func (t *recorderTx) Rollback() error {
+	t.r.mu.Lock()
+	defer t.r.mu.Unlock()
+	t.r.rollbacks++
+	return nil
+}
+
+var registerRecorder sync.Once
+
+func TestUnitOfWorkDo(t *testing.T) {
+	recorder := &txRecorder{}
+	registerRecorder.Do(func() { sql.Register("txrecorder", recorder) })
+
+	sqlDB, err := sql.Open("txrecorder", "")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer sqlDB.Close()
+
+	uow := NewUnitOfWork(db.NewTxManager(sqlDB), time.Second, false)
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name         string
+		fn           func(repos *Repositories) error
+		wantErr      error
+		wantPanic    bool
+		wantCommit   int
+		wantRollback int
+	}{
+		{
+			name:       "commit on success",
+			fn:         func(repos *Repositories) error { return nil },
+			wantCommit: 1,
+		},
+		{
+			name:         "rollback on error",
+			fn:           func(repos *Repositories) error { return errBoom },
+			wantErr:      errBoom,
+			wantRollback: 1,
+		},
+		{
+			name:         "rollback on panic",
+			fn:           func(repos *Repositories) error { panic(errBoom) },
+			wantPanic:    true,
+			wantRollback: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.commits, recorder.rollbacks = 0, 0
+
+			var err error
+			panicked := func() (panicked bool) {
+				defer func() {
+					if recover() != nil {
+						panicked = true
+					}
+				}()
+				err = uow.Do(context.Background(), tt.fn)
+				return false
+			}()
+
+			if panicked != tt.wantPanic {
+				t.Fatalf("panicked = %v, want %v", panicked, tt.wantPanic)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if recorder.commits != tt.wantCommit || recorder.rollbacks != tt.wantRollback {
+				t.Fatalf("commits = %d, rollbacks = %d, want %d and %d",
+					recorder.commits, recorder.rollbacks, tt.wantCommit, tt.wantRollback)
+			}
+		})
+	}
+}
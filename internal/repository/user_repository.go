@@ -1,33 +1,40 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"futsal-booking-app/internal/domain"
+	"time"
 )
 
 type UserRepository interface {
-	Create(user *domain.User) error
-	FindByID(id int) (*domain.User, error)
-	FindByEmail(email string) (*domain.User, error)
-	Update(user *domain.User) error
-	Delete(id int) error
-	FindByRole(role domain.Role) ([]*domain.User, error)
+	Create(ctx context.Context, user *domain.User) error
+	FindByID(ctx context.Context, id int) (*domain.User, error)
+	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	Update(ctx context.Context, user *domain.User) error
+	Delete(ctx context.Context, id int) error
+	FindByRole(ctx context.Context, role domain.Role) ([]*domain.User, error)
 }
 
 type userRepository struct {
-	db *sql.DB
+	db      DBTX
+	timeout time.Duration
 }
 
-func NewUserRepository(db *sql.DB) UserRepository {
-	return &userRepository{db: db}
+func NewUserRepository(db DBTX, timeout time.Duration) UserRepository {
+	return &userRepository{db: db, timeout: timeout}
 }
 
-func (r *userRepository) Create(user *domain.User) error {
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `INSERT INTO users (name, email, password_hash, role, created_at)
 		VALUES ($1, $2, $3, $4, $5) RETURNING id`
 
-	err := r.db.QueryRow(
+	err := r.db.QueryRowContext(
+		ctx,
 		query,
 		user.Name,
 		user.Email,
@@ -43,12 +50,15 @@ func (r *userRepository) Create(user *domain.User) error {
 	return nil
 }
 
-func (r *userRepository) FindByID(id int) (*domain.User, error) {
+func (r *userRepository) FindByID(ctx context.Context, id int) (*domain.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `SELECT id, name, email, password_hash, role, created_at FROM users WHERE id = $1`
 
 	user := &domain.User{}
 
-	err := r.db.QueryRow(query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&user.ID,
 		&user.Name,
 		&user.Email,
@@ -59,7 +69,7 @@ func (r *userRepository) FindByID(id int) (*domain.User, error) {
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, domain.ErrUserNotFound
 		}
 
 		return nil, fmt.Errorf("error finding user: %w", err)
@@ -68,14 +78,18 @@ func (r *userRepository) FindByID(id int) (*domain.User, error) {
 	return user, nil
 }
 
-func (r *userRepository) FindByEmail(email string) (*domain.User, error) {
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `SELECT id, name, email, password_hash, role, created_at FROM users WHERE email = $1`
 
 	user := &domain.User{}
 
-	err := r.db.QueryRow(query, email).Scan(
+	err := r.db.QueryRowContext(ctx, query, email).Scan(
 		&user.ID,
 		&user.Name,
+		&user.Email,
 		&user.PasswordHash,
 		&user.Role,
 		&user.CreatedAt,
@@ -83,7 +97,7 @@ func (r *userRepository) FindByEmail(email string) (*domain.User, error) {
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, domain.ErrUserNotFound
 		}
 
 		return nil, fmt.Errorf("error finding user: %w", err)
@@ -92,10 +106,14 @@ func (r *userRepository) FindByEmail(email string) (*domain.User, error) {
 	return user, nil
 }
 
-func (r *userRepository) Update(user *domain.User) error {
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `UPDATE users SET name=$1, email=$2, password_hash=$3, role=$4 WHERE id=$5`
 
-	result, err := r.db.Exec(
+	result, err := r.db.ExecContext(
+		ctx,
 		query,
 		user.Name,
 		user.Email,
@@ -114,16 +132,19 @@ func (r *userRepository) Update(user *domain.User) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("user not found")
+		return domain.ErrUserNotFound
 	}
 
 	return nil
 }
 
-func (r *userRepository) Delete(id int) error {
+func (r *userRepository) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `DELETE FROM users WHERE id=$1`
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("error deleting user: %w", err)
 	}
@@ -134,16 +155,19 @@ func (r *userRepository) Delete(id int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("user not found")
+		return domain.ErrUserNotFound
 	}
 
 	return nil
 }
 
-func (r *userRepository) FindByRole(role domain.Role) ([]*domain.User, error) {
+func (r *userRepository) FindByRole(ctx context.Context, role domain.Role) ([]*domain.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `SELECT id, name, email, password_hash, role, created_at FROM users WHERE role=$1 ORDER BY created_at DESC`
 
-	rows, err := r.db.Query(query, role)
+	rows, err := r.db.QueryContext(ctx, query, role)
 	if err != nil {
 		return nil, fmt.Errorf("error finding users by role: %w", err)
 	}
@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type VenueRepository interface {
+	Create(ctx context.Context, venue *domain.Venue) error
+	FindByID(ctx context.Context, id int) (*domain.Venue, error)
+	FindByOwnerID(ctx context.Context, ownerID int) ([]*domain.Venue, error)
+	FindAll(ctx context.Context) ([]*domain.Venue, error)
+	Update(ctx context.Context, venue *domain.Venue) error
+	Delete(ctx context.Context, id int) error
+
+	CreateSchedule(ctx context.Context, venueID int, schedule *domain.Schedule) error
+	FindSchedulesByVenueID(ctx context.Context, venueID int) ([]*domain.Schedule, error)
+	DeleteSchedulesByVenueID(ctx context.Context, venueID int) error
+}
+
+type venueRepository struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func NewVenueRepository(db DBTX, timeout time.Duration) VenueRepository {
+	return &venueRepository{db: db, timeout: timeout}
+}
+
+// venueColumns adalah urutan kolom yang dibaca oleh scanVenue.
+const venueColumns = `id, owner_id, name, address, description, image_url, amenities, latitude, longitude, time_zone, created_at`
+
+func scanVenue(row rowScanner) (*domain.Venue, error) {
+	venue := &domain.Venue{}
+	var amenities pq.StringArray
+	var latitude, longitude sql.NullFloat64
+
+	err := row.Scan(
+		&venue.ID,
+		&venue.OwnerID,
+		&venue.Name,
+		&venue.Address,
+		&venue.Description,
+		&venue.ImageURL,
+		&amenities,
+		&latitude,
+		&longitude,
+		&venue.TimeZone,
+		&venue.CreatedAt,
+	)
+
+	venue.Amenities = []string(amenities)
+	if venue.Amenities == nil {
+		venue.Amenities = []string{}
+	}
+	venue.Coordinates = toGeoPoint(latitude, longitude)
+
+	return venue, err
+}
+
+// toGeoPoint mengubah kolom latitude/longitude nullable menjadi GeoPoint.
+func toGeoPoint(latitude, longitude sql.NullFloat64) *domain.GeoPoint {
+	if !latitude.Valid || !longitude.Valid {
+		return nil
+	}
+
+	return &domain.GeoPoint{Latitude: latitude.Float64, Longitude: longitude.Float64}
+}
+
+func latitudeArg(p *domain.GeoPoint) sql.NullFloat64 {
+	if p == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: p.Latitude, Valid: true}
+}
+
+func longitudeArg(p *domain.GeoPoint) sql.NullFloat64 {
+	if p == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: p.Longitude, Valid: true}
+}
+
+func (r *venueRepository) queryVenues(ctx context.Context, query string, args ...interface{}) ([]*domain.Venue, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	venues := []*domain.Venue{}
+
+	for rows.Next() {
+		venue, err := scanVenue(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning venue: %w", err)
+		}
+		venues = append(venues, venue)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating venues: %w", err)
+	}
+
+	return venues, nil
+}
+
+func (r *venueRepository) Create(ctx context.Context, venue *domain.Venue) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO venues (owner_id, name, address, description, image_url, amenities, latitude, longitude, time_zone, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		venue.OwnerID,
+		venue.Name,
+		venue.Address,
+		venue.Description,
+		venue.ImageURL,
+		pq.StringArray(venue.Amenities),
+		latitudeArg(venue.Coordinates),
+		longitudeArg(venue.Coordinates),
+		venue.TimeZone,
+		venue.CreatedAt,
+	).Scan(&venue.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating venue: %w", err)
+	}
+
+	return nil
+}
+
+func (r *venueRepository) FindByID(ctx context.Context, id int) (*domain.Venue, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + venueColumns + ` FROM venues WHERE id=$1`
+
+	venue, err := scanVenue(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrVenueNotFound
+		}
+		return nil, fmt.Errorf("error finding venue: %w", err)
+	}
+
+	return venue, nil
+}
+
+func (r *venueRepository) FindByOwnerID(ctx context.Context, ownerID int) ([]*domain.Venue, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + venueColumns + ` FROM venues WHERE owner_id=$1 ORDER BY created_at DESC`
+
+	venues, err := r.queryVenues(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding venues by owner: %w", err)
+	}
+
+	return venues, nil
+}
+
+func (r *venueRepository) FindAll(ctx context.Context) ([]*domain.Venue, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + venueColumns + ` FROM venues ORDER BY created_at DESC`
+
+	venues, err := r.queryVenues(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error finding all venues: %w", err)
+	}
+
+	return venues, nil
+}
+
+func (r *venueRepository) Update(ctx context.Context, venue *domain.Venue) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE venues SET name=$1, address=$2, description=$3, image_url=$4, amenities=$5, latitude=$6, longitude=$7, time_zone=$8 WHERE id=$9`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		venue.Name,
+		venue.Address,
+		venue.Description,
+		venue.ImageURL,
+		pq.StringArray(venue.Amenities),
+		latitudeArg(venue.Coordinates),
+		longitudeArg(venue.Coordinates),
+		venue.TimeZone,
+		venue.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("error updating venue: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVenueNotFound
+	}
+
+	return nil
+}
+
+// Delete menghapus venue beserta semua court dan booking-nya (ON DELETE CASCADE).
+func (r *venueRepository) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `DELETE FROM venues WHERE id=$1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("error deleting venue: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVenueNotFound
+	}
+
+	return nil
+}
+
+func (r *venueRepository) CreateSchedule(ctx context.Context, venueID int, schedule *domain.Schedule) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO venue_schedules (venue_id, day_of_week, open_time, close_time) VALUES ($1, $2, $3, $4) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		venueID,
+		schedule.DayOfWeek,
+		schedule.OpenTime,
+		schedule.CloseTime,
+	).Scan(&schedule.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating venue schedule: %w", err)
+	}
+
+	return nil
+}
+
+// FindSchedulesByVenueID mengambil jam buka default venue. FieldID pada
+// hasilnya kosong karena jadwal ini belum milik court mana pun.
+func (r *venueRepository) FindSchedulesByVenueID(ctx context.Context, venueID int) ([]*domain.Schedule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, day_of_week, open_time, close_time FROM venue_schedules WHERE venue_id=$1 ORDER BY day_of_week, open_time`
+
+	rows, err := r.db.QueryContext(ctx, query, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding venue schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []*domain.Schedule{}
+
+	for rows.Next() {
+		schedule := &domain.Schedule{}
+		err := rows.Scan(
+			&schedule.ID,
+			&schedule.DayOfWeek,
+			&schedule.OpenTime,
+			&schedule.CloseTime,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning venue schedule: %w", err)
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating venue schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+func (r *venueRepository) DeleteSchedulesByVenueID(ctx context.Context, venueID int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `DELETE FROM venue_schedules WHERE venue_id=$1`
+
+	if _, err := r.db.ExecContext(ctx, query, venueID); err != nil {
+		return fmt.Errorf("error deleting venue schedules: %w", err)
+	}
+
+	return nil
+}
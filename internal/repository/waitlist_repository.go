@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type WaitlistRepository interface {
+	Create(ctx context.Context, entry *domain.WaitlistEntry) error
+	FindByID(ctx context.Context, id int) (*domain.WaitlistEntry, error)
+	FindByIDForUpdate(ctx context.Context, id int) (*domain.WaitlistEntry, error)
+	FindByUserID(ctx context.Context, userID int) ([]*domain.WaitlistEntry, error)
+	Update(ctx context.Context, entry *domain.WaitlistEntry) error
+
+	ExpireStale(ctx context.Context, now time.Time, limit int) ([]*domain.WaitlistEntry, error)
+	FindOfferable(ctx context.Context, now time.Time, limit int) ([]*domain.WaitlistEntry, error)
+}
+
+// waitlistColumns adalah urutan kolom yang dibaca oleh scanWaitlistEntry.
+const waitlistColumns = `id, user_id, field_id, start_time, end_time, status, offered_at, offer_expires_at, booking_id, created_at, updated_at`
+
+// activeOfferCondition memfilter entry waitlist yang penawarannya masih
+// menahan slot. Parameter $4 adalah waktu sekarang.
+const activeOfferCondition = `(status = 'OFFERED' AND offer_expires_at > $4)`
+
+// waitlistOpenIndex mencegah customer mengantre dua kali untuk rentang yang sama.
+const waitlistOpenIndex = "idx_waitlist_entries_open"
+
+type waitlistRepository struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func NewWaitlistRepository(db DBTX, timeout time.Duration) WaitlistRepository {
+	return &waitlistRepository{db: db, timeout: timeout}
+}
+
+func scanWaitlistEntry(row rowScanner) (*domain.WaitlistEntry, error) {
+	entry := &domain.WaitlistEntry{}
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.UserID,
+		&entry.FieldID,
+		&entry.StartTime,
+		&entry.EndTime,
+		&entry.Status,
+		&entry.OfferedAt,
+		&entry.OfferExpiresAt,
+		&entry.BookingID,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+
+	return entry, err
+}
+
+func (r *waitlistRepository) queryEntries(ctx context.Context, query string, args ...interface{}) ([]*domain.WaitlistEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*domain.WaitlistEntry{}
+
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning waitlist entry: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating waitlist entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *waitlistRepository) Create(ctx context.Context, entry *domain.WaitlistEntry) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `INSERT INTO waitlist_entries (user_id, field_id, start_time, end_time, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		entry.UserID,
+		entry.FieldID,
+		entry.StartTime,
+		entry.EndTime,
+		entry.Status,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	).Scan(&entry.ID)
+
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Constraint == waitlistOpenIndex {
+			return domain.ErrAlreadyOnWaitlist
+		}
+		return fmt.Errorf("error creating waitlist entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *waitlistRepository) findOne(ctx context.Context, query string, args ...interface{}) (*domain.WaitlistEntry, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	entry, err := scanWaitlistEntry(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrWaitlistNotFound
+		}
+		return nil, fmt.Errorf("error finding waitlist entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *waitlistRepository) FindByID(ctx context.Context, id int) (*domain.WaitlistEntry, error) {
+	return r.findOne(ctx, `SELECT `+waitlistColumns+` FROM waitlist_entries WHERE id=$1`, id)
+}
+
+// FindByIDForUpdate mengunci entry sampai transaksi selesai. Harus dipanggil
+// di dalam UnitOfWork.
+func (r *waitlistRepository) FindByIDForUpdate(ctx context.Context, id int) (*domain.WaitlistEntry, error) {
+	return r.findOne(ctx, `SELECT `+waitlistColumns+` FROM waitlist_entries WHERE id=$1 FOR UPDATE`, id)
+}
+
+func (r *waitlistRepository) FindByUserID(ctx context.Context, userID int) ([]*domain.WaitlistEntry, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + waitlistColumns + ` FROM waitlist_entries WHERE user_id=$1 ORDER BY start_time DESC`
+
+	entries, err := r.queryEntries(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding waitlist entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *waitlistRepository) Update(ctx context.Context, entry *domain.WaitlistEntry) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE waitlist_entries SET status=$1, offered_at=$2, offer_expires_at=$3, booking_id=$4 WHERE id=$5`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		entry.Status,
+		entry.OfferedAt,
+		entry.OfferExpiresAt,
+		entry.BookingID,
+		entry.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("error updating waitlist entry: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrWaitlistNotFound
+	}
+
+	return nil
+}
+
+// ExpireStale mengubah entry menjadi EXPIRED jika penawarannya habis atau
+// slot yang diantre sudah dimulai, lalu mengembalikan entry yang diubah.
+// Maksimal limit baris per panggilan.
+func (r *waitlistRepository) ExpireStale(ctx context.Context, now time.Time, limit int) ([]*domain.WaitlistEntry, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE waitlist_entries SET status='EXPIRED' WHERE id IN (
+		SELECT id FROM waitlist_entries
+		WHERE (status='OFFERED' AND offer_expires_at <= $1) OR (status IN ('WAITING', 'OFFERED') AND start_time <= $1)
+		ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED
+	) RETURNING ` + waitlistColumns
+
+	entries, err := r.queryEntries(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error expiring waitlist entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// FindOfferable mengambil entry WAITING yang seluruh rentang waktunya sedang
+// kosong: tidak ada booking aktif maupun penawaran aktif lain yang bentrok.
+// Hasil diurutkan per lapangan lalu berdasarkan waktu mengantre; urutan akhir
+// antrean ditentukan service sesuai WaitlistPolicy lapangan.
+func (r *waitlistRepository) FindOfferable(ctx context.Context, now time.Time, limit int) ([]*domain.WaitlistEntry, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + waitlistColumns + ` FROM waitlist_entries w
+		WHERE w.status='WAITING' AND w.start_time > $1
+		AND NOT EXISTS (
+			SELECT 1 FROM bookings b WHERE b.field_id=w.field_id
+			AND (b.status = 'CONFIRMED' OR (b.status = 'PENDING' AND (b.expires_at IS NULL OR b.expires_at > $1)))
+			AND b.start_time < w.end_time + (SELECT f.buffer_minutes FROM fields f WHERE f.id=w.field_id) * INTERVAL '1 minute'
+			AND b.blocked_until > w.start_time
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM waitlist_entries o WHERE o.field_id=w.field_id
+			AND o.status='OFFERED' AND o.offer_expires_at > $1
+			AND o.start_time < w.end_time AND o.end_time > w.start_time
+		)
+		ORDER BY w.field_id, w.created_at, w.id
+		LIMIT $2`
+
+	entries, err := r.queryEntries(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error finding offerable waitlist entries: %w", err)
+	}
+
+	return entries, nil
+}
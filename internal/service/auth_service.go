@@ -1,9 +1,12 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"futsal-booking-app/internal/domain"
 	"futsal-booking-app/internal/repository"
+	"futsal-booking-app/pkg/token"
 	"strings"
 	"time"
 
@@ -11,17 +14,38 @@ import (
 )
 
 type AuthService interface {
-	RegisterUser(name, email, password string, role domain.Role) (*domain.User, error)
-	LoginUser(email, password string) (*domain.User, error)
-	GetUserByID(id int) (*domain.User, error)
+	RegisterUser(ctx context.Context, name, email, password string, role domain.Role) (*domain.User, error)
+	LoginUser(ctx context.Context, email, password string) (*domain.User, *AuthTokens, error)
+	GetUserByID(ctx context.Context, id int) (*domain.User, error)
+
+	Authenticate(ctx context.Context, accessToken string) (*domain.User, *token.Claims, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*AuthTokens, error)
+	Logout(ctx context.Context, sessionID int) error
+	RevokeAllSessions(ctx context.Context, userID int) error
+}
+
+// AuthTokens adalah pasangan credential yang dikembalikan saat login/refresh.
+type AuthTokens struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
 }
 
 type authService struct {
-	userRepo repository.UserRepository
+	userRepo        repository.UserRepository
+	sessionRepo     repository.SessionRepository
+	tokenManager    *token.Manager
+	refreshTokenTTL time.Duration
 }
 
-func NewAuthService(userRepo repository.UserRepository) AuthService {
-	return &authService{userRepo: userRepo}
+func NewAuthService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, tokenManager *token.Manager, refreshTokenTTL time.Duration) AuthService {
+	return &authService{
+		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		tokenManager:    tokenManager,
+		refreshTokenTTL: refreshTokenTTL,
+	}
 }
 
 // RegisterUser mendaftarkan user baru ke sistem
@@ -41,30 +65,30 @@ func NewAuthService(userRepo repository.UserRepository) AuthService {
 // Return:
 //   - *entity.User: user yang berhasil dibuat
 //   - error: error jika ada validasi yang gagal
-func (u *authService) RegisterUser(name, email, password string, role domain.Role) (*domain.User, error) {
+func (u *authService) RegisterUser(ctx context.Context, name, email, password string, role domain.Role) (*domain.User, error) {
 	if strings.TrimSpace(name) == "" {
-		return nil, fmt.Errorf("name cannot be empty")
+		return nil, domain.Invalidf("name cannot be empty")
 	}
 
 	if strings.TrimSpace(email) == "" {
-		return nil, fmt.Errorf("email cannot be empty")
+		return nil, domain.Invalidf("email cannot be empty")
 	}
 
 	if !strings.Contains(email, "@") || !strings.Contains(email, ".") {
-		return nil, fmt.Errorf("invalid email format")
+		return nil, domain.Invalidf("invalid email format")
 	}
 
 	if len(password) < 6 {
-		return nil, fmt.Errorf("password must be at least 6 characters")
+		return nil, domain.Invalidf("password must be at least 6 characters")
 	}
 
 	if role != domain.RoleCustomer && role != domain.RoleOwner {
-		return nil, fmt.Errorf("invalid role, must be CUSTOMER or OWNER")
+		return nil, domain.Invalidf("invalid role, must be CUSTOMER or OWNER")
 	}
 
-	existingUser, err := u.userRepo.FindByEmail(email)
+	existingUser, err := u.userRepo.FindByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
 	if err == nil && existingUser != nil {
-		return nil, fmt.Errorf("email already registered")
+		return nil, domain.ErrEmailAlreadyRegistered
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -80,7 +104,7 @@ func (u *authService) RegisterUser(name, email, password string, role domain.Rol
 		CreatedAt:    time.Now(),
 	}
 
-	if err := u.userRepo.Create(user); err != nil {
+	if err := u.userRepo.Create(ctx, user); err != nil {
 		return nil, fmt.Errorf("error creating user: %w", err)
 	}
 
@@ -93,35 +117,42 @@ func (u *authService) RegisterUser(name, email, password string, role domain.Rol
 // 1. Validasi input tidak kosong
 // 2. Cari user berdasarkan email
 // 3. Verifikasi password dengan bcrypt
-// 4. Return user jika berhasil
+// 4. Buat session baru berisi refresh token
+// 5. Terbitkan access token yang membawa user ID, role dan session ID
 // Parameter:
 //   - email: email user
 //   - password: plain password dari user
 //
 // Return:
 //   - *entity.User: user jika autentikasi berhasil
+//   - *AuthTokens: access token dan refresh token
 //   - error: error jika autentikasi gagal
-func (u *authService) LoginUser(email, password string) (*domain.User, error) {
+func (u *authService) LoginUser(ctx context.Context, email, password string) (*domain.User, *AuthTokens, error) {
 	if strings.TrimSpace(email) == "" {
-		return nil, fmt.Errorf("email cannot be empty")
+		return nil, nil, domain.Invalidf("email cannot be empty")
 	}
 
 	if strings.TrimSpace(password) == "" {
-		return nil, fmt.Errorf("password cannot be empty")
+		return nil, nil, domain.Invalidf("password cannot be empty")
 	}
 
-	user, err := u.userRepo.FindByEmail(strings.ToLower(strings.TrimSpace(email)))
+	user, err := u.userRepo.FindByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
 	if err != nil {
-		return nil, fmt.Errorf("invalid email or password")
+		return nil, nil, domain.ErrInvalidCredentials
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
 	if err != nil {
-		return nil, fmt.Errorf("invalid email or password")
+		return nil, nil, domain.ErrInvalidCredentials
+	}
+
+	tokens, err := u.issueTokens(ctx, user, time.Now())
+	if err != nil {
+		return nil, nil, err
 	}
 
 	user.PasswordHash = ""
-	return user, nil
+	return user, tokens, nil
 }
 
 // GetUserByID mengambil data user berdasarkan ID
@@ -132,16 +163,172 @@ func (u *authService) LoginUser(email, password string) (*domain.User, error) {
 // Return:
 //   - *entity.User: user jika ditemukan
 //   - error: error jika user tidak ditemukan
-func (u *authService) GetUserByID(id int) (*domain.User, error) {
+func (u *authService) GetUserByID(ctx context.Context, id int) (*domain.User, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("invalid user ID")
+		return nil, domain.Invalidf("invalid user ID")
 	}
 
-	user, err := u.userRepo.FindByID(id)
+	user, err := u.userRepo.FindByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, domain.ErrUserNotFound
 	}
 
 	user.PasswordHash = ""
 	return user, nil
 }
+
+// Authenticate memverifikasi access token dan mengembalikan user pemiliknya
+// Token ditolak jika signature tidak valid, sudah kadaluarsa, atau
+// session-nya sudah dicabut (logout / revoke all).
+func (u *authService) Authenticate(ctx context.Context, accessToken string) (*domain.User, *token.Claims, error) {
+	now := time.Now()
+
+	claims, err := u.tokenManager.Verify(accessToken, now)
+	if err != nil {
+		return nil, nil, domain.ErrInvalidToken
+	}
+
+	session, err := u.sessionRepo.FindByID(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, nil, domain.ErrInvalidToken
+		}
+		return nil, nil, fmt.Errorf("error finding session: %w", err)
+	}
+
+	if session.UserID != claims.UserID || !session.IsValid(now) {
+		return nil, nil, domain.ErrSessionRevoked
+	}
+
+	user, err := u.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, nil, domain.ErrInvalidToken
+	}
+
+	user.PasswordHash = ""
+	return user, claims, nil
+}
+
+// RefreshTokens menukar refresh token dengan pasangan token baru
+// Business logic:
+// 1. Refresh token lama langsung dicabut dengan alasan ROTATED, sehingga hanya bisa dipakai sekali
+// 2. Refresh token yang sudah dirotasi dipakai lagi berarti token bocor, semua session user dicabut
+// 3. Refresh token dari session yang dicabut karena logout hanya ditolak, session lain tidak disentuh
+// 4. Pencabutan dicek atomik di database, jadi dua request bersamaan dengan token yang sama juga dianggap pemakaian ulang
+func (u *authService) RefreshTokens(ctx context.Context, refreshToken string) (*AuthTokens, error) {
+	if strings.TrimSpace(refreshToken) == "" {
+		return nil, domain.Invalidf("refresh token cannot be empty")
+	}
+
+	now := time.Now()
+
+	session, err := u.sessionRepo.FindByRefreshTokenHash(ctx, token.HashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("error finding session: %w", err)
+	}
+
+	if session.WasRotated() {
+		return nil, u.revokeReusedSession(ctx, session.UserID, now)
+	}
+
+	if session.IsRevoked() {
+		return nil, domain.ErrSessionRevoked
+	}
+
+	if session.IsExpired(now) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	user, err := u.userRepo.FindByID(ctx, session.UserID)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if err := u.sessionRepo.Revoke(ctx, session.ID, domain.RevokeRotated, now); err != nil {
+		if !errors.Is(err, domain.ErrSessionRevoked) {
+			return nil, fmt.Errorf("error revoking session: %w", err)
+		}
+
+		// Session dicabut request lain di antara pengecekan dan rotasi. Jika
+		// itu rotasi, token yang sama dipakai dua kali secara bersamaan.
+		session, err = u.sessionRepo.FindByID(ctx, session.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error finding session: %w", err)
+		}
+		if session.WasRotated() {
+			return nil, u.revokeReusedSession(ctx, session.UserID, now)
+		}
+		return nil, domain.ErrSessionRevoked
+	}
+
+	return u.issueTokens(ctx, user, now)
+}
+
+// revokeReusedSession mencabut semua session user setelah refresh token yang
+// sudah dirotasi dipakai ulang, lalu mengembalikan ErrSessionRevoked.
+func (u *authService) revokeReusedSession(ctx context.Context, userID int, now time.Time) error {
+	if err := u.sessionRepo.RevokeAllByUserID(ctx, userID, domain.RevokeReuseDetected, now); err != nil {
+		return fmt.Errorf("error revoking sessions: %w", err)
+	}
+	return domain.ErrSessionRevoked
+}
+
+// Logout mencabut session yang sedang dipakai
+func (u *authService) Logout(ctx context.Context, sessionID int) error {
+	if sessionID <= 0 {
+		return domain.Invalidf("invalid session ID")
+	}
+
+	// Logout ulang untuk session yang sudah dicabut tidak dianggap error
+	if err := u.sessionRepo.Revoke(ctx, sessionID, domain.RevokeLogout, time.Now()); err != nil && !errors.Is(err, domain.ErrSessionRevoked) {
+		return fmt.Errorf("error revoking session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions mencabut semua session user (logout dari semua perangkat)
+func (u *authService) RevokeAllSessions(ctx context.Context, userID int) error {
+	if userID <= 0 {
+		return domain.Invalidf("invalid user ID")
+	}
+
+	if err := u.sessionRepo.RevokeAllByUserID(ctx, userID, domain.RevokeLogout, time.Now()); err != nil {
+		return fmt.Errorf("error revoking sessions: %w", err)
+	}
+
+	return nil
+}
+
+func (u *authService) issueTokens(ctx context.Context, user *domain.User, now time.Time) (*AuthTokens, error) {
+	refreshToken, err := token.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &domain.Session{
+		UserID:           user.ID,
+		RefreshTokenHash: token.HashRefreshToken(refreshToken),
+		ExpiresAt:        now.Add(u.refreshTokenTTL),
+		CreatedAt:        now,
+	}
+
+	if err := u.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("error creating session: %w", err)
+	}
+
+	accessToken, accessExpiresAt, err := u.tokenManager.Generate(user.ID, string(user.Role), session.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("error generating access token: %w", err)
+	}
+
+	return &AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: session.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"futsal-booking-app/pkg/token"
+	"testing"
+	"time"
+)
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[int]*domain.User
+}
+
+func (r *fakeUserRepo) FindByID(ctx context.Context, id int) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+// fakeSessionRepo meniru sessionRepository: Revoke hanya berhasil untuk
+// session yang belum dicabut.
+type fakeSessionRepo struct {
+	sessions []*domain.Session
+}
+
+func (r *fakeSessionRepo) Create(ctx context.Context, session *domain.Session) error {
+	session.ID = len(r.sessions) + 1
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+
+func (r *fakeSessionRepo) FindByID(ctx context.Context, id int) (*domain.Session, error) {
+	for _, s := range r.sessions {
+		if s.ID == id {
+			copied := *s
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrSessionNotFound
+}
+
+func (r *fakeSessionRepo) FindByRefreshTokenHash(ctx context.Context, hash string) (*domain.Session, error) {
+	for _, s := range r.sessions {
+		if s.RefreshTokenHash == hash {
+			copied := *s
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrSessionNotFound
+}
+
+func (r *fakeSessionRepo) Revoke(ctx context.Context, id int, reason domain.RevokeReason, revokedAt time.Time) error {
+	for _, s := range r.sessions {
+		if s.ID == id {
+			if s.IsRevoked() {
+				return domain.ErrSessionRevoked
+			}
+			s.RevokedAt = &revokedAt
+			s.RevokeReason = reason
+			return nil
+		}
+	}
+	return domain.ErrSessionRevoked
+}
+
+func (r *fakeSessionRepo) RevokeAllByUserID(ctx context.Context, userID int, reason domain.RevokeReason, revokedAt time.Time) error {
+	for _, s := range r.sessions {
+		if s.UserID == userID && !s.IsRevoked() {
+			s.RevokedAt = &revokedAt
+			s.RevokeReason = reason
+		}
+	}
+	return nil
+}
+
+func (r *fakeSessionRepo) active() int {
+	n := 0
+	for _, s := range r.sessions {
+		if !s.IsRevoked() {
+			n++
+		}
+	}
+	return n
+}
+
+func newAuthFixture(t *testing.T) (*authService, *fakeSessionRepo, *domain.User) {
+	t.Helper()
+
+	manager, err := token.NewManager("0123456789abcdef0123456789abcdef", "futsal", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	user := &domain.User{ID: 1, Name: "Customer", Email: "customer@example.com", Role: domain.RoleCustomer}
+	sessions := &fakeSessionRepo{}
+	svc := &authService{
+		userRepo:        &fakeUserRepo{users: map[int]*domain.User{user.ID: user}},
+		sessionRepo:     sessions,
+		tokenManager:    manager,
+		refreshTokenTTL: time.Hour,
+	}
+
+	return svc, sessions, user
+}
+
+func TestRefreshTokensRotates(t *testing.T) {
+	ctx := context.Background()
+	svc, sessions, user := newAuthFixture(t)
+
+	first, err := svc.issueTokens(ctx, user, time.Now())
+	if err != nil {
+		t.Fatalf("issueTokens: %v", err)
+	}
+
+	second, err := svc.RefreshTokens(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshTokens: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Fatal("RefreshTokens returned the same refresh token")
+	}
+
+	old := sessions.sessions[0]
+	if !old.WasRotated() {
+		t.Fatalf("old session revoked = %v reason %q, want %s", old.IsRevoked(), old.RevokeReason, domain.RevokeRotated)
+	}
+	if sessions.active() != 1 {
+		t.Fatalf("active sessions = %d, want 1", sessions.active())
+	}
+
+	// Access token lama ditolak karena session-nya sudah dirotasi
+	if _, _, err := svc.Authenticate(ctx, first.AccessToken); !errors.Is(err, domain.ErrSessionRevoked) {
+		t.Fatalf("Authenticate(old) error = %v, want %v", err, domain.ErrSessionRevoked)
+	}
+
+	got, claims, err := svc.Authenticate(ctx, second.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate(new): %v", err)
+	}
+	if got.ID != user.ID || claims.SessionID != sessions.sessions[1].ID {
+		t.Fatalf("Authenticate(new) = user %d session %d", got.ID, claims.SessionID)
+	}
+}
+
+func TestRefreshTokensRevokedSession(t *testing.T) {
+	tests := []struct {
+		name string
+		// revoke mencabut session pertama dan mengembalikan refresh token
+		// yang kemudian dipakai ulang.
+		revoke     func(t *testing.T, svc *authService, tokens *AuthTokens) string
+		wantActive int
+		wantReason domain.RevokeReason
+	}{
+		{
+			name: "reused rotated token revokes every session",
+			revoke: func(t *testing.T, svc *authService, tokens *AuthTokens) string {
+				if _, err := svc.RefreshTokens(context.Background(), tokens.RefreshToken); err != nil {
+					t.Fatalf("RefreshTokens: %v", err)
+				}
+				return tokens.RefreshToken
+			},
+			wantActive: 0,
+			wantReason: domain.RevokeReuseDetected,
+		},
+		{
+			name: "logged out token is only rejected",
+			revoke: func(t *testing.T, svc *authService, tokens *AuthTokens) string {
+				if err := svc.Logout(context.Background(), 1); err != nil {
+					t.Fatalf("Logout: %v", err)
+				}
+				return tokens.RefreshToken
+			},
+			wantActive: 1,
+			wantReason: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc, sessions, user := newAuthFixture(t)
+
+			tokens, err := svc.issueTokens(ctx, user, time.Now())
+			if err != nil {
+				t.Fatalf("issueTokens: %v", err)
+			}
+
+			// Session di perangkat lain yang tidak terkait token yang dicabut
+			if _, err := svc.issueTokens(ctx, user, time.Now()); err != nil {
+				t.Fatalf("issueTokens: %v", err)
+			}
+
+			reused := tt.revoke(t, svc, tokens)
+
+			if _, err := svc.RefreshTokens(ctx, reused); !errors.Is(err, domain.ErrSessionRevoked) {
+				t.Fatalf("RefreshTokens(reused) error = %v, want %v", err, domain.ErrSessionRevoked)
+			}
+
+			if got := sessions.active(); got != tt.wantActive {
+				t.Fatalf("active sessions = %d, want %d", got, tt.wantActive)
+			}
+
+			// Session perangkat lain hanya dicabut saat pemakaian ulang terdeteksi
+			if got := sessions.sessions[1].RevokeReason; got != tt.wantReason {
+				t.Fatalf("other session revoke reason = %q, want %q", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestRefreshTokensUnknownToken(t *testing.T) {
+	svc, _, _ := newAuthFixture(t)
+
+	if _, err := svc.RefreshTokens(context.Background(), "unknown"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Fatalf("RefreshTokens error = %v, want %v", err, domain.ErrInvalidToken)
+	}
+}
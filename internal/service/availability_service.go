@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"time"
+)
+
+const (
+	// MaxCalendarDays membatasi jumlah hari dalam satu permintaan kalender.
+	MaxCalendarDays = 31
+	// MaxCalendarFields membatasi jumlah lapangan dalam satu permintaan kalender.
+	MaxCalendarFields = 20
+)
+
+// CalendarInput memilih lapangan dan rentang tanggal kalender ketersediaan.
+// Lapangan dipilih lewat FieldIDs, atau semua court di VenueID jika FieldIDs
+// kosong.
+type CalendarInput struct {
+	FieldIDs []int
+	VenueID  int
+	// From adalah tanggal pertama; jamnya diabaikan.
+	From time.Time
+	Days int
+}
+
+// CalendarDay adalah slot satu lapangan pada satu tanggal.
+type CalendarDay struct {
+	Date  time.Time
+	Slots []domain.TimeSlot
+}
+
+// FieldAvailability adalah satu baris grid kalender: slot satu lapangan
+// untuk setiap tanggal yang diminta.
+type FieldAvailability struct {
+	Field *domain.Field
+	Days  []CalendarDay
+}
+
+// GetAvailabilityCalendar menghitung grid slot banyak lapangan untuk banyak hari
+// Business logic:
+// 1. Maksimal MaxCalendarFields lapangan dan MaxCalendarDays hari per permintaan
+// 2. Jadwal, exception, dan pricing rule dimuat sekali per lapangan
+// 3. Rentang yang ditahan booking dan penawaran waitlist semua lapangan dimuat dalam satu query
+// 4. Slot setiap tanggal dihitung di memori di zona waktu masing-masing lapangan
+func (u *fieldService) GetAvailabilityCalendar(ctx context.Context, input CalendarInput) ([]FieldAvailability, error) {
+	if input.Days <= 0 || input.Days > MaxCalendarDays {
+		return nil, domain.Invalidf("days must be between 1 and %d", MaxCalendarDays)
+	}
+
+	fields, err := u.calendarFields(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	calendars, err := u.loadCalendars(ctx, fields, input.From, input.Days)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]FieldAvailability, 0, len(calendars))
+
+	for _, calendar := range calendars {
+		row := FieldAvailability{Field: calendar.Field, Days: make([]CalendarDay, 0, input.Days)}
+
+		for i := 0; i < input.Days; i++ {
+			date := time.Date(input.From.Year(), input.From.Month(), input.From.Day()+i, 0, 0, 0, 0, calendar.Field.Location())
+			row.Days = append(row.Days, CalendarDay{Date: date, Slots: calendar.SlotsOn(date)})
+		}
+
+		result = append(result, row)
+	}
+
+	return result, nil
+}
+
+func (u *fieldService) calendarFields(ctx context.Context, input CalendarInput) ([]*domain.Field, error) {
+	if len(input.FieldIDs) == 0 {
+		if input.VenueID <= 0 {
+			return nil, domain.Invalidf("field IDs or venue ID is required")
+		}
+
+		fields, err := u.fieldRepo.FindByVenueID(ctx, input.VenueID)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching courts: %w", err)
+		}
+
+		if len(fields) > MaxCalendarFields {
+			return nil, domain.Invalidf("venue has more than %d courts; request field IDs instead", MaxCalendarFields)
+		}
+
+		return fields, nil
+	}
+
+	if len(input.FieldIDs) > MaxCalendarFields {
+		return nil, domain.Invalidf("at most %d fields can be requested at once", MaxCalendarFields)
+	}
+
+	fields := make([]*domain.Field, 0, len(input.FieldIDs))
+	seen := map[int]bool{}
+
+	for _, id := range input.FieldIDs {
+		if id <= 0 {
+			return nil, domain.Invalidf("invalid field ID")
+		}
+
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		field, err := u.fieldRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// loadCalendars memuat data yang dibutuhkan domain.FieldCalendar untuk days
+// hari mulai tanggal from. Rentang yang ditahan semua lapangan diambil dalam
+// satu query, diperluas satu hari supaya jendela yang melewati tengah malam
+// pada tanggal terakhir ikut tercakup.
+func (u *fieldService) loadCalendars(ctx context.Context, fields []*domain.Field, from time.Time, days int) ([]*domain.FieldCalendar, error) {
+	calendars := make([]*domain.FieldCalendar, 0, len(fields))
+	fieldIDs := make([]int, 0, len(fields))
+	byField := map[int]*domain.FieldCalendar{}
+
+	var rangeStart, rangeEnd time.Time
+
+	for _, field := range fields {
+		loc := field.Location()
+		start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+		end := time.Date(from.Year(), from.Month(), from.Day()+days+1, 0, 0, 0, 0, loc)
+
+		if rangeStart.IsZero() || start.Before(rangeStart) {
+			rangeStart = start
+		}
+		if end.After(rangeEnd) {
+			rangeEnd = end
+		}
+
+		schedules, exceptions, err := openingHours(ctx, u.fieldRepo, field, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		rules, err := u.fieldRepo.FindPricingRulesByFieldID(ctx, field.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching pricing rules: %w", err)
+		}
+
+		calendar := &domain.FieldCalendar{
+			Field:      field,
+			Schedules:  schedules,
+			Exceptions: exceptions,
+			Rules:      rules,
+			Blocked:    []domain.BlockedRange{},
+		}
+
+		calendars = append(calendars, calendar)
+		fieldIDs = append(fieldIDs, field.ID)
+		byField[field.ID] = calendar
+	}
+
+	if len(calendars) == 0 {
+		return calendars, nil
+	}
+
+	blocked, err := u.bookingRepo.FindBlockedRanges(ctx, fieldIDs, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, fmt.Errorf("error checking availability: %w", err)
+	}
+
+	for _, b := range blocked {
+		if calendar, ok := byField[b.FieldID]; ok {
+			calendar.Blocked = append(calendar.Blocked, b)
+		}
+	}
+
+	return calendars, nil
+}
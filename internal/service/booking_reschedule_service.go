@@ -0,0 +1,570 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"futsal-booking-app/internal/authz"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/payment"
+	"futsal-booking-app/internal/repository"
+	"sort"
+	"time"
+)
+
+// RescheduleResult adalah hasil RescheduleBooking. Adjustment diisi jika
+// booking sudah dibayar dan slot baru lebih mahal; Refunds diisi jika slot
+// baru lebih murah, satu per transaksi gateway yang dananya dikembalikan.
+type RescheduleResult struct {
+	Booking    *domain.Booking
+	Reschedule *domain.BookingReschedule
+	Adjustment *domain.PaymentAdjustment
+	Refunds    []*domain.Refund
+}
+
+// RescheduleBooking memindahkan booking ke slot lain tanpa membatalkannya
+// Business logic:
+// 1. Hanya customer pemilik booking (atau admin), untuk booking PENDING atau CONFIRMED
+// 2. ReschedulePolicy lapangan membatasi batas waktu (MinHoursBefore sebelum slot lama dimulai) dan jumlah reschedule
+// 3. durationMinutes 0 berarti durasi booking tidak berubah; slot baru tetap divalidasi terhadap SlotPolicy lapangan
+// 4. Slot baru dicek dan booking dipindah dalam satu transaksi; bentrok dengan booking lain ditolak constraint bookings_no_overlap
+// 5. Harga slot baru dihitung ulang dari pricing rule lapangan, lalu diskon promo dihitung ulang untuk harga baru (dihapus jika harga baru di bawah MinSpend promo). Selisih harga setelah diskon: payment PENDING diganti charge baru dengan nominal baru; payment SUCCESS ditagih selisihnya lewat PaymentAdjustment atau dikembalikan lewat refund
+// 6. Perpindahan dicatat di riwayat reschedule booking
+// 7. Charge lama yang diganti tetap dipetakan ke payment; jika terlanjur dibayar, dananya di-refund lewat webhook
+// 8. Charge baru atau tagihan selisih dibuat di gateway setelah transaksi commit. Jika gagal, payment PENDING ditandai FAILED dan booking dibatalkan; tagihan selisih diperlakukan seperti adjustment yang gagal dibayar
+// 9. Tagihan selisih harus dibayar sebelum batas hold lapangan (paling lambat jam mulai booking). Jika gagal atau tidak dibayar, booking dibatalkan dan dananya di-refund (lihat ExpireUnpaidAdjustments)
+// 10. Booking yang masih punya tagihan selisih belum dibayar tidak bisa di-reschedule lagi
+// Occurrence dari series UPFRONT tidak bisa di-reschedule karena payment-nya milik series.
+func (u *bookingService) RescheduleBooking(ctx context.Context, actor *domain.User, bookingID int, newStart time.Time, durationMinutes int) (*RescheduleResult, error) {
+	if bookingID <= 0 {
+		return nil, domain.Invalidf("invalid booking ID")
+	}
+
+	if durationMinutes < 0 {
+		return nil, domain.Invalidf("duration cannot be negative")
+	}
+
+	now := time.Now()
+	if newStart.Before(now) {
+		return nil, domain.Invalidf("cannot reschedule to the past")
+	}
+
+	booking, err := u.bookingRepo.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, domain.ErrBookingNotFound
+	}
+
+	field, err := u.fieldRepo.FindByID(ctx, booking.FieldID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching field: %w", err)
+	}
+
+	if err := u.policy.Authorize(actor, authz.ActionBookingReschedule, authz.Resource{Field: field, Booking: booking}); err != nil {
+		return nil, err
+	}
+
+	result := &RescheduleResult{}
+	var charge *pendingCharge
+
+	err = u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		booking, err := repos.Bookings.FindByIDForUpdate(ctx, bookingID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkReschedulable(booking, field.ReschedulePolicy, now); err != nil {
+			return err
+		}
+
+		if err := ensureNoPendingAdjustment(ctx, repos, booking); err != nil {
+			return err
+		}
+
+		p, err := findBookingPayment(ctx, repos, booking)
+		if err != nil {
+			return err
+		}
+
+		if p != nil && p.SeriesID != nil {
+			return domain.Invalidf("occurrences of an upfront series cannot be rescheduled")
+		}
+
+		minutes := durationMinutes
+		if minutes == 0 {
+			minutes = booking.GetDurationMinutes()
+		}
+
+		if err := validateDuration(field, newStart, minutes); err != nil {
+			return err
+		}
+
+		newEnd := newStart.Add(time.Duration(minutes) * time.Minute)
+		blockedUntil := newEnd.Add(field.SlotPolicy.Buffer())
+
+		if newStart.Equal(booking.StartTime) && newEnd.Equal(booking.EndTime) {
+			return domain.Invalidf("new time slot is the same as the current one")
+		}
+
+		if err := ensureOpen(ctx, repos.Fields, field, newStart, newEnd); err != nil {
+			return err
+		}
+
+		slotTaken := &domain.SlotTakenError{FieldID: field.ID, StartTime: newStart, EndTime: newEnd}
+
+		available, err := repos.Bookings.CheckAvailabilityExcept(ctx, field.ID, newStart, blockedUntil, booking.ID)
+		if err != nil {
+			return err
+		}
+
+		if !available {
+			return slotTaken
+		}
+
+		expired, err := repos.Bookings.ExpireOverlappingHolds(ctx, field.ID, newStart, blockedUntil, now)
+		if err != nil {
+			return fmt.Errorf("error releasing expired holds: %w", err)
+		}
+
+		if err := releaseExpiredHolds(ctx, repos, expired, now); err != nil {
+			return err
+		}
+
+		price, err := quotePrice(ctx, repos.Fields, field, newStart, newEnd)
+		if err != nil {
+			return fmt.Errorf("error calculating price: %w", err)
+		}
+
+		reschedule := &domain.BookingReschedule{
+			BookingID:    booking.ID,
+			ActorID:      &actor.ID,
+			OldStartTime: booking.StartTime,
+			OldEndTime:   booking.EndTime,
+			NewStartTime: newStart,
+			NewEndTime:   newEnd,
+			OldPrice:     booking.TotalPrice,
+			CreatedAt:    now,
+		}
+
+		booking.StartTime = newStart
+		booking.EndTime = newEnd
+		booking.BufferMinutes = field.SlotPolicy.BufferMinutes
+		booking.SetPrice(price)
+		booking.RescheduleCount++
+
+		if err := repriceDiscount(ctx, repos, booking, now); err != nil {
+			return err
+		}
+
+		// Kedua harga dibandingkan setelah diskon supaya promo tidak ikut ditagih
+		reschedule.NewPrice = booking.TotalPrice
+
+		if err := repos.Bookings.Update(ctx, booking); err != nil {
+			if repository.IsBookingOverlap(err) {
+				return slotTaken
+			}
+			return err
+		}
+
+		if err := repos.Reschedules.Create(ctx, reschedule); err != nil {
+			return err
+		}
+
+		result.Booking = booking
+		result.Reschedule = reschedule
+
+		if p == nil || reschedule.PriceDelta() == 0 {
+			return nil
+		}
+
+		charge, err = u.settlePriceDelta(ctx, repos, actor, field, booking, p, reschedule.PriceDelta(), result, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if charge != nil {
+		if err := createCharges(ctx, u.uow, u.gateway, charge); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, refund := range result.Refunds {
+		issueRefund(ctx, u.uow, u.gateway, refund)
+	}
+
+	return result, nil
+}
+
+// GetBookingReschedules mengambil riwayat reschedule booking, terlama lebih dulu
+// Aturan aksesnya sama dengan melihat booking
+func (u *bookingService) GetBookingReschedules(ctx context.Context, actor *domain.User, bookingID int) ([]*domain.BookingReschedule, error) {
+	booking, err := u.GetBookingByID(ctx, actor, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	reschedules, err := u.rescheduleRepo.FindByBookingID(ctx, booking.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching booking reschedules: %w", err)
+	}
+
+	return reschedules, nil
+}
+
+// GetBookingAdjustments mengambil tagihan tambahan booking, terbaru lebih dulu
+// Aturan aksesnya sama dengan melihat booking
+func (u *bookingService) GetBookingAdjustments(ctx context.Context, actor *domain.User, bookingID int) ([]*domain.PaymentAdjustment, error) {
+	booking, err := u.GetBookingByID(ctx, actor, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustments, err := u.adjustmentRepo.FindByBookingID(ctx, booking.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching payment adjustments: %w", err)
+	}
+
+	return adjustments, nil
+}
+
+// repriceDiscount menghitung ulang diskon promo booking untuk harga slot baru.
+// Promo persentase mengikuti harga baru, promo nominal tetap sebesar nilainya.
+// Nominal diskon di redemption ikut diperbarui. Jika harga baru di bawah
+// MinSpend promo, diskon dihapus dan redemption-nya dilepas.
+func repriceDiscount(ctx context.Context, repos *repository.Repositories, booking *domain.Booking, now time.Time) error {
+	promo, err := repos.Promos.FindActiveByBookingID(ctx, booking.ID)
+	if errors.Is(err, domain.ErrPromoNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if booking.OriginalPrice < promo.MinSpend {
+		booking.ApplyDiscount(0)
+		return repos.Promos.ReleaseRedemption(ctx, booking.ID, now)
+	}
+
+	booking.ApplyDiscount(promo.Discount(booking.OriginalPrice))
+	if booking.TotalPrice <= 0 {
+		return domain.Invalidf("promo code cannot cover the full price of the new slot")
+	}
+
+	if booking.DiscountAmount == 0 {
+		return repos.Promos.ReleaseRedemption(ctx, booking.ID, now)
+	}
+
+	return repos.Promos.UpdateRedemptionDiscount(ctx, booking.ID, booking.DiscountAmount)
+}
+
+// checkReschedulable mengevaluasi status booking dan ReschedulePolicy lapangan.
+func checkReschedulable(booking *domain.Booking, policy domain.ReschedulePolicy, now time.Time) error {
+	if !booking.IsPending() && !booking.IsConfirmed() {
+		return domain.Invalidf("only pending or confirmed bookings can be rescheduled")
+	}
+
+	if booking.IsHoldExpired(now) {
+		return domain.Invalidf("booking payment hold has expired")
+	}
+
+	if policy.MaxReschedules == 0 {
+		return domain.Invalidf("this field does not allow rescheduling")
+	}
+
+	if booking.RescheduleCount >= policy.MaxReschedules {
+		return domain.Invalidf("booking has reached the maximum of %d reschedules", policy.MaxReschedules)
+	}
+
+	if !policy.AllowsAt(booking.StartTime, now) {
+		return domain.Invalidf("bookings can only be rescheduled at least %d hours before they start", policy.MinHoursBefore)
+	}
+
+	return nil
+}
+
+// settlePriceDelta menyelesaikan selisih harga reschedule terhadap payment booking.
+// Payment PENDING dipindah ke transaction ID baru sebesar harga baru; charge lama
+// dicatat sebagai SupersededCharge. Payment SUCCESS ditagih selisihnya sebagai
+// PaymentAdjustment, atau mendapat refund PENDING yang dicairkan setelah
+// transaksi commit. Charge gateway untuk transaction ID baru dikembalikan sebagai
+// pendingCharge dan dibuat pemanggil setelah commit.
+func (u *bookingService) settlePriceDelta(ctx context.Context, repos *repository.Repositories, actor *domain.User, field *domain.Field, booking *domain.Booking, p *domain.Payment, delta int, result *RescheduleResult, now time.Time) (*pendingCharge, error) {
+	// ID transaksi diturunkan dari jumlah reschedule supaya unik per perpindahan
+	transactionID := fmt.Sprintf("TRX-%d-R%d", booking.ID, booking.RescheduleCount)
+
+	switch {
+	case p.IsPending():
+		expiresAt := field.HoldDeadline(now)
+		if booking.ExpiresAt != nil {
+			expiresAt = *booking.ExpiresAt
+		}
+
+		// Charge yang belum dibuka customer tidak bisa dibatalkan di gateway,
+		// jadi charge lama tetap dipetakan ke payment ini supaya pembayaran
+		// yang terlanjur masuk bisa di-refund.
+		err := repos.Payments.CreateSupersededCharge(ctx, &domain.SupersededCharge{
+			TransactionID: p.TransactionID,
+			PaymentID:     p.ID,
+			BookingID:     booking.ID,
+			Amount:        p.Amount,
+			CreatedAt:     now,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		p.Amount = booking.TotalPrice
+		p.TransactionID = transactionID
+		p.PaymentURL = ""
+		p.UpdatedAt = now
+
+		if err := repos.Payments.Update(ctx, p); err != nil {
+			return nil, fmt.Errorf("error updating payment: %w", err)
+		}
+
+		return &pendingCharge{
+			payment: p,
+			request: payment.ChargeRequest{
+				TransactionID: transactionID,
+				Amount:        booking.TotalPrice,
+				ItemName:      field.Name,
+				CustomerName:  actor.Name,
+				CustomerEmail: actor.Email,
+				ExpiresAt:     expiresAt,
+			},
+		}, nil
+
+	case p.IsSuccess() && delta > 0:
+		expiresAt := field.HoldDeadline(now)
+		if booking.StartTime.Before(expiresAt) {
+			expiresAt = booking.StartTime
+		}
+
+		adjustment := &domain.PaymentAdjustment{
+			PaymentID:     p.ID,
+			BookingID:     booking.ID,
+			Amount:        delta,
+			Reason:        "booking rescheduled",
+			TransactionID: transactionID,
+			Status:        domain.PaymentPending,
+			ExpiresAt:     expiresAt,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+
+		if err := repos.Adjustments.Create(ctx, adjustment); err != nil {
+			return nil, err
+		}
+		result.Adjustment = adjustment
+
+		return &pendingCharge{
+			adjustment: adjustment,
+			request: payment.ChargeRequest{
+				TransactionID: transactionID,
+				Amount:        delta,
+				ItemName:      field.Name + " (reschedule)",
+				CustomerName:  actor.Name,
+				CustomerEmail: actor.Email,
+				ExpiresAt:     expiresAt,
+			},
+		}, nil
+
+	case p.IsSuccess() && delta < 0:
+		refunds, err := splitRefund(ctx, repos, booking, p, -delta, "booking rescheduled", fmt.Sprintf("RF-%d-R%d", booking.ID, booking.RescheduleCount), now)
+		if err != nil {
+			return nil, err
+		}
+		result.Refunds = refunds
+	}
+
+	return nil, nil
+}
+
+// ensureNoPendingAdjustment menolak reschedule selama booking masih punya
+// tagihan selisih yang belum dibayar.
+func ensureNoPendingAdjustment(ctx context.Context, repos *repository.Repositories, booking *domain.Booking) error {
+	adjustments, err := repos.Adjustments.FindByBookingID(ctx, booking.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range adjustments {
+		if a.IsPending() {
+			return domain.Invalidf("booking has an unpaid reschedule adjustment")
+		}
+	}
+
+	return nil
+}
+
+// failAdjustment menandai adjustment yang sudah dikunci sebagai FAILED. Booking
+// yang masih CONFIRMED dibatalkan oleh sistem karena harga slot barunya tidak
+// lunas, lalu seluruh dana yang sudah diterima disiapkan sebagai refund PENDING:
+// sisa payment utama dan setiap adjustment yang sudah dibayar, masing-masing
+// lewat transaksinya sendiri. Harus dipanggil di dalam UnitOfWork.
+func failAdjustment(ctx context.Context, repos *repository.Repositories, a *domain.PaymentAdjustment, reason string, now time.Time) ([]*domain.Refund, error) {
+	a.MarkAsFailed()
+	if err := repos.Adjustments.Update(ctx, a); err != nil {
+		return nil, fmt.Errorf("error updating payment adjustment: %w", err)
+	}
+
+	booking, err := repos.Bookings.FindByIDForUpdate(ctx, a.BookingID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching booking: %w", err)
+	}
+
+	if !booking.IsConfirmed() {
+		return nil, nil
+	}
+
+	if err := transitionBooking(ctx, repos, booking, domain.BookingCancelled, nil, reason); err != nil {
+		return nil, err
+	}
+
+	p, err := repos.Payments.FindByID(ctx, a.PaymentID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching payment: %w", err)
+	}
+
+	if !p.IsSuccess() {
+		return nil, nil
+	}
+
+	paid, err := netPaid(ctx, repos, booking, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitRefund(ctx, repos, booking, p, paid, reason, fmt.Sprintf("RF-%d-A%d", booking.ID, a.ID), now)
+}
+
+// refundableCharge adalah sisa dana yang masih bisa dikembalikan pada satu
+// transaksi gateway booking. TransactionID kosong berarti transaksi utama payment.
+type refundableCharge struct {
+	transactionID string
+	adjustmentID  int
+	remaining     int
+}
+
+// refundableCharges menghitung sisa dana per transaksi gateway booking yang
+// dibayar lewat payment p: setiap adjustment yang sudah dibayar, terbaru lebih
+// dulu, lalu transaksi utama payment. Refund PENDING dan FAILED ikut mengurangi
+// sisa karena akan dicairkan ulang. Tidak berlaku untuk payment series, yang
+// nominalnya dibagi ke beberapa booking.
+func refundableCharges(ctx context.Context, repos *repository.Repositories, booking *domain.Booking, p *domain.Payment) ([]refundableCharge, error) {
+	adjustments, err := repos.Adjustments.FindByBookingID(ctx, booking.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching payment adjustments: %w", err)
+	}
+
+	refunds, err := repos.Refunds.FindByBookingID(ctx, booking.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching refunds: %w", err)
+	}
+
+	refunded := map[string]int{}
+	for _, refund := range refunds {
+		if refund.PaymentID == p.ID {
+			refunded[refund.TransactionID] += refund.Amount
+		}
+	}
+
+	sort.Slice(adjustments, func(i, j int) bool { return adjustments[i].ID > adjustments[j].ID })
+
+	charges := make([]refundableCharge, 0, len(adjustments)+1)
+	for _, a := range adjustments {
+		if a.IsSuccess() {
+			charges = append(charges, refundableCharge{
+				transactionID: a.TransactionID,
+				adjustmentID:  a.ID,
+				remaining:     a.Amount - refunded[a.TransactionID],
+			})
+		}
+	}
+
+	return append(charges, refundableCharge{remaining: p.Amount - refunded[""]}), nil
+}
+
+// splitRefund menyiapkan refund PENDING sebesar amount yang dibagi ke transaksi
+// booking sesuai urutan refundableCharges, masing-masing dibatasi sisa dananya,
+// supaya gateway tidak diminta mengembalikan lebih dari nominal transaksinya.
+// Bagian untuk adjustment memakai refundKey ditambah ID adjustment.
+func splitRefund(ctx context.Context, repos *repository.Repositories, booking *domain.Booking, p *domain.Payment, amount int, reason, refundKey string, now time.Time) ([]*domain.Refund, error) {
+	charges, err := refundableCharges(ctx, repos, booking, p)
+	if err != nil {
+		return nil, err
+	}
+
+	var refunds []*domain.Refund
+
+	for _, charge := range charges {
+		part := min(amount, charge.remaining)
+		if part <= 0 {
+			continue
+		}
+
+		key := refundKey
+		if charge.adjustmentID != 0 {
+			key = fmt.Sprintf("%s-A%d", refundKey, charge.adjustmentID)
+		}
+
+		refund := &domain.Refund{
+			BookingID:     booking.ID,
+			PaymentID:     p.ID,
+			Amount:        part,
+			Reason:        reason,
+			RefundKey:     key,
+			TransactionID: charge.transactionID,
+			Status:        domain.RefundPending,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+
+		if err := repos.Refunds.Create(ctx, refund); err != nil {
+			return nil, fmt.Errorf("error creating refund: %w", err)
+		}
+
+		refunds = append(refunds, refund)
+		amount -= part
+	}
+
+	if amount > 0 {
+		return nil, fmt.Errorf("refund for booking %d exceeds the amount paid by %d", booking.ID, amount)
+	}
+
+	return refunds, nil
+}
+
+// createChargeRefund menyiapkan refund PENDING untuk seluruh nominal transaksi
+// gateway selain transaksi utama payment. RefundKey diturunkan dari transaction
+// ID, jadi notifikasi duplikat tidak membuat refund kedua (nil dikembalikan).
+func createChargeRefund(ctx context.Context, repos *repository.Repositories, bookingID, paymentID int, transactionID string, amount int, reason string, now time.Time) (*domain.Refund, error) {
+	refundKey := "RF-" + transactionID
+
+	_, err := repos.Refunds.FindByRefundKey(ctx, refundKey)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, domain.ErrRefundNotFound) {
+		return nil, err
+	}
+
+	refund := &domain.Refund{
+		BookingID:     bookingID,
+		PaymentID:     paymentID,
+		Amount:        amount,
+		Reason:        reason,
+		RefundKey:     refundKey,
+		TransactionID: transactionID,
+		Status:        domain.RefundPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := repos.Refunds.Create(ctx, refund); err != nil {
+		return nil, fmt.Errorf("error creating refund: %w", err)
+	}
+
+	return refund, nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/payment"
+	"futsal-booking-app/internal/repository"
+	"testing"
+	"time"
+)
+
+// fakeRedemptionRepo menyimpan satu redemption aktif untuk repriceDiscount.
+type fakeRedemptionRepo struct {
+	repository.PromoRepository
+	promo    *domain.PromoCode
+	discount int
+	released bool
+}
+
+func (r *fakeRedemptionRepo) FindActiveByBookingID(ctx context.Context, bookingID int) (*domain.PromoCode, error) {
+	if r.promo == nil || r.released {
+		return nil, domain.ErrPromoNotFound
+	}
+	return r.promo, nil
+}
+
+func (r *fakeRedemptionRepo) UpdateRedemptionDiscount(ctx context.Context, bookingID, discountAmount int) error {
+	r.discount = discountAmount
+	return nil
+}
+
+func (r *fakeRedemptionRepo) ReleaseRedemption(ctx context.Context, bookingID int, now time.Time) error {
+	r.released = true
+	return nil
+}
+
+func TestRepriceDiscount(t *testing.T) {
+	percent := &domain.PromoCode{ID: 1, DiscountType: domain.DiscountPercentage, DiscountValue: 10, MinSpend: 100000}
+	fixed := &domain.PromoCode{ID: 2, DiscountType: domain.DiscountFixed, DiscountValue: 20000, MinSpend: 100000}
+
+	tests := []struct {
+		name         string
+		promo        *domain.PromoCode
+		newPrice     int
+		wantDiscount int
+		wantReleased bool
+	}{
+		{"no promo", nil, 150000, 0, false},
+		{"percentage follows new price", percent, 200000, 20000, false},
+		{"fixed keeps its value", fixed, 200000, 20000, false},
+		{"new price exactly at min spend", fixed, 100000, 20000, false},
+		{"new price below min spend releases percentage promo", percent, 90000, 0, true},
+		{"new price below min spend releases fixed promo", fixed, 90000, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Booking lama 150000 dengan diskon promo 15000, dipindah ke slot seharga newPrice
+			booking := &domain.Booking{ID: 1, OriginalPrice: 150000, TotalPrice: 150000}
+			if tt.promo != nil {
+				booking.ApplyDiscount(15000)
+			}
+			booking.SetPrice(domain.PriceBreakdown{Total: tt.newPrice})
+
+			promos := &fakeRedemptionRepo{promo: tt.promo, discount: booking.DiscountAmount}
+			repos := &repository.Repositories{Promos: promos}
+
+			if err := repriceDiscount(context.Background(), repos, booking, time.Now()); err != nil {
+				t.Fatalf("repriceDiscount: %v", err)
+			}
+
+			if booking.DiscountAmount != tt.wantDiscount {
+				t.Errorf("DiscountAmount = %d, want %d", booking.DiscountAmount, tt.wantDiscount)
+			}
+			if booking.TotalPrice != tt.newPrice-tt.wantDiscount {
+				t.Errorf("TotalPrice = %d, want %d", booking.TotalPrice, tt.newPrice-tt.wantDiscount)
+			}
+			if promos.released != tt.wantReleased {
+				t.Errorf("released = %v, want %v", promos.released, tt.wantReleased)
+			}
+			if tt.promo != nil && !tt.wantReleased && promos.discount != tt.wantDiscount {
+				t.Errorf("redemption discount = %d, want %d", promos.discount, tt.wantDiscount)
+			}
+		})
+	}
+}
+
+func TestRescheduleCreditSplitsAcrossPaidCharges(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	gateway := payment.NewFakeGateway("secret")
+	if _, err := gateway.CreateCharge(ctx, payment.ChargeRequest{TransactionID: "TRX-1-1", Amount: 100000}); err != nil {
+		t.Fatalf("CreateCharge: %v", err)
+	}
+	if err := gateway.SetStatus("TRX-1-1", payment.StatusSuccess); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	bookingID := 1
+	p := &domain.Payment{ID: 1, BookingID: &bookingID, Amount: 100000, TransactionID: "TRX-1-1", Status: domain.PaymentSuccess}
+	booking := &domain.Booking{ID: bookingID, UserID: 1, FieldID: 1, StartTime: now.Add(48 * time.Hour), Status: domain.BookingConfirmed}
+
+	payments := &fakePaymentRepo{payments: map[int]*domain.Payment{p.ID: p}}
+	bookings := &fakeBookingRepo{bookings: map[int]*domain.Booking{booking.ID: booking}}
+	refunds := &fakeRefundRepo{}
+	adjustments := &fakeAdjustmentRepo{}
+	repos := &repository.Repositories{
+		Payments:       payments,
+		PaymentHistory: &fakePaymentHistoryRepo{},
+		Bookings:       bookings,
+		BookingHistory: &fakeBookingHistoryRepo{},
+		Refunds:        refunds,
+		Adjustments:    adjustments,
+		Promos:         &fakePromoRepo{},
+	}
+	uow := &fakeUnitOfWork{repos: repos}
+	svc := &bookingService{uow: uow, gateway: gateway}
+
+	actor := &domain.User{ID: 1, Name: "Customer", Email: "customer@example.com"}
+	field := &domain.Field{ID: 1, Name: "Lapangan A"}
+
+	// Pindah ke slot lebih mahal: selisih 50000 ditagih lalu dibayar
+	booking.RescheduleCount = 1
+	charge, err := svc.settlePriceDelta(ctx, repos, actor, field, booking, p, 50000, &RescheduleResult{}, now)
+	if err != nil {
+		t.Fatalf("settlePriceDelta(+50000): %v", err)
+	}
+	if err := createCharges(ctx, uow, gateway, charge); err != nil {
+		t.Fatalf("createCharges: %v", err)
+	}
+
+	adjustment := adjustments.adjustments[0]
+	if err := gateway.SetStatus(adjustment.TransactionID, payment.StatusSuccess); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	body, err := gateway.BuildNotification(adjustment.TransactionID)
+	if err != nil {
+		t.Fatalf("BuildNotification: %v", err)
+	}
+	if err := NewPaymentService(uow, gateway).HandleNotification(ctx, body); err != nil {
+		t.Fatalf("HandleNotification: %v", err)
+	}
+	if !adjustment.IsSuccess() {
+		t.Fatalf("adjustment status = %s, want %s", adjustment.Status, domain.PaymentSuccess)
+	}
+
+	// Pindah ke slot seharga 40000: kredit 110000 melebihi charge utama 100000
+	booking.RescheduleCount = 2
+	result := &RescheduleResult{}
+	if _, err := svc.settlePriceDelta(ctx, repos, actor, field, booking, p, -110000, result, now); err != nil {
+		t.Fatalf("settlePriceDelta(-110000): %v", err)
+	}
+
+	if len(result.Refunds) != 2 {
+		t.Fatalf("got %d refunds, want 2: %+v", len(result.Refunds), result.Refunds)
+	}
+
+	want := []struct {
+		transactionID string
+		amount        int
+	}{
+		{adjustment.TransactionID, 50000},
+		{"", 60000},
+	}
+	for i, w := range want {
+		refund := result.Refunds[i]
+		if refund.TransactionID != w.transactionID || refund.Amount != w.amount {
+			t.Errorf("refund %d = %q %d, want %q %d", i, refund.TransactionID, refund.Amount, w.transactionID, w.amount)
+		}
+	}
+
+	for _, refund := range result.Refunds {
+		issueRefund(ctx, uow, gateway, refund)
+		if !refund.IsSuccess() {
+			t.Errorf("refund %s status = %s (%s), want %s", refund.RefundKey, refund.Status, refund.FailureReason, domain.RefundSuccess)
+		}
+	}
+
+	// Booking masih berjalan, jadi payment utama tidak boleh REFUNDED
+	if got := payments.payments[p.ID].Status; got != domain.PaymentSuccess {
+		t.Errorf("payment status = %s, want %s", got, domain.PaymentSuccess)
+	}
+
+	paid, err := netPaid(ctx, repos, booking, p)
+	if err != nil {
+		t.Fatalf("netPaid: %v", err)
+	}
+	if paid != 40000 {
+		t.Errorf("netPaid = %d, want 40000", paid)
+	}
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"futsal-booking-app/internal/authz"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"log"
+	"time"
+)
+
+// BookingSeriesInput berisi permintaan booking berulang. Tepat satu dari
+// Occurrences atau EndDate harus diisi.
+type BookingSeriesInput struct {
+	FieldID         int
+	FirstStart      time.Time
+	DurationMinutes int
+	Frequency       domain.RecurrenceFrequency
+	Occurrences     int
+	EndDate         *time.Time
+	PaymentMode     domain.SeriesPaymentMode
+}
+
+func (in BookingSeriesInput) validate(now time.Time) error {
+	if in.FieldID <= 0 {
+		return domain.Invalidf("invalid field ID")
+	}
+
+	if in.DurationMinutes <= 0 {
+		return domain.Invalidf("duration must be positive")
+	}
+
+	if in.FirstStart.Before(now) {
+		return domain.Invalidf("cannot book in the past")
+	}
+
+	if in.Frequency.IntervalDays() == 0 {
+		return domain.Invalidf("frequency must be WEEKLY or BIWEEKLY")
+	}
+
+	if in.PaymentMode != domain.SeriesPayPerOccurrence && in.PaymentMode != domain.SeriesPayUpfront {
+		return domain.Invalidf("payment mode must be PER_OCCURRENCE or UPFRONT")
+	}
+
+	if (in.Occurrences > 0) == (in.EndDate != nil) {
+		return domain.Invalidf("either occurrences or end date must be set")
+	}
+
+	if in.Occurrences < 0 {
+		return domain.Invalidf("occurrences must be positive")
+	}
+
+	return nil
+}
+
+// BookingSeriesDetail adalah series beserta semua occurrence-nya.
+// Payment hanya diisi untuk series UPFRONT.
+type BookingSeriesDetail struct {
+	Series   *domain.BookingSeries
+	Bookings []*domain.Booking
+	Payment  *domain.Payment
+}
+
+// CreateBookingSeries membuat booking berulang mingguan/dua mingguan
+// Business logic:
+// 1. Validasi input, otorisasi actor, dan SlotPolicy lapangan (sama dengan booking biasa)
+// 2. Hitung semua occurrence dari jumlah occurrence atau tanggal akhir (maksimal domain.MaxSeriesOccurrences)
+// 3. Cek SlotPolicy, ketersediaan, dan jam buka setiap occurrence di awal dengan helper yang sama dengan CreateBooking; jika ada yang bentrok atau jatuh pada tanggal tutup, semua tanggal tersebut dikembalikan sebagai SeriesConflictError
+// 4. Series, semua booking occurrence, dan payment PENDING dibuat dalam satu transaksi; charge di payment gateway dibuat setelah commit
+// 5. PER_OCCURRENCE: satu payment per booking dengan batas bayar domain.SeriesPaymentLeadTime sebelum occurrence dimulai
+// 6. Harga setiap occurrence dihitung terpisah dari pricing rule lapangan
+// 7. UPFRONT: satu payment untuk total harga series dengan hold pembayaran normal lapangan
+// 8. Jika salah satu charge gagal dibuat, semua payment series ditandai FAILED, semua occurrence dibatalkan, dan series ditandai CANCELLED
+func (u *bookingService) CreateBookingSeries(ctx context.Context, actor *domain.User, input BookingSeriesInput) (*BookingSeriesDetail, error) {
+	now := time.Now()
+
+	if err := input.validate(now); err != nil {
+		return nil, err
+	}
+
+	field, err := u.fieldRepo.FindByID(ctx, input.FieldID)
+	if err != nil {
+		return nil, domain.ErrFieldNotFound
+	}
+
+	if err := u.policy.Authorize(actor, authz.ActionBookingCreate, authz.Resource{Field: field}); err != nil {
+		return nil, err
+	}
+
+	// Occurrence dihitung di zona waktu lapangan supaya jam dinding tiap
+	// occurrence sama dengan FirstStart di lokasi lapangan.
+	firstStart := input.FirstStart.In(field.Location())
+
+	occurrences := input.Occurrences
+	if input.EndDate != nil {
+		occurrences = domain.CountOccurrencesUntil(firstStart, input.Frequency, *input.EndDate)
+	}
+
+	if occurrences == 0 {
+		return nil, domain.Invalidf("end date must not be before the first occurrence")
+	}
+
+	if occurrences > domain.MaxSeriesOccurrences {
+		return nil, domain.Invalidf("a series can have at most %d occurrences", domain.MaxSeriesOccurrences)
+	}
+
+	series := &domain.BookingSeries{
+		UserID:          actor.ID,
+		FieldID:         field.ID,
+		Frequency:       input.Frequency,
+		FirstStart:      firstStart,
+		DurationMinutes: input.DurationMinutes,
+		Occurrences:     occurrences,
+		PaymentMode:     input.PaymentMode,
+		Status:          domain.SeriesActive,
+		CreatedAt:       now,
+	}
+
+	duration := time.Duration(input.DurationMinutes) * time.Minute
+	blocked := duration + field.SlotPolicy.Buffer()
+	starts := series.OccurrenceStarts()
+
+	rules, err := u.fieldRepo.FindPricingRulesByFieldID(ctx, field.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching pricing rules: %w", err)
+	}
+
+	schedules, exceptions, err := openingHours(ctx, u.fieldRepo, field, starts[0], starts[len(starts)-1].Add(duration))
+	if err != nil {
+		return nil, err
+	}
+
+	// Setiap occurrence dicek seperti CreateBooking: SlotPolicy lalu jam buka
+	// pada tanggalnya sendiri, termasuk schedule exception.
+	conflicts := []time.Time{}
+	for _, start := range starts {
+		if err := validateDuration(field, start, input.DurationMinutes); err != nil {
+			return nil, err
+		}
+
+		if !isOpen(field, schedules, exceptions, start, start.Add(duration)) {
+			conflicts = append(conflicts, start)
+			continue
+		}
+
+		available, err := u.bookingRepo.CheckAvailability(ctx, field.ID, start, start.Add(blocked))
+		if err != nil {
+			return nil, fmt.Errorf("error checking availability: %w", err)
+		}
+
+		if !available {
+			conflicts = append(conflicts, start)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return nil, &domain.SeriesConflictError{Conflicts: conflicts}
+	}
+
+	detail := &BookingSeriesDetail{Series: series}
+	holdDeadline := field.HoldDeadline(now)
+	var charges []*pendingCharge
+
+	err = u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		charges = nil
+
+		if err := repos.BookingSeries.Create(ctx, series); err != nil {
+			return err
+		}
+
+		total := 0
+		for _, start := range starts {
+			expiresAt := holdDeadline
+			if !series.IsUpfront() {
+				expiresAt = domain.SeriesPaymentDeadline(field, start, now)
+			}
+
+			booking := &domain.Booking{
+				UserID:        actor.ID,
+				FieldID:       field.ID,
+				SeriesID:      &series.ID,
+				StartTime:     start,
+				EndTime:       start.Add(duration),
+				Status:        domain.BookingPending,
+				ExpiresAt:     &expiresAt,
+				BufferMinutes: field.SlotPolicy.BufferMinutes,
+				CreatedAt:     now,
+			}
+			price := domain.QuotePrice(field, rules, booking.StartTime, booking.EndTime)
+			if err := checkQuote(price); err != nil {
+				return err
+			}
+			booking.SetPrice(price)
+
+			// Request lain bisa saja mengambil slot setelah pengecekan di atas
+			slotTaken := &domain.SeriesConflictError{Conflicts: []time.Time{start}}
+			if err := insertBooking(ctx, repos, booking, actor, slotTaken, now); err != nil {
+				return err
+			}
+
+			if !series.IsUpfront() {
+				charge, err := createPayment(ctx, repos, u.gateway, actor, field, paymentOwner{bookingID: &booking.ID}, booking.TotalPrice, expiresAt, now)
+				if err != nil {
+					return err
+				}
+				booking.PaymentID = &charge.payment.ID
+				charges = append(charges, charge)
+			}
+
+			total += booking.TotalPrice
+			detail.Bookings = append(detail.Bookings, booking)
+		}
+
+		if series.IsUpfront() {
+			charge, err := createPayment(ctx, repos, u.gateway, actor, field, paymentOwner{seriesID: &series.ID}, total, holdDeadline, now)
+			if err != nil {
+				return err
+			}
+			detail.Payment = charge.payment
+			charges = append(charges, charge)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := createCharges(ctx, u.uow, u.gateway, charges...); err != nil {
+		u.cancelUnchargedSeries(ctx, series)
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// GetBookingSeries mengambil series beserta semua occurrence-nya
+// Customer pemilik series dan owner lapangan (atau admin) yang boleh melihat
+func (u *bookingService) GetBookingSeries(ctx context.Context, actor *domain.User, seriesID int) (*BookingSeriesDetail, error) {
+	series, _, err := u.authorizeSeries(ctx, actor, authz.ActionSeriesView, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := u.bookingRepo.FindBySeriesID(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching bookings: %w", err)
+	}
+
+	detail := &BookingSeriesDetail{Series: series, Bookings: bookings}
+
+	if series.IsUpfront() {
+		p, err := u.paymentRepo.FindBySeriesID(ctx, series.ID)
+		if err != nil && !errors.Is(err, domain.ErrPaymentNotFound) {
+			return nil, fmt.Errorf("error fetching payment: %w", err)
+		}
+		detail.Payment = p
+	}
+
+	return detail, nil
+}
+
+// CancelBookingSeries membatalkan semua occurrence yang dimulai sejak from
+// Business logic:
+// 1. Hanya customer pemilik series (atau admin) yang boleh membatalkan
+// 2. from kosong atau sudah lewat berarti semua occurrence yang belum dimulai
+// 3. Setiap occurrence dibatalkan dengan aturan yang sama seperti CancelBooking, termasuk refund sesuai kebijakan lapangan
+// 4. Series UPFRONT yang belum dibayar hanya bisa dibatalkan seluruhnya; payment-nya ditandai FAILED
+// 5. Series ditandai CANCELLED jika tidak ada lagi occurrence yang aktif
+func (u *bookingService) CancelBookingSeries(ctx context.Context, actor *domain.User, seriesID int, from time.Time) error {
+	series, field, err := u.authorizeSeries(ctx, actor, authz.ActionSeriesCancel, seriesID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if from.Before(now) {
+		from = now
+	}
+
+	var refunds []*domain.Refund
+
+	err = u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		bookings, err := repos.Bookings.LockBySeriesID(ctx, series.ID)
+		if err != nil {
+			return err
+		}
+
+		cancelled := 0
+		for _, booking := range bookings {
+			if booking.StartTime.Before(from) || !booking.CanBeCancelled(now) {
+				continue
+			}
+
+			cancelRefunds, err := cancelOccurrence(ctx, repos, booking, field, actor, now)
+			if err != nil {
+				return err
+			}
+
+			refunds = append(refunds, cancelRefunds...)
+			cancelled++
+		}
+
+		if cancelled == 0 {
+			return domain.Invalidf("no upcoming occurrences to cancel")
+		}
+
+		stillActive := false
+		for _, booking := range bookings {
+			if booking.IsPending() || booking.IsConfirmed() {
+				stillActive = true
+			}
+		}
+
+		if series.IsUpfront() {
+			sp, err := repos.Payments.FindBySeriesID(ctx, series.ID)
+			if err != nil && !errors.Is(err, domain.ErrPaymentNotFound) {
+				return fmt.Errorf("error fetching payment: %w", err)
+			}
+
+			if err == nil && sp.IsPending() {
+				if stillActive {
+					return domain.Invalidf("an unpaid upfront series can only be cancelled entirely")
+				}
+
+				if err := transitionPayment(ctx, repos, sp, domain.PaymentFailed, "series cancelled"); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !stillActive {
+			return repos.BookingSeries.UpdateStatus(ctx, series.ID, domain.SeriesCancelled)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, refund := range refunds {
+		issueRefund(ctx, u.uow, u.gateway, refund)
+	}
+
+	return nil
+}
+
+// cancelUnchargedSeries menandai series CANCELLED setelah semua occurrence-nya
+// dilepas karena charge pembayarannya gagal dibuat.
+func (u *bookingService) cancelUnchargedSeries(ctx context.Context, series *domain.BookingSeries) {
+	ctx, cancel := detach(ctx)
+	defer cancel()
+
+	err := u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		return repos.BookingSeries.UpdateStatus(ctx, series.ID, domain.SeriesCancelled)
+	})
+	if err != nil {
+		log.Printf("error cancelling series %d after failed charge: %v", series.ID, err)
+	}
+}
+
+// authorizeSeries memuat series dan lapangannya lalu mengevaluasi policy.
+func (u *bookingService) authorizeSeries(ctx context.Context, actor *domain.User, action authz.Action, seriesID int) (*domain.BookingSeries, *domain.Field, error) {
+	if seriesID <= 0 {
+		return nil, nil, domain.Invalidf("invalid series ID")
+	}
+
+	series, err := u.seriesRepo.FindByID(ctx, seriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	field, err := u.fieldRepo.FindByID(ctx, series.FieldID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching field: %w", err)
+	}
+
+	if err := u.policy.Authorize(actor, action, authz.Resource{Field: field, Series: series}); err != nil {
+		return nil, nil, err
+	}
+
+	return series, field, nil
+}
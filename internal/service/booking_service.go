@@ -1,106 +1,1091 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"futsal-booking-app/internal/authz"
 	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/payment"
 	"futsal-booking-app/internal/repository"
+	"log"
 	"time"
 )
 
 type BookingService interface {
-	CreateBooking(userID, fieldID int, startTime time.Time, durationHours int) (*domain.Booking, error)
-	GetBookingByID(id int) (*domain.Booking, error)
-	GetMyBookings(userID int) ([]*domain.Booking, error)
-	GetFileBookings(fieldID int) ([]*domain.Booking, error)
-	CancelBooking(userID, bookingID int) error
+	CreateBooking(ctx context.Context, actor *domain.User, fieldID int, startTime time.Time, durationMinutes int, promoCode string) (*domain.Booking, error)
+	GetBookingByID(ctx context.Context, actor *domain.User, id int) (*domain.Booking, error)
+	GetBookingPayment(ctx context.Context, actor *domain.User, bookingID int) (*domain.Payment, error)
+	GetMyBookings(ctx context.Context, userID int) ([]*domain.Booking, error)
+	GetFieldBookings(ctx context.Context, actor *domain.User, fieldID int) ([]*domain.Booking, error)
+	CancelBooking(ctx context.Context, actor *domain.User, bookingID int) error
+	GetBookingRefunds(ctx context.Context, actor *domain.User, bookingID int) ([]*domain.Refund, error)
 
-	ConfirmBooking(bookingID int) error
-	CompleteBooking(bookingID int) error
+	ConfirmBooking(ctx context.Context, actor *domain.User, bookingID int) error
+	CompleteBooking(ctx context.Context, actor *domain.User, bookingID int) error
+	MarkNoShow(ctx context.Context, actor *domain.User, bookingID int) error
+	GetBookingHistory(ctx context.Context, actor *domain.User, bookingID int) ([]*domain.BookingStatusChange, error)
+
+	RescheduleBooking(ctx context.Context, actor *domain.User, bookingID int, newStart time.Time, durationMinutes int) (*RescheduleResult, error)
+	GetBookingReschedules(ctx context.Context, actor *domain.User, bookingID int) ([]*domain.BookingReschedule, error)
+	GetBookingAdjustments(ctx context.Context, actor *domain.User, bookingID int) ([]*domain.PaymentAdjustment, error)
+
+	ExpireStaleBookings(ctx context.Context, limit int) (int, error)
+	CompleteEndedBookings(ctx context.Context, limit int) (int, error)
+	ExpireUnpaidAdjustments(ctx context.Context, limit int) (int, error)
+	RetryRefunds(ctx context.Context, limit int) (int, error)
+
+	CreateBookingSeries(ctx context.Context, actor *domain.User, input BookingSeriesInput) (*BookingSeriesDetail, error)
+	GetBookingSeries(ctx context.Context, actor *domain.User, seriesID int) (*BookingSeriesDetail, error)
+	CancelBookingSeries(ctx context.Context, actor *domain.User, seriesID int, from time.Time) error
 }
 
 type bookingService struct {
-	bookingRepo repository.BookingRepository
-	fieldRepo   repository.FieldRepository
-	paymentRepo repository.PaymentRepository
+	uow            repository.UnitOfWork
+	bookingRepo    repository.BookingRepository
+	fieldRepo      repository.FieldRepository
+	paymentRepo    repository.PaymentRepository
+	refundRepo     repository.RefundRepository
+	historyRepo    repository.BookingHistoryRepository
+	seriesRepo     repository.BookingSeriesRepository
+	rescheduleRepo repository.BookingRescheduleRepository
+	adjustmentRepo repository.PaymentAdjustmentRepository
+	gateway        payment.Gateway
+	policy         *authz.Policy
 }
 
-// func NewBookingService(bookingRepo repository.BookingRepository, fieldRepo repository.FieldRepository, paymentRepo repository.PaymentRepository) BookingService {
-// 	return &bookingService{
-// 		bookingRepo: bookingRepo,
-// 		fieldRepo:   fieldRepo,
-// 		paymentRepo: paymentRepo,
-// 	}
-// }
-
-func (u *bookingService) CreateBooking(userID, fieldID int, startTime time.Time, durationHours int) (*domain.Booking, error) {
-	if userID <= 0 {
-		return nil, fmt.Errorf("invalid user ID")
+func NewBookingService(uow repository.UnitOfWork, bookingRepo repository.BookingRepository, fieldRepo repository.FieldRepository, paymentRepo repository.PaymentRepository, refundRepo repository.RefundRepository, historyRepo repository.BookingHistoryRepository, seriesRepo repository.BookingSeriesRepository, rescheduleRepo repository.BookingRescheduleRepository, adjustmentRepo repository.PaymentAdjustmentRepository, gateway payment.Gateway, policy *authz.Policy) BookingService {
+	return &bookingService{
+		uow:            uow,
+		bookingRepo:    bookingRepo,
+		fieldRepo:      fieldRepo,
+		paymentRepo:    paymentRepo,
+		refundRepo:     refundRepo,
+		historyRepo:    historyRepo,
+		seriesRepo:     seriesRepo,
+		rescheduleRepo: rescheduleRepo,
+		adjustmentRepo: adjustmentRepo,
+		gateway:        gateway,
+		policy:         policy,
 	}
+}
 
+// CreateBooking membuat booking baru beserta payment PENDING
+// Business logic:
+// 1. Validasi input dan otorisasi actor
+// 2. Validasi jam mulai dan durasi terhadap SlotPolicy lapangan
+// 3. Cek ketersediaan slot termasuk buffer (fast path untuk pesan error yang jelas)
+// 4. Harga dihitung dari pricing rule lapangan dan rinciannya disimpan di booking
+// 5. Kode promo (opsional) divalidasi dan diskonnya diterapkan di dalam transaksi; redemption dicatat bersama booking
+// 6. Booking dan payment PENDING di-insert dalam satu transaksi; charge di payment gateway dibuat setelah commit
+// 7. Jika charge gagal dibuat, payment ditandai FAILED dan booking dibatalkan sehingga slot kembali tersedia
+// 8. Request yang kalah berebut slot ditolak constraint bookings_no_overlap dan dikembalikan sebagai SlotTakenError
+func (u *bookingService) CreateBooking(ctx context.Context, actor *domain.User, fieldID int, startTime time.Time, durationMinutes int, promoCode string) (*domain.Booking, error) {
 	if fieldID <= 0 {
-		return nil, fmt.Errorf("invalid field ID")
+		return nil, domain.Invalidf("invalid field ID")
 	}
 
-	if durationHours <= 0 {
-		return nil, fmt.Errorf("duration must be at least 1 hour")
+	if durationMinutes <= 0 {
+		return nil, domain.Invalidf("duration must be positive")
 	}
 
 	if startTime.Before(time.Now()) {
-		return nil, fmt.Errorf("cannot book in the past")
+		return nil, domain.Invalidf("cannot book in the past")
 	}
 
-	endTime := startTime.Add(time.Duration(durationHours) * time.Hour)
+	endTime := startTime.Add(time.Duration(durationMinutes) * time.Minute)
+	slotTaken := &domain.SlotTakenError{FieldID: fieldID, StartTime: startTime, EndTime: endTime}
 
-	field, err := u.fieldRepo.FindByID(fieldID)
+	field, err := u.fieldRepo.FindByID(ctx, fieldID)
 	if err != nil {
-		return nil, fmt.Errorf("field not found")
+		return nil, domain.ErrFieldNotFound
 	}
 
-	available, err := u.bookingRepo.CheckAvailability(fieldID, startTime, endTime)
+	if err := u.policy.Authorize(actor, authz.ActionBookingCreate, authz.Resource{Field: field}); err != nil {
+		return nil, err
+	}
+
+	if err := validateDuration(field, startTime, durationMinutes); err != nil {
+		return nil, err
+	}
+
+	if err := ensureOpen(ctx, u.fieldRepo, field, startTime, endTime); err != nil {
+		return nil, err
+	}
+
+	available, err := u.bookingRepo.CheckAvailability(ctx, fieldID, startTime, endTime.Add(field.SlotPolicy.Buffer()))
 	if err != nil {
 		return nil, fmt.Errorf("error checking availability: %w", err)
 	}
 
 	if !available {
-		return nil, fmt.Errorf("time slot is not available")
+		return nil, slotTaken
 	}
 
-	totalPrice := field.CalculatePrice(durationHours)
+	price, err := quotePrice(ctx, u.fieldRepo, field, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating price: %w", err)
+	}
+
+	now := time.Now()
+	expiresAt := field.HoldDeadline(now)
 
 	booking := &domain.Booking{
-		UserID:     userID,
-		FieldID:    fieldID,
-		StartTime:  startTime,
-		EndTime:    endTime,
-		TotalPrice: totalPrice,
-		Status:     domain.BookingPending,
-		CreatedAt:  time.Now(),
+		UserID:        actor.ID,
+		FieldID:       fieldID,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		Status:        domain.BookingPending,
+		ExpiresAt:     &expiresAt,
+		BufferMinutes: field.SlotPolicy.BufferMinutes,
+		CreatedAt:     now,
+	}
+	booking.SetPrice(price)
+
+	var charge *pendingCharge
+
+	err = u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		var promo *domain.PromoCode
+		if promoCode != "" {
+			promo, err = redeemPromo(ctx, repos, promoCode, booking, now)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := insertBooking(ctx, repos, booking, actor, slotTaken, now); err != nil {
+			return err
+		}
+
+		if promo != nil {
+			if err := recordRedemption(ctx, repos, promo, booking, now); err != nil {
+				return err
+			}
+		}
+
+		charge, err = createPayment(ctx, repos, u.gateway, actor, field, paymentOwner{bookingID: &booking.ID}, booking.TotalPrice, expiresAt, now)
+		if err != nil {
+			return err
+		}
+
+		booking.PaymentID = &charge.payment.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := u.bookingRepo.Create(booking); err != nil {
-		return nil, fmt.Errorf("error creating booking: %w", err)
+	if err := createCharges(ctx, u.uow, u.gateway, charge); err != nil {
+		return nil, err
 	}
 
-	payment := &domain.Payment{
-		BookingID:      booking.ID,
-		Amount:         totalPrice,
-		PaymentGateway: "Midtrans",
-		TransactionID:  fmt.Sprintf("TRX-%d-%d", booking.ID, time.Now().Unix()),
+	return booking, nil
+}
+
+func (u *bookingService) GetBookingByID(ctx context.Context, actor *domain.User, id int) (*domain.Booking, error) {
+	if id <= 0 {
+		return nil, domain.Invalidf("invalid booking ID")
+	}
+
+	booking, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching booking: %w", err)
+	}
+
+	if err := u.authorizeBooking(ctx, actor, authz.ActionBookingView, booking); err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+// GetBookingPayment mengambil payment milik booking, termasuk URL pembayaran dari gateway
+// Aturan aksesnya sama dengan melihat booking
+func (u *bookingService) GetBookingPayment(ctx context.Context, actor *domain.User, bookingID int) (*domain.Payment, error) {
+	booking, err := u.GetBookingByID(ctx, actor, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := u.paymentRepo.FindByBookingID(ctx, booking.ID)
+	if errors.Is(err, domain.ErrPaymentNotFound) && booking.SeriesID != nil {
+		p, err = u.paymentRepo.FindBySeriesID(ctx, *booking.SeriesID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (u *bookingService) GetMyBookings(ctx context.Context, userID int) ([]*domain.Booking, error) {
+	if userID <= 0 {
+		return nil, domain.Invalidf("invalid user ID")
+	}
+
+	bookings, err := u.bookingRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// GetFieldBookings mengambil semua booking untuk satu lapangan
+// Hanya owner lapangan (atau admin) yang boleh melihat daftar booking lapangannya
+func (u *bookingService) GetFieldBookings(ctx context.Context, actor *domain.User, fieldID int) ([]*domain.Booking, error) {
+	if fieldID <= 0 {
+		return nil, domain.Invalidf("invalid field ID")
+	}
+
+	field, err := u.fieldRepo.FindByID(ctx, fieldID)
+	if err != nil {
+		return nil, domain.ErrFieldNotFound
+	}
+
+	if err := u.policy.Authorize(actor, authz.ActionFieldViewBookings, authz.Resource{Field: field}); err != nil {
+		return nil, err
+	}
+
+	bookings, err := u.bookingRepo.FindByFieldID(ctx, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// CancelBooking membatalkan booking milik customer, termasuk satu occurrence dari series
+// Business logic:
+// 1. Hanya customer pemilik booking (atau admin) yang boleh membatalkan
+// 2. Pembatalan hanya bisa dilakukan lebih dari 2 jam sebelum booking dimulai (Booking.CanBeCancelled)
+// 3. Booking yang belum dibayar: payment PENDING ditandai FAILED
+// 4. Booking yang sudah dibayar: besar refund dihitung dari CancellationPolicy lapangan dan dicatat sebagai refund PENDING
+// 5. Booking, payment, dan refund diupdate dalam satu transaksi; refund dicairkan lewat gateway setelah transaksi commit
+// 6. Occurrence dari series UPFRONT yang belum dibayar tidak bisa dibatalkan satu per satu
+func (u *bookingService) CancelBooking(ctx context.Context, actor *domain.User, bookingID int) error {
+	if bookingID <= 0 {
+		return domain.Invalidf("invalid booking ID")
+	}
+
+	booking, err := u.bookingRepo.FindByID(ctx, bookingID)
+	if err != nil {
+		return domain.ErrBookingNotFound
+	}
+
+	field, err := u.fieldRepo.FindByID(ctx, booking.FieldID)
+	if err != nil {
+		return fmt.Errorf("error fetching field: %w", err)
+	}
+
+	if err := u.policy.Authorize(actor, authz.ActionBookingCancel, authz.Resource{Field: field, Booking: booking}); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var refunds []*domain.Refund
+
+	err = u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		// Booking dikunci supaya tidak bentrok dengan notifikasi pembayaran
+		// yang mengkonfirmasi booking yang sama.
+		booking, err := repos.Bookings.FindByIDForUpdate(ctx, bookingID)
+		if err != nil {
+			return err
+		}
+
+		if booking.SeriesID != nil {
+			sp, err := repos.Payments.FindBySeriesID(ctx, *booking.SeriesID)
+			if err != nil && !errors.Is(err, domain.ErrPaymentNotFound) {
+				return fmt.Errorf("error fetching payment: %w", err)
+			}
+			if err == nil && sp.IsPending() {
+				return domain.Invalidf("occurrences of an unpaid upfront series cannot be cancelled individually, cancel the series instead")
+			}
+		}
+
+		refunds, err = cancelOccurrence(ctx, repos, booking, field, actor, now)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, refund := range refunds {
+		issueRefund(ctx, u.uow, u.gateway, refund)
+	}
+
+	return nil
+}
+
+// GetBookingRefunds mengambil riwayat refund sebuah booking, terbaru lebih dulu
+// Aturan aksesnya sama dengan melihat booking
+func (u *bookingService) GetBookingRefunds(ctx context.Context, actor *domain.User, bookingID int) ([]*domain.Refund, error) {
+	booking, err := u.GetBookingByID(ctx, actor, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := u.refundRepo.FindByBookingID(ctx, booking.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching refunds: %w", err)
+	}
+
+	return refunds, nil
+}
+
+// ConfirmBooking mengkonfirmasi booking PENDING
+// Hanya owner lapangan dari booking tersebut (atau admin) yang boleh mengkonfirmasi
+func (u *bookingService) ConfirmBooking(ctx context.Context, actor *domain.User, bookingID int) error {
+	return u.changeStatus(ctx, actor, bookingID, authz.ActionBookingConfirm, func(repos *repository.Repositories, booking *domain.Booking) error {
+		if booking.IsHoldExpired(time.Now()) {
+			return domain.Invalidf("booking payment hold has expired")
+		}
+
+		return transitionBooking(ctx, repos, booking, domain.BookingConfirmed, actor, "confirmed by owner")
+	})
+}
+
+// CompleteBooking menandai booking CONFIRMED sebagai selesai
+// Hanya owner lapangan dari booking tersebut (atau admin) yang boleh menyelesaikan
+func (u *bookingService) CompleteBooking(ctx context.Context, actor *domain.User, bookingID int) error {
+	return u.changeStatus(ctx, actor, bookingID, authz.ActionBookingComplete, func(repos *repository.Repositories, booking *domain.Booking) error {
+		return transitionBooking(ctx, repos, booking, domain.BookingCompleted, actor, "completed by owner")
+	})
+}
+
+// MarkNoShow menandai booking CONFIRMED yang customernya tidak datang
+// Hanya owner lapangan dari booking tersebut (atau admin), dan hanya setelah booking dimulai
+func (u *bookingService) MarkNoShow(ctx context.Context, actor *domain.User, bookingID int) error {
+	return u.changeStatus(ctx, actor, bookingID, authz.ActionBookingNoShow, func(repos *repository.Repositories, booking *domain.Booking) error {
+		if time.Now().Before(booking.StartTime) {
+			return domain.Invalidf("booking cannot be marked as no-show before it starts")
+		}
+
+		return transitionBooking(ctx, repos, booking, domain.BookingNoShow, actor, "customer did not show up")
+	})
+}
+
+// GetBookingHistory mengambil riwayat perubahan status booking, terlama lebih dulu
+// Aturan aksesnya sama dengan melihat booking
+func (u *bookingService) GetBookingHistory(ctx context.Context, actor *domain.User, bookingID int) ([]*domain.BookingStatusChange, error) {
+	booking, err := u.GetBookingByID(ctx, actor, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := u.historyRepo.FindByBookingID(ctx, booking.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching booking history: %w", err)
+	}
+
+	return changes, nil
+}
+
+// changeStatus memuat dan mengotorisasi booking, lalu menjalankan fn di dalam
+// transaksi dengan baris booking terkunci.
+func (u *bookingService) changeStatus(ctx context.Context, actor *domain.User, bookingID int, action authz.Action, fn func(repos *repository.Repositories, booking *domain.Booking) error) error {
+	if bookingID <= 0 {
+		return domain.Invalidf("invalid booking ID")
+	}
+
+	booking, err := u.bookingRepo.FindByID(ctx, bookingID)
+	if err != nil {
+		return domain.ErrBookingNotFound
+	}
+
+	if err := u.authorizeBooking(ctx, actor, action, booking); err != nil {
+		return err
+	}
+
+	return u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		booking, err := repos.Bookings.FindByIDForUpdate(ctx, bookingID)
+		if err != nil {
+			return err
+		}
+
+		return fn(repos, booking)
+	})
+}
+
+// ExpireStaleBookings meng-expire booking PENDING yang melewati batas waktu pembayaran
+// Business logic:
+// 1. Booking diubah menjadi EXPIRED sehingga slot kembali tersedia
+// 2. Payment PENDING milik booking tersebut ditandai FAILED
+// Dipanggil secara berkala oleh worker, maksimal limit booking per panggilan.
+func (u *bookingService) ExpireStaleBookings(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, domain.Invalidf("limit must be positive")
+	}
+
+	var count int
+
+	err := u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		now := time.Now()
+
+		expired, err := repos.Bookings.ExpireHolds(ctx, now, limit)
+		if err != nil {
+			return err
+		}
+
+		if err := releaseExpiredHolds(ctx, repos, expired, now); err != nil {
+			return err
+		}
+
+		count = len(expired)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error expiring bookings: %w", err)
+	}
+
+	return count, nil
+}
+
+// CompleteEndedBookings menandai booking CONFIRMED yang sudah melewati end time sebagai COMPLETED
+// Business logic:
+// 1. Booking diambil dengan FOR UPDATE SKIP LOCKED, maksimal limit per panggilan
+// 2. Setiap booking dipindahkan lewat state machine oleh sistem dan dicatat di riwayat status
+// Dipanggil secara berkala oleh scheduler.
+func (u *bookingService) CompleteEndedBookings(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, domain.Invalidf("limit must be positive")
+	}
+
+	var count int
+
+	err := u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		ended, err := repos.Bookings.LockEndedConfirmed(ctx, time.Now(), limit)
+		if err != nil {
+			return err
+		}
+
+		for _, booking := range ended {
+			if err := transitionBooking(ctx, repos, booking, domain.BookingCompleted, nil, "booking ended"); err != nil {
+				return err
+			}
+		}
+
+		count = len(ended)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error completing bookings: %w", err)
+	}
+
+	return count, nil
+}
+
+// ExpireUnpaidAdjustments menangani tagihan selisih reschedule yang tidak dibayar
+// Business logic:
+// 1. Adjustment PENDING yang melewati ExpiresAt diambil dengan FOR UPDATE SKIP LOCKED, maksimal limit per panggilan
+// 2. Adjustment ditandai FAILED
+// 3. Booking yang masih CONFIRMED dibatalkan oleh sistem dan seluruh dana yang sudah dibayar di-refund
+// 4. Refund dicairkan lewat gateway setelah transaksi commit
+// Dipanggil secara berkala oleh worker.
+func (u *bookingService) ExpireUnpaidAdjustments(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, domain.Invalidf("limit must be positive")
+	}
+
+	var count int
+	var refunds []*domain.Refund
+
+	err := u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		now := time.Now()
+		refunds = nil
+
+		expired, err := repos.Adjustments.LockExpiredPending(ctx, now, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, a := range expired {
+			issued, err := failAdjustment(ctx, repos, a, "reschedule adjustment expired unpaid", now)
+			if err != nil {
+				return err
+			}
+			refunds = append(refunds, issued...)
+		}
+
+		count = len(expired)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error expiring payment adjustments: %w", err)
+	}
+
+	for _, refund := range refunds {
+		issueRefund(ctx, u.uow, u.gateway, refund)
+	}
+
+	return count, nil
+}
+
+// refundRetryDelay adalah jeda sejak refund terakhir diubah sebelum dicoba ulang,
+// supaya refund yang baru dibuat dan masih dicairkan pemanggilnya tidak diambil.
+const refundRetryDelay = time.Minute
+
+// RetryRefunds mencairkan ulang refund yang belum berhasil
+// Business logic:
+// 1. Refund PENDING (proses terhenti sebelum gateway dipanggil) dan FAILED yang tidak diubah selama refundRetryDelay diambil, maksimal limit per panggilan
+// 2. Setiap refund dicairkan lewat issueRefund; RefundKey yang sama membuat gateway tidak mencairkan dana dua kali
+// 3. Refund yang gagal lagi tetap FAILED dan updated_at-nya maju, jadi dicoba lagi di eksekusi berikutnya
+// Dipanggil secara berkala oleh scheduler.
+func (u *bookingService) RetryRefunds(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, domain.Invalidf("limit must be positive")
+	}
+
+	refunds, err := u.refundRepo.FindRetryable(ctx, time.Now().Add(-refundRetryDelay), limit)
+	if err != nil {
+		return 0, fmt.Errorf("error fetching retryable refunds: %w", err)
+	}
+
+	for _, refund := range refunds {
+		if ctx.Err() != nil {
+			break
+		}
+		issueRefund(ctx, u.uow, u.gateway, refund)
+	}
+
+	return len(refunds), nil
+}
+
+// authorizeBooking memuat lapangan milik booking lalu mengevaluasi policy,
+// sehingga rule berbasis owner lapangan bisa dipakai untuk aksi pada booking.
+func (u *bookingService) authorizeBooking(ctx context.Context, actor *domain.User, action authz.Action, booking *domain.Booking) error {
+	field, err := u.fieldRepo.FindByID(ctx, booking.FieldID)
+	if err != nil {
+		return fmt.Errorf("error fetching field: %w", err)
+	}
+
+	return u.policy.Authorize(actor, action, authz.Resource{Field: field, Booking: booking})
+}
+
+// issueRefund mencairkan refund PENDING atau FAILED lewat payment gateway lalu
+// mencatat hasilnya. Dipanggil setelah transaksi yang membuat refund commit,
+// dengan context yang lepas dari request, jadi kegagalan gateway tidak
+// dikembalikan ke caller; refund ditandai FAILED, terlihat di riwayat refund,
+// dan dicoba ulang oleh RetryRefunds.
+func issueRefund(ctx context.Context, uow repository.UnitOfWork, gateway payment.Gateway, refund *domain.Refund) {
+	ctx, cancel := detach(ctx)
+	defer cancel()
+
+	var p *domain.Payment
+
+	err := uow.Do(ctx, func(repos *repository.Repositories) error {
+		var err error
+		p, err = repos.Payments.FindByID(ctx, refund.PaymentID)
+		return err
+	})
+	if err != nil {
+		log.Printf("error fetching payment for refund %d: %v", refund.ID, err)
+		return
+	}
+
+	transactionID := refund.TransactionID
+	if transactionID == "" {
+		transactionID = p.TransactionID
+	}
+
+	_, gatewayErr := gateway.Refund(ctx, payment.RefundRequest{
+		TransactionID: transactionID,
+		RefundKey:     refund.RefundKey,
+		Amount:        refund.Amount,
+		Reason:        refund.Reason,
+	})
+
+	err = uow.Do(ctx, func(repos *repository.Repositories) error {
+		if gatewayErr != nil {
+			refund.MarkAsFailed(gatewayErr.Error())
+			return repos.Refunds.Update(ctx, refund)
+		}
+
+		refund.MarkAsSuccess()
+		if err := repos.Refunds.Update(ctx, refund); err != nil {
+			return err
+		}
+
+		// Refund atas transaksi lain tidak mengurangi nominal payment
+		if refund.TransactionID != "" {
+			return nil
+		}
+
+		// Payment series bisa di-refund sebagian per occurrence, jadi baru
+		// ditandai REFUNDED setelah seluruh nominalnya dikembalikan.
+		refunded, err := repos.Refunds.TotalSucceededByPaymentID(ctx, p.ID)
+		if err != nil {
+			return err
+		}
+
+		if refunded < p.Amount {
+			return nil
+		}
+
+		return transitionPayment(ctx, repos, p, domain.PaymentRefunded, "fully refunded")
+	})
+	if err != nil {
+		log.Printf("error recording refund %d: %v", refund.ID, err)
+	}
+
+	if gatewayErr != nil {
+		log.Printf("refund %d for booking %d failed at gateway: %v", refund.ID, refund.BookingID, gatewayErr)
+	}
+}
+
+// transitionBooking memindahkan status booking lewat state machine domain,
+// menyimpannya, dan mencatat riwayatnya. Actor nil berarti perubahan oleh sistem.
+// Harus dipanggil di dalam UnitOfWork.
+func transitionBooking(ctx context.Context, repos *repository.Repositories, booking *domain.Booking, to domain.BookingStatus, actor *domain.User, reason string) error {
+	from := booking.Status
+
+	var actorID *int
+	if actor != nil {
+		actorID = &actor.ID
+	}
+
+	change, err := booking.TransitionTo(to, transitionActor(actor, booking), actorID, reason, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := repos.Bookings.UpdateStatus(ctx, booking.ID, from, to); err != nil {
+		return err
+	}
+
+	if err := repos.BookingHistory.Create(ctx, change); err != nil {
+		return err
+	}
+
+	// Kuota promo dikembalikan begitu booking tidak lagi berlaku
+	if to == domain.BookingCancelled || to == domain.BookingExpired {
+		return repos.Promos.ReleaseRedemption(ctx, booking.ID, change.CreatedAt)
+	}
+
+	return nil
+}
+
+// transitionPayment memindahkan status payment lewat transisi yang diizinkan
+// domain, menyimpannya, dan mencatat riwayatnya. Harus dipanggil di dalam UnitOfWork.
+func transitionPayment(ctx context.Context, repos *repository.Repositories, p *domain.Payment, to domain.PaymentStatus, reason string) error {
+	change, err := p.TransitionTo(to, reason, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := repos.Payments.Update(ctx, p); err != nil {
+		return fmt.Errorf("error updating payment: %w", err)
+	}
+
+	return repos.PaymentHistory.Create(ctx, change)
+}
+
+// transitionActor menentukan peran actor terhadap booking untuk state machine.
+// Otorisasi sudah dilakukan policy, jadi actor selain admin dan customer
+// pemilik booking adalah owner lapangan.
+func transitionActor(actor *domain.User, booking *domain.Booking) domain.TransitionActor {
+	switch {
+	case actor == nil:
+		return domain.ActorSystem
+	case actor.IsAdmin():
+		return domain.ActorAdmin
+	case actor.ID == booking.UserID:
+		return domain.ActorCustomer
+	default:
+		return domain.ActorOwner
+	}
+}
+
+// releaseExpiredHolds mencatat riwayat booking yang baru saja di-expire secara
+// massal oleh repository, menandai payment PENDING-nya FAILED, dan melepas
+// redemption promo-nya.
+func releaseExpiredHolds(ctx context.Context, repos *repository.Repositories, expired []*domain.Booking, now time.Time) error {
+	for _, booking := range expired {
+		err := repos.BookingHistory.Create(ctx, &domain.BookingStatusChange{
+			BookingID:  booking.ID,
+			FromStatus: domain.BookingPending,
+			ToStatus:   domain.BookingExpired,
+			ActorType:  domain.ActorSystem,
+			Reason:     "payment hold expired",
+			CreatedAt:  now,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := failPendingPayment(ctx, repos, booking); err != nil {
+			return err
+		}
+
+		if err := repos.Promos.ReleaseRedemption(ctx, booking.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertBooking menyimpan booking PENDING baru beserta riwayat pembuatannya.
+// Hold kadaluarsa yang belum diproses worker masih memblokir constraint
+// bookings_no_overlap, jadi dilepas dulu di transaksi yang sama. Jika slot
+// tetap bentrok, slotTaken dikembalikan.
+func insertBooking(ctx context.Context, repos *repository.Repositories, booking *domain.Booking, actor *domain.User, slotTaken error, now time.Time) error {
+	expired, err := repos.Bookings.ExpireOverlappingHolds(ctx, booking.FieldID, booking.StartTime, booking.BlockedUntil(), now)
+	if err != nil {
+		return fmt.Errorf("error releasing expired holds: %w", err)
+	}
+
+	if err := releaseExpiredHolds(ctx, repos, expired, now); err != nil {
+		return err
+	}
+
+	if err := repos.Bookings.Create(ctx, booking); err != nil {
+		if repository.IsBookingOverlap(err) {
+			return slotTaken
+		}
+		return fmt.Errorf("error creating booking: %w", err)
+	}
+
+	return repos.BookingHistory.Create(ctx, &domain.BookingStatusChange{
+		BookingID: booking.ID,
+		ToStatus:  domain.BookingPending,
+		ActorID:   &actor.ID,
+		ActorType: transitionActor(actor, booking),
+		Reason:    "booking created",
+		CreatedAt: now,
+	})
+}
+
+// validateDuration mengecek jam mulai dan durasi booking terhadap SlotPolicy lapangan.
+func validateDuration(field *domain.Field, startTime time.Time, durationMinutes int) error {
+	policy := field.SlotPolicy
+
+	if durationMinutes <= 0 {
+		return domain.Invalidf("duration must be positive")
+	}
+
+	if !policy.IsAligned(startTime.In(field.Location())) {
+		return domain.Invalidf("start time must be aligned to %d-minute slots", policy.SlotMinutes)
+	}
+
+	if durationMinutes%policy.SlotMinutes != 0 {
+		return domain.Invalidf("duration must be a multiple of %d minutes", policy.SlotMinutes)
+	}
+
+	if durationMinutes < policy.MinDurationMinutes {
+		return domain.Invalidf("duration must be at least %d minutes", policy.MinDurationMinutes)
+	}
+
+	if policy.MaxDurationMinutes > 0 && durationMinutes > policy.MaxDurationMinutes {
+		return domain.Invalidf("duration cannot exceed %d minutes", policy.MaxDurationMinutes)
+	}
+
+	return nil
+}
+
+// paymentOwner menunjuk pemilik payment: satu booking atau satu series.
+type paymentOwner struct {
+	bookingID *int
+	seriesID  *int
+}
+
+func (o paymentOwner) transactionID(now time.Time) string {
+	if o.seriesID != nil {
+		return fmt.Sprintf("TRX-S%d-%d", *o.seriesID, now.Unix())
+	}
+	return fmt.Sprintf("TRX-%d-%d", *o.bookingID, now.Unix())
+}
+
+// settleTimeout membatasi pekerjaan gateway yang dijalankan setelah transaksi
+// commit. Pekerjaan ini memakai context yang lepas dari request supaya tidak
+// ikut batal saat client memutus koneksi.
+const settleTimeout = 30 * time.Second
+
+// detach membuat context untuk pekerjaan setelah commit: tetap membawa nilai
+// dari ctx, tetapi tidak ikut dibatalkan dan dibatasi settleTimeout.
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), settleTimeout)
+}
+
+// pendingCharge adalah payment atau adjustment PENDING yang sudah disimpan
+// tetapi charge-nya belum dibuat di payment gateway. Tepat satu dari payment
+// atau adjustment yang diisi.
+type pendingCharge struct {
+	payment    *domain.Payment
+	adjustment *domain.PaymentAdjustment
+	request    payment.ChargeRequest
+}
+
+// createPayment menyimpan payment PENDING dengan transaction ID yang sudah
+// ditentukan, tanpa memanggil gateway. Charge-nya dibuat oleh createCharges
+// setelah transaksi pemanggil commit, sehingga setiap charge yang bisa dibayar
+// customer selalu punya baris payment untuk dicocokkan webhook.
+func createPayment(ctx context.Context, repos *repository.Repositories, gateway payment.Gateway, actor *domain.User, field *domain.Field, owner paymentOwner, amount int, expiresAt, now time.Time) (*pendingCharge, error) {
+	p := &domain.Payment{
+		BookingID:      owner.bookingID,
+		SeriesID:       owner.seriesID,
+		Amount:         amount,
+		PaymentGateway: gateway.Name(),
+		TransactionID:  owner.transactionID(now),
 		Status:         domain.PaymentPending,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}
 
-	if err := u.paymentRepo.Create(payment); err != nil {
+	if err := repos.Payments.Create(ctx, p); err != nil {
 		return nil, fmt.Errorf("error creating payment: %w", err)
 	}
 
-	booking.PaymentID = &payment.ID
+	return &pendingCharge{
+		payment: p,
+		request: payment.ChargeRequest{
+			TransactionID: p.TransactionID,
+			Amount:        amount,
+			ItemName:      field.Name,
+			CustomerName:  actor.Name,
+			CustomerEmail: actor.Email,
+			ExpiresAt:     expiresAt,
+		},
+	}, nil
+}
+
+// createCharges membuat charge di payment gateway untuk payment/adjustment yang
+// sudah di-commit, lalu menyimpan URL pembayarannya. Gateway tidak dipanggil di
+// dalam transaksi supaya lock baris tidak tertahan selama request HTTP.
+// Jika salah satu charge gagal, semua charges dilepas lewat releaseCharges
+// (payment FAILED, booking PENDING dibatalkan) dan error dikembalikan.
+func createCharges(ctx context.Context, uow repository.UnitOfWork, gateway payment.Gateway, charges ...*pendingCharge) error {
+	ctx, cancel := detach(ctx)
+	defer cancel()
 
-	if err := u.bookingRepo.Update(booking); err != nil {
-		return nil, fmt.Errorf("error updating booking: %w", err)
+	for _, c := range charges {
+		charge, err := gateway.CreateCharge(ctx, c.request)
+		if err == nil {
+			err = uow.Do(ctx, func(repos *repository.Repositories) error {
+				return c.storeURL(ctx, repos, charge.PaymentURL)
+			})
+		}
+		if err != nil {
+			releaseCharges(ctx, uow, gateway, charges, "payment charge could not be created")
+			return fmt.Errorf("error creating payment charge: %w", err)
+		}
 	}
 
-	return booking, nil
+	return nil
+}
+
+func (c *pendingCharge) storeURL(ctx context.Context, repos *repository.Repositories, paymentURL string) error {
+	if c.adjustment != nil {
+		if err := repos.Adjustments.UpdatePaymentURL(ctx, c.adjustment.ID, paymentURL); err != nil {
+			return err
+		}
+		c.adjustment.PaymentURL = paymentURL
+		return nil
+	}
+
+	if err := repos.Payments.UpdatePaymentURL(ctx, c.payment.ID, paymentURL); err != nil {
+		return err
+	}
+	c.payment.PaymentURL = paymentURL
+	return nil
+}
+
+// releaseCharges melepas payment/adjustment yang charge-nya tidak bisa dibuat.
+// Payment PENDING ditandai FAILED dan booking PENDING-nya dibatalkan sehingga
+// slot kembali tersedia; adjustment PENDING diperlakukan seperti adjustment
+// yang gagal dibayar (lihat failAdjustment). Charge yang terlanjur dibuat tetap
+// punya baris lokal, jadi jika dibayar dananya di-refund lewat webhook.
+func releaseCharges(ctx context.Context, uow repository.UnitOfWork, gateway payment.Gateway, charges []*pendingCharge, reason string) {
+	var refunds []*domain.Refund
+
+	err := uow.Do(ctx, func(repos *repository.Repositories) error {
+		refunds = nil
+		now := time.Now()
+
+		for _, c := range charges {
+			issued, err := c.release(ctx, repos, reason, now)
+			if err != nil {
+				return err
+			}
+			refunds = append(refunds, issued...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("error releasing uncharged payments: %v", err)
+		return
+	}
+
+	for _, refund := range refunds {
+		issueRefund(ctx, uow, gateway, refund)
+	}
+}
+
+func (c *pendingCharge) release(ctx context.Context, repos *repository.Repositories, reason string, now time.Time) ([]*domain.Refund, error) {
+	if c.adjustment != nil {
+		a, err := repos.Adjustments.FindByTransactionIDForUpdate(ctx, c.adjustment.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !a.IsPending() {
+			return nil, nil
+		}
+
+		return failAdjustment(ctx, repos, a, reason, now)
+	}
+
+	p, err := repos.Payments.FindByTransactionIDForUpdate(ctx, c.payment.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.IsPending() {
+		return nil, nil
+	}
+
+	if err := transitionPayment(ctx, repos, p, domain.PaymentFailed, reason); err != nil {
+		return nil, err
+	}
+	*c.payment = *p
+
+	bookings, err := lockPaymentBookings(ctx, repos, p)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, booking := range bookings {
+		if !booking.IsPending() {
+			continue
+		}
+
+		if err := transitionBooking(ctx, repos, booking, domain.BookingCancelled, nil, reason); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// cancelOccurrence membatalkan booking yang sudah dikunci dan menyiapkan refund
+// jika booking sudah dibayar. Untuk occurrence series UPFRONT, refund dihitung
+// dari harga occurrence tersebut, bukan seluruh payment series. Refund booking
+// biasa dibagi ke transaksi payment dan adjustment yang sudah dibayar (lihat
+// splitRefund). Payment series yang masih PENDING tidak diubah; pemanggil yang
+// menanganinya.
+func cancelOccurrence(ctx context.Context, repos *repository.Repositories, booking *domain.Booking, field *domain.Field, actor *domain.User, now time.Time) ([]*domain.Refund, error) {
+	if !booking.CanBeCancelled(now) {
+		return nil, domain.Invalidf("booking can only be cancelled more than 2 hours before it starts")
+	}
+
+	if err := transitionBooking(ctx, repos, booking, domain.BookingCancelled, actor, "cancelled by request"); err != nil {
+		return nil, err
+	}
+
+	p, err := findBookingPayment(ctx, repos, booking)
+	if err != nil || p == nil {
+		return nil, err
+	}
+
+	if p.IsPending() {
+		if p.SeriesID != nil {
+			return nil, nil
+		}
+
+		return nil, transitionPayment(ctx, repos, p, domain.PaymentFailed, "booking cancelled")
+	}
+
+	if !p.IsSuccess() {
+		return nil, nil
+	}
+
+	refundKey := fmt.Sprintf("RF-%d-%d", booking.ID, now.Unix())
+
+	if p.SeriesID != nil {
+		amount := field.CancellationPolicy.RefundAmount(booking.TotalPrice, booking.StartTime, now)
+		if amount <= 0 {
+			return nil, nil
+		}
+
+		refund := &domain.Refund{
+			BookingID: booking.ID,
+			PaymentID: p.ID,
+			Amount:    amount,
+			Reason:    "booking cancelled",
+			RefundKey: refundKey,
+			Status:    domain.RefundPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		if err := repos.Refunds.Create(ctx, refund); err != nil {
+			return nil, fmt.Errorf("error creating refund: %w", err)
+		}
+
+		return []*domain.Refund{refund}, nil
+	}
+
+	paid, err := netPaid(ctx, repos, booking, p)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := field.CancellationPolicy.RefundAmount(paid, booking.StartTime, now)
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	return splitRefund(ctx, repos, booking, p, amount, "booking cancelled", refundKey, now)
+}
+
+// netPaid menghitung dana yang benar-benar diterima untuk booking: payment
+// awal ditambah adjustment yang sudah dibayar, dikurangi refund yang sudah
+// dibuat (misalnya kredit selisih harga reschedule).
+func netPaid(ctx context.Context, repos *repository.Repositories, booking *domain.Booking, p *domain.Payment) (int, error) {
+	charges, err := refundableCharges(ctx, repos, booking, p)
+	if err != nil {
+		return 0, err
+	}
+
+	paid := 0
+	for _, charge := range charges {
+		paid += charge.remaining
+	}
+
+	return paid, nil
+}
+
+// findBookingPayment mengambil payment yang menanggung booking: payment booking
+// itu sendiri, atau payment series jika series dibayar di muka. Booking tanpa
+// payment mengembalikan nil.
+func findBookingPayment(ctx context.Context, repos *repository.Repositories, booking *domain.Booking) (*domain.Payment, error) {
+	p, err := repos.Payments.FindByBookingID(ctx, booking.ID)
+	if errors.Is(err, domain.ErrPaymentNotFound) && booking.SeriesID != nil {
+		p, err = repos.Payments.FindBySeriesID(ctx, *booking.SeriesID)
+	}
+
+	if err != nil {
+		if errors.Is(err, domain.ErrPaymentNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error fetching payment: %w", err)
+	}
+
+	return p, nil
+}
+
+// failPendingPayment menandai payment PENDING yang menanggung booking sebagai FAILED.
+// Booking tanpa payment dilewati.
+func failPendingPayment(ctx context.Context, repos *repository.Repositories, booking *domain.Booking) error {
+	p, err := findBookingPayment(ctx, repos, booking)
+	if err != nil || p == nil {
+		return err
+	}
+
+	if !p.IsPending() {
+		return nil
+	}
 
+	return transitionPayment(ctx, repos, p, domain.PaymentFailed, "payment hold expired")
 }
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"time"
+)
+
+// fakeUnitOfWork menjalankan fn langsung dengan repository in-memory. Tidak
+// ada rollback, jadi test hanya memeriksa hasil transaksi yang berhasil.
+type fakeUnitOfWork struct {
+	repos *repository.Repositories
+}
+
+func (u *fakeUnitOfWork) Do(ctx context.Context, fn func(repos *repository.Repositories) error) error {
+	return fn(u.repos)
+}
+
+// Repository in-memory di bawah hanya mengimplementasikan method yang dipakai
+// test; method lain panic lewat interface yang di-embed.
+
+type fakePaymentRepo struct {
+	repository.PaymentRepository
+	payments map[int]*domain.Payment
+}
+
+func (r *fakePaymentRepo) FindByID(ctx context.Context, id int) (*domain.Payment, error) {
+	p, ok := r.payments[id]
+	if !ok {
+		return nil, domain.ErrPaymentNotFound
+	}
+	copied := *p
+	return &copied, nil
+}
+
+func (r *fakePaymentRepo) FindByTransactionIDForUpdate(ctx context.Context, transactionID string) (*domain.Payment, error) {
+	for _, p := range r.payments {
+		if p.TransactionID == transactionID {
+			copied := *p
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrPaymentNotFound
+}
+
+func (r *fakePaymentRepo) Update(ctx context.Context, p *domain.Payment) error {
+	copied := *p
+	r.payments[p.ID] = &copied
+	return nil
+}
+
+type fakeBookingRepo struct {
+	repository.BookingRepository
+	bookings map[int]*domain.Booking
+}
+
+func (r *fakeBookingRepo) FindByIDForUpdate(ctx context.Context, id int) (*domain.Booking, error) {
+	b, ok := r.bookings[id]
+	if !ok {
+		return nil, domain.ErrBookingNotFound
+	}
+	copied := *b
+	return &copied, nil
+}
+
+func (r *fakeBookingRepo) UpdateStatus(ctx context.Context, id int, from, to domain.BookingStatus) error {
+	b, ok := r.bookings[id]
+	if !ok || b.Status != from {
+		return domain.ErrBookingNotFound
+	}
+	b.Status = to
+	return nil
+}
+
+type fakeBookingHistoryRepo struct {
+	repository.BookingHistoryRepository
+	changes []*domain.BookingStatusChange
+}
+
+func (r *fakeBookingHistoryRepo) Create(ctx context.Context, change *domain.BookingStatusChange) error {
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+type fakePaymentHistoryRepo struct {
+	changes []*domain.PaymentStatusChange
+}
+
+func (r *fakePaymentHistoryRepo) Create(ctx context.Context, change *domain.PaymentStatusChange) error {
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+type fakeRefundRepo struct {
+	repository.RefundRepository
+	refunds []*domain.Refund
+}
+
+func (r *fakeRefundRepo) Create(ctx context.Context, refund *domain.Refund) error {
+	refund.ID = len(r.refunds) + 1
+	r.refunds = append(r.refunds, refund)
+	return nil
+}
+
+func (r *fakeRefundRepo) Update(ctx context.Context, refund *domain.Refund) error {
+	return nil
+}
+
+func (r *fakeRefundRepo) FindByBookingID(ctx context.Context, bookingID int) ([]*domain.Refund, error) {
+	refunds := []*domain.Refund{}
+	for _, refund := range r.refunds {
+		if refund.BookingID == bookingID {
+			refunds = append(refunds, refund)
+		}
+	}
+	return refunds, nil
+}
+
+func (r *fakeRefundRepo) TotalSucceededByPaymentID(ctx context.Context, paymentID int) (int, error) {
+	total := 0
+	for _, refund := range r.refunds {
+		if refund.PaymentID == paymentID && refund.TransactionID == "" && refund.IsSuccess() {
+			total += refund.Amount
+		}
+	}
+	return total, nil
+}
+
+type fakePromoRepo struct {
+	repository.PromoRepository
+	released []int
+}
+
+func (r *fakePromoRepo) ReleaseRedemption(ctx context.Context, bookingID int, now time.Time) error {
+	r.released = append(r.released, bookingID)
+	return nil
+}
+
+type fakeAdjustmentRepo struct {
+	repository.PaymentAdjustmentRepository
+	adjustments []*domain.PaymentAdjustment
+}
+
+func (r *fakeAdjustmentRepo) Create(ctx context.Context, a *domain.PaymentAdjustment) error {
+	a.ID = len(r.adjustments) + 1
+	r.adjustments = append(r.adjustments, a)
+	return nil
+}
+
+func (r *fakeAdjustmentRepo) FindByBookingID(ctx context.Context, bookingID int) ([]*domain.PaymentAdjustment, error) {
+	adjustments := []*domain.PaymentAdjustment{}
+	for _, a := range r.adjustments {
+		if a.BookingID == bookingID {
+			adjustments = append(adjustments, a)
+		}
+	}
+	return adjustments, nil
+}
+
+func (r *fakeAdjustmentRepo) FindByTransactionIDForUpdate(ctx context.Context, transactionID string) (*domain.PaymentAdjustment, error) {
+	for _, a := range r.adjustments {
+		if a.TransactionID == transactionID {
+			return a, nil
+		}
+	}
+	return nil, domain.ErrPaymentNotFound
+}
+
+func (r *fakeAdjustmentRepo) Update(ctx context.Context, a *domain.PaymentAdjustment) error {
+	return nil
+}
+
+func (r *fakeAdjustmentRepo) UpdatePaymentURL(ctx context.Context, id int, paymentURL string) error {
+	for _, a := range r.adjustments {
+		if a.ID == id {
+			a.PaymentURL = paymentURL
+			return nil
+		}
+	}
+	return domain.ErrPaymentNotFound
+}
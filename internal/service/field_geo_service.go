@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+)
+
+const (
+	// DefaultGeoLimit dipakai jika limit query peta tidak diisi.
+	DefaultGeoLimit = 50
+	// MaxGeoLimit membatasi jumlah lapangan dalam satu query peta.
+	MaxGeoLimit = 200
+	// MaxGeoRadiusKm membatasi radius pencarian lapangan terdekat.
+	MaxGeoRadiusKm = 100
+)
+
+// FindFieldsNear mencari lapangan terdekat dari center
+// Business logic:
+// 1. Radius harus positif dan paling besar MaxGeoRadiusKm
+// 2. Limit 0 berarti DefaultGeoLimit, paling besar MaxGeoLimit
+// 3. Hanya lapangan yang venue-nya punya koordinat yang ikut
+// 4. Hasil urut dari yang terdekat
+func (u *fieldService) FindFieldsNear(ctx context.Context, center domain.GeoPoint, radiusKm float64, limit int) ([]domain.FieldDistance, error) {
+	if !center.IsValid() {
+		return nil, domain.Invalidf("invalid coordinates")
+	}
+
+	if radiusKm <= 0 || radiusKm > MaxGeoRadiusKm {
+		return nil, domain.Invalidf("radius must be between 0 and %d km", MaxGeoRadiusKm)
+	}
+
+	limit, err := geoLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := u.fieldRepo.FindWithinRadius(ctx, center, radiusKm, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching nearby fields: %w", err)
+	}
+
+	return fields, nil
+}
+
+// FindFieldsInBounds mengambil lapangan di dalam area peta yang sedang
+// ditampilkan client. Limit mengikuti aturan FindFieldsNear.
+func (u *fieldService) FindFieldsInBounds(ctx context.Context, box domain.BoundingBox, limit int) ([]*domain.Field, error) {
+	if !box.IsValid() {
+		return nil, domain.Invalidf("invalid bounding box")
+	}
+
+	limit, err := geoLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := u.fieldRepo.FindInBoundingBox(ctx, box, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching fields in bounding box: %w", err)
+	}
+
+	return fields, nil
+}
+
+func geoLimit(limit int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, domain.Invalidf("limit cannot be negative")
+	case limit == 0:
+		return DefaultGeoLimit, nil
+	case limit > MaxGeoLimit:
+		return 0, domain.Invalidf("limit cannot exceed %d", MaxGeoLimit)
+	}
+
+	return limit, nil
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSearchPageSize dipakai jika PageSize tidak diisi.
+	DefaultSearchPageSize = 20
+	// MaxSearchPageSize membatasi jumlah hasil per halaman.
+	MaxSearchPageSize = 100
+)
+
+type FieldSearchSort string
+
+const (
+	SearchSortDistance FieldSearchSort = "distance"
+	SearchSortPrice    FieldSearchSort = "price"
+	SearchSortName     FieldSearchSort = "name"
+)
+
+// FieldSearchInput berisi filter pencarian lapangan. Semua filter opsional.
+type FieldSearchInput struct {
+	// Start dan DurationMinutes memilih rentang yang harus bisa dibooking.
+	// Start nol berarti tanpa filter waktu.
+	Start           time.Time
+	DurationMinutes int
+	// MinPrice dan MaxPrice adalah batas harga per jam; 0 berarti tanpa batas.
+	// Jika Start diisi, harga per jam dihitung dari pricing rule pada rentang
+	// tersebut, bukan tarif dasar lapangan.
+	MinPrice int
+	MaxPrice int
+	// Amenities harus dimiliki semua oleh venue lapangan.
+	Amenities []string
+	// Near adalah posisi customer. RadiusKm 0 berarti tanpa batas jarak, dan
+	// lapangan tanpa koordinat tetap ikut dengan jarak kosong.
+	Near     *domain.GeoPoint
+	RadiusKm float64
+	// Sort kosong berarti distance jika Near diisi, selain itu price.
+	Sort     FieldSearchSort
+	Page     int
+	PageSize int
+}
+
+func (in *FieldSearchInput) normalize() error {
+	if !in.Start.IsZero() && in.DurationMinutes <= 0 {
+		return domain.Invalidf("duration must be positive")
+	}
+
+	if in.MinPrice < 0 || in.MaxPrice < 0 {
+		return domain.Invalidf("price range cannot be negative")
+	}
+
+	if in.MaxPrice > 0 && in.MinPrice > in.MaxPrice {
+		return domain.Invalidf("min price cannot exceed max price")
+	}
+
+	if in.Near != nil && !in.Near.IsValid() {
+		return domain.Invalidf("invalid coordinates")
+	}
+
+	if in.RadiusKm < 0 {
+		return domain.Invalidf("radius cannot be negative")
+	}
+
+	if in.RadiusKm > 0 && in.Near == nil {
+		return domain.Invalidf("radius requires coordinates")
+	}
+
+	switch in.Sort {
+	case "":
+		in.Sort = SearchSortPrice
+		if in.Near != nil {
+			in.Sort = SearchSortDistance
+		}
+	case SearchSortDistance:
+		if in.Near == nil {
+			return domain.Invalidf("sorting by distance requires coordinates")
+		}
+	case SearchSortPrice, SearchSortName:
+	default:
+		return domain.Invalidf("sort must be distance, price, or name")
+	}
+
+	if in.Page <= 0 {
+		in.Page = 1
+	}
+
+	if in.PageSize <= 0 {
+		in.PageSize = DefaultSearchPageSize
+	}
+
+	if in.PageSize > MaxSearchPageSize {
+		return domain.Invalidf("page size cannot exceed %d", MaxSearchPageSize)
+	}
+
+	return nil
+}
+
+// FieldSearchResult adalah satu lapangan yang lolos filter pencarian.
+type FieldSearchResult struct {
+	Field *domain.Field
+	// DistanceKm kosong jika Near tidak diisi atau venue belum punya koordinat.
+	DistanceKm *float64
+	// PricePerHour adalah harga per jam yang dipakai untuk filter dan sort.
+	PricePerHour int
+	// Price adalah total harga rentang waktu yang dicari; 0 tanpa filter waktu.
+	Price int
+}
+
+// FieldSearchPage adalah satu halaman hasil pencarian beserta jumlah total
+// lapangan yang lolos filter.
+type FieldSearchPage struct {
+	Results  []FieldSearchResult
+	Total    int
+	Page     int
+	PageSize int
+}
+
+// SearchFields mencari lapangan berdasarkan waktu, harga, fasilitas, dan jarak
+// Business logic:
+// 1. Jika RadiusKm diisi, kandidat diambil dengan query radius di database; filter fasilitas diterapkan lebih dulu di memori
+// 2. Jika Start diisi, lapangan harus menerima durasi dan jam mulai tersebut (SlotPolicy), buka menurut jadwal dan exception, dan tidak bentrok dengan booking atau penawaran waitlist (termasuk buffer)
+// 3. Ketersediaan semua kandidat dihitung dari satu query rentang yang ditahan
+// 4. Harga per jam dihitung dari pricing rule pada rentang tersebut, lalu difilter dengan MinPrice/MaxPrice
+// 5. Hasil diurutkan (distance, price, atau name) lalu dipotong per halaman
+func (u *fieldService) SearchFields(ctx context.Context, input FieldSearchInput) (*FieldSearchPage, error) {
+	if err := input.normalize(); err != nil {
+		return nil, err
+	}
+
+	fields, err := u.searchSource(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]FieldSearchResult, 0, len(fields))
+	for _, result := range fields {
+		if hasAmenities(result.Field, input.Amenities) {
+			candidates = append(candidates, result)
+		}
+	}
+
+	if !input.Start.IsZero() {
+		candidates, err = u.availableCandidates(ctx, candidates, input.Start, input.DurationMinutes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]FieldSearchResult, 0, len(candidates))
+	for _, result := range candidates {
+		if result.PricePerHour < input.MinPrice || (input.MaxPrice > 0 && result.PricePerHour > input.MaxPrice) {
+			continue
+		}
+		results = append(results, result)
+	}
+
+	sortSearchResults(results, input.Sort)
+
+	page := &FieldSearchPage{Results: []FieldSearchResult{}, Total: len(results), Page: input.Page, PageSize: input.PageSize}
+
+	offset := (input.Page - 1) * input.PageSize
+	if offset < len(results) {
+		end := offset + input.PageSize
+		if end > len(results) {
+			end = len(results)
+		}
+		page.Results = results[offset:end]
+	}
+
+	return page, nil
+}
+
+// searchSource mengambil semua lapangan calon hasil pencarian beserta jaraknya
+// dari Near. Jika RadiusKm diisi, hanya lapangan di dalam radius yang diambil.
+func (u *fieldService) searchSource(ctx context.Context, input FieldSearchInput) ([]FieldSearchResult, error) {
+	if input.RadiusKm > 0 {
+		nearby, err := u.fieldRepo.FindWithinRadius(ctx, *input.Near, input.RadiusKm, 0)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching nearby fields: %w", err)
+		}
+
+		results := make([]FieldSearchResult, 0, len(nearby))
+		for _, item := range nearby {
+			distance := item.DistanceKm
+			results = append(results, FieldSearchResult{Field: item.Field, DistanceKm: &distance, PricePerHour: item.Field.PricePerHour})
+		}
+
+		return results, nil
+	}
+
+	fields, err := u.fieldRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching fields: %w", err)
+	}
+
+	results := make([]FieldSearchResult, 0, len(fields))
+	for _, field := range fields {
+		result := FieldSearchResult{Field: field, PricePerHour: field.PricePerHour}
+
+		if input.Near != nil && field.Coordinates != nil {
+			distance := input.Near.DistanceKm(*field.Coordinates)
+			result.DistanceKm = &distance
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// availableCandidates menyaring kandidat yang bisa dibooking pada
+// [start, start+durationMinutes) dan mengisi harganya untuk rentang tersebut.
+func (u *fieldService) availableCandidates(ctx context.Context, candidates []FieldSearchResult, start time.Time, durationMinutes int) ([]FieldSearchResult, error) {
+	fields := make([]*domain.Field, 0, len(candidates))
+	for _, result := range candidates {
+		if validateDuration(result.Field, start, durationMinutes) == nil {
+			fields = append(fields, result.Field)
+		}
+	}
+
+	// Rentang kalender dimulai sehari lebih awal karena tanggal start bisa
+	// berbeda dengan tanggal lokal lapangan di zona waktu lain.
+	calendars, err := u.loadCalendars(ctx, fields, start.AddDate(0, 0, -1), 2)
+	if err != nil {
+		return nil, err
+	}
+
+	end := start.Add(time.Duration(durationMinutes) * time.Minute)
+	byField := map[int]*domain.FieldCalendar{}
+	for _, calendar := range calendars {
+		byField[calendar.Field.ID] = calendar
+	}
+
+	available := make([]FieldSearchResult, 0, len(calendars))
+	for _, result := range candidates {
+		calendar, ok := byField[result.Field.ID]
+		if !ok || !calendar.CanBook(start, end) {
+			continue
+		}
+
+		result.Price = domain.QuotePrice(result.Field, calendar.Rules, start, end).Total
+		result.PricePerHour = result.Price * 60 / durationMinutes
+		available = append(available, result)
+	}
+
+	return available, nil
+}
+
+// hasAmenities mengecek apakah venue lapangan punya semua fasilitas yang
+// diminta, tanpa membedakan huruf besar/kecil.
+func hasAmenities(field *domain.Field, amenities []string) bool {
+	owned := map[string]bool{}
+	for _, amenity := range field.Amenities {
+		owned[strings.ToLower(amenity)] = true
+	}
+
+	for _, amenity := range amenities {
+		if amenity = strings.ToLower(strings.TrimSpace(amenity)); amenity != "" && !owned[amenity] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortSearchResults mengurutkan hasil secara stabil dengan ID lapangan sebagai
+// penentu terakhir supaya pagination konsisten. Lapangan tanpa jarak
+// ditempatkan di akhir saat diurutkan berdasarkan distance.
+func sortSearchResults(results []FieldSearchResult, by FieldSearchSort) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+
+		switch by {
+		case SearchSortDistance:
+			if (a.DistanceKm == nil) != (b.DistanceKm == nil) {
+				return a.DistanceKm != nil
+			}
+			if a.DistanceKm != nil && *a.DistanceKm != *b.DistanceKm {
+				return *a.DistanceKm < *b.DistanceKm
+			}
+		case SearchSortPrice:
+			if a.PricePerHour != b.PricePerHour {
+				return a.PricePerHour < b.PricePerHour
+			}
+		case SearchSortName:
+			if nameA, nameB := strings.ToLower(a.Field.Name), strings.ToLower(b.Field.Name); nameA != nameB {
+				return nameA < nameB
+			}
+		}
+
+		return a.Field.ID < b.Field.ID
+	})
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"testing"
+	"time"
+)
+
+// fakeSearchFieldRepo menyimpan semua lapangan dengan jadwal yang sama.
+type fakeSearchFieldRepo struct {
+	repository.FieldRepository
+	fields    []*domain.Field
+	schedules []*domain.Schedule
+}
+
+func (r *fakeSearchFieldRepo) FindAll(ctx context.Context) ([]*domain.Field, error) {
+	return r.fields, nil
+}
+
+func (r *fakeSearchFieldRepo) FindWithinRadius(ctx context.Context, center domain.GeoPoint, radiusKm float64, limit int) ([]domain.FieldDistance, error) {
+	result := []domain.FieldDistance{}
+	for _, field := range r.fields {
+		if field.Coordinates == nil {
+			continue
+		}
+		if distance := center.DistanceKm(*field.Coordinates); distance <= radiusKm {
+			result = append(result, domain.FieldDistance{Field: field, DistanceKm: distance})
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeSearchFieldRepo) FindScheduleByFieldID(ctx context.Context, fieldID int) ([]*domain.Schedule, error) {
+	return r.schedules, nil
+}
+
+func (r *fakeSearchFieldRepo) FindScheduleExceptions(ctx context.Context, fieldID int, from, to time.Time) ([]*domain.ScheduleException, error) {
+	return nil, nil
+}
+
+func (r *fakeSearchFieldRepo) FindPricingRulesByFieldID(ctx context.Context, fieldID int) ([]*domain.PricingRule, error) {
+	return nil, nil
+}
+
+type fakeBlockedRangeRepo struct {
+	repository.BookingRepository
+	blocked []domain.BlockedRange
+}
+
+func (r *fakeBlockedRangeRepo) FindBlockedRanges(ctx context.Context, fieldIDs []int, startTime, endTime time.Time) ([]domain.BlockedRange, error) {
+	return r.blocked, nil
+}
+
+func TestSearchFields(t *testing.T) {
+	// Jumat 2026-03-06
+	day := func(hour int) time.Time { return time.Date(2026, 3, 6, hour, 0, 0, 0, time.UTC) }
+	near := &domain.GeoPoint{Latitude: -6.2, Longitude: 106.8}
+
+	fields := []*domain.Field{
+		{ID: 1, Name: "Arena A", PricePerHour: 150000, TimeZone: "UTC", SlotPolicy: domain.DefaultSlotPolicy,
+			Amenities: []string{"parking"}, Coordinates: &domain.GeoPoint{Latitude: -6.201, Longitude: 106.801}},
+		{ID: 2, Name: "Bola B", PricePerHour: 100000, TimeZone: "UTC", SlotPolicy: domain.DefaultSlotPolicy,
+			Amenities: []string{"parking", "shower"}, Coordinates: &domain.GeoPoint{Latitude: -6.2, Longitude: 106.845}},
+		{ID: 3, Name: "court C", PricePerHour: 120000, TimeZone: "UTC", SlotPolicy: domain.DefaultSlotPolicy},
+	}
+
+	svc := &fieldService{
+		fieldRepo: &fakeSearchFieldRepo{fields: fields, schedules: []*domain.Schedule{{
+			DayOfWeek: domain.Friday,
+			OpenTime:  time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC),
+			CloseTime: time.Date(0, 1, 1, 22, 0, 0, 0, time.UTC),
+		}}},
+		bookingRepo: &fakeBlockedRangeRepo{blocked: []domain.BlockedRange{{FieldID: 2, Start: day(19), End: day(20)}}},
+	}
+
+	tests := []struct {
+		name      string
+		input     FieldSearchInput
+		wantIDs   []int
+		wantTotal int
+	}{
+		{"default sort by price", FieldSearchInput{}, []int{2, 3, 1}, 3},
+		{"amenities ignore case", FieldSearchInput{Amenities: []string{"Parking"}}, []int{2, 1}, 2},
+		{"max price", FieldSearchInput{MaxPrice: 120000}, []int{2, 3}, 2},
+		{"sort by name", FieldSearchInput{Sort: SearchSortName}, []int{1, 2, 3}, 3},
+		{"near sorts by distance with unknown last", FieldSearchInput{Near: near}, []int{1, 2, 3}, 3},
+		{"radius drops far and unknown venues", FieldSearchInput{Near: near, RadiusKm: 1}, []int{1}, 1},
+		{"start skips booked field", FieldSearchInput{Start: day(19), DurationMinutes: 60}, []int{3, 1}, 2},
+		{"start outside opening hours", FieldSearchInput{Start: day(22), DurationMinutes: 60}, []int{}, 0},
+		{"second page", FieldSearchInput{Page: 2, PageSize: 2}, []int{1}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := svc.SearchFields(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("SearchFields: %v", err)
+			}
+
+			ids := []int{}
+			for _, result := range page.Results {
+				ids = append(ids, result.Field.ID)
+			}
+			if len(ids) != len(tt.wantIDs) || page.Total != tt.wantTotal {
+				t.Fatalf("results = %v (total %d), want %v (total %d)", ids, page.Total, tt.wantIDs, tt.wantTotal)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Fatalf("results = %v, want %v", ids, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchFieldsRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input FieldSearchInput
+	}{
+		{"start without duration", FieldSearchInput{Start: time.Now()}},
+		{"min price above max price", FieldSearchInput{MinPrice: 200000, MaxPrice: 100000}},
+		{"radius without coordinates", FieldSearchInput{RadiusKm: 5}},
+		{"distance sort without coordinates", FieldSearchInput{Sort: SearchSortDistance}},
+		{"unknown sort", FieldSearchInput{Sort: "rating"}},
+		{"page size too large", FieldSearchInput{PageSize: MaxSearchPageSize + 1}},
+	}
+
+	svc := &fieldService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SearchFields(context.Background(), tt.input)
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("SearchFields error = %v, want validation error", err)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"futsal-booking-app/internal/authz"
 	"futsal-booking-app/internal/domain"
 	"futsal-booking-app/internal/repository"
 	"strings"
@@ -9,17 +11,207 @@ import (
 )
 
 type FieldService interface {
-	CreateField(ownerID int, name, address, description, imageURL string, pricePerHour int) (*domain.Field, error)
-	GetFieldByID(id int) (*domain.Field, error)
-	GetAllFields() ([]*domain.Field, error)
-	GetFieldsByOwnerID(ownerID int) ([]*domain.Field, error)
-	UpdateField(fieldID, ownerID int, name, address, description, imageURL string, pricePerHour int) (*domain.Field, error)
-	DeleteField(fieldID, ownerID int) error
+	CreateField(ctx context.Context, actor *domain.User, input FieldInput) (*domain.Field, error)
+	GetFieldByID(ctx context.Context, id int) (*domain.Field, error)
+	GetAllFields(ctx context.Context) ([]*domain.Field, error)
+	GetFieldsByOwnerID(ctx context.Context, ownerID int) ([]*domain.Field, error)
+	UpdateField(ctx context.Context, actor *domain.User, fieldID int, input FieldInput) (*domain.Field, error)
+	DeleteField(ctx context.Context, actor *domain.User, fieldID int) error
+
+	SetupSchedules(ctx context.Context, actor *domain.User, fieldID int, schedules []ScheduleInput) error
+	GetScheduleByFieldID(ctx context.Context, fieldID int) ([]*domain.Schedule, error)
+
+	AddScheduleException(ctx context.Context, actor *domain.User, fieldID int, input ScheduleExceptionInput) (*ScheduleExceptionResult, error)
+	GetScheduleExceptions(ctx context.Context, fieldID int, from, to time.Time) ([]*domain.ScheduleException, error)
+	DeleteScheduleException(ctx context.Context, actor *domain.User, fieldID, exceptionID int) error
+	GetScheduleExceptionConflicts(ctx context.Context, actor *domain.User, fieldID, exceptionID int) ([]*domain.Booking, error)
+
+	SetPricingRules(ctx context.Context, actor *domain.User, fieldID int, rules []PricingRuleInput) ([]*domain.PricingRule, error)
+	GetPricingRules(ctx context.Context, fieldID int) ([]*domain.PricingRule, error)
+
+	FindAvailableSlots(ctx context.Context, fieldID int, date time.Time) ([]domain.TimeSlot, error)
+	GetAvailabilityCalendar(ctx context.Context, input CalendarInput) ([]FieldAvailability, error)
+	SearchFields(ctx context.Context, input FieldSearchInput) (*FieldSearchPage, error)
+
+	FindFieldsNear(ctx context.Context, center domain.GeoPoint, radiusKm float64, limit int) ([]domain.FieldDistance, error)
+	FindFieldsInBounds(ctx context.Context, box domain.BoundingBox, limit int) ([]*domain.Field, error)
+}
+
+// FieldInput berisi data court yang bisa diatur owner saat create/update.
+type FieldInput struct {
+	// VenueID adalah venue tempat court berada. Saat create, nilai 0 membuat
+	// venue baru berisi satu court dari Name, Address, dan ImageURL. Saat
+	// update, nilai 0 berarti venue tidak berubah dan Address serta ImageURL
+	// diabaikan karena keduanya diubah lewat venue.
+	VenueID      int
+	Name         string
+	Address      string
+	Description  string
+	ImageURL     string
+	PricePerHour int
+	// SurfaceType kosong berarti memakai domain.DefaultSurfaceType saat create
+	// dan tidak berubah saat update.
+	SurfaceType domain.SurfaceType
+	Indoor      bool
+	// TimeZone hanya dipakai saat create membuat venue baru (VenueID 0).
+	// Kosong berarti memakai domain.DefaultTimeZone.
+	TimeZone string
+	// Coordinates adalah titik peta court. Koordinat disimpan di venue, jadi
+	// nilai ini ikut memindahkan semua court di venue yang sama. Nil berarti
+	// tidak berubah.
+	Coordinates *domain.GeoPoint
+
+	// PaymentHoldMinutes adalah lama slot ditahan menunggu pembayaran.
+	// Nilai 0 berarti memakai domain.DefaultPaymentHoldMinutes saat create dan
+	// tidak berubah saat update.
+	PaymentHoldMinutes int
+
+	// CancellationPolicy menentukan besar refund saat booking dibatalkan.
+	// Nil berarti memakai domain.DefaultCancellationPolicy saat create dan tidak
+	// berubah saat update.
+	CancellationPolicy *domain.CancellationPolicy
+
+	// WaitlistPolicy mengatur antrean slot penuh.
+	// Nil berarti memakai domain.DefaultWaitlistPolicy saat create dan tidak
+	// berubah saat update.
+	WaitlistPolicy *domain.WaitlistPolicy
+
+	// ReschedulePolicy membatasi kapan dan berapa kali booking boleh dipindah.
+	// Nil berarti memakai domain.DefaultReschedulePolicy saat create dan tidak
+	// berubah saat update.
+	ReschedulePolicy *domain.ReschedulePolicy
+
+	// SlotPolicy mengatur granularitas slot, batas durasi, dan buffer antar booking.
+	// Nil berarti memakai domain.DefaultSlotPolicy saat create dan tidak
+	// berubah saat update.
+	SlotPolicy *domain.SlotPolicy
+}
+
+func (in FieldInput) validate() error {
+	if strings.TrimSpace(in.Name) == "" {
+		return domain.Invalidf("field name cannot be empty")
+	}
+
+	if in.PricePerHour <= 0 {
+		return domain.Invalidf("price per hour must be positive")
+	}
+
+	if in.SurfaceType != "" && !in.SurfaceType.IsValid() {
+		return domain.Invalidf("invalid surface type: %s", in.SurfaceType)
+	}
+
+	if err := validateTimeZone(in.TimeZone); err != nil {
+		return err
+	}
+
+	if in.Coordinates != nil && !in.Coordinates.IsValid() {
+		return domain.Invalidf("invalid coordinates")
+	}
+
+	if in.PaymentHoldMinutes < 0 {
+		return domain.Invalidf("payment hold minutes cannot be negative")
+	}
+
+	if policy := in.CancellationPolicy; policy != nil {
+		if time.Duration(policy.FullRefundHours)*time.Hour < domain.MinCancellationNotice {
+			return domain.Invalidf("full refund hours must be at least %d, the minimum cancellation notice", int(domain.MinCancellationNotice/time.Hour))
+		}
+
+		if policy.PartialRefundPercent < 0 || policy.PartialRefundPercent > 100 {
+			return domain.Invalidf("partial refund percent must be between 0 and 100")
+		}
+	}
+
+	if policy := in.WaitlistPolicy; policy != nil {
+		if policy.Order != domain.WaitlistFIFO && policy.Order != domain.WaitlistLottery {
+			return domain.Invalidf("waitlist order must be FIFO or LOTTERY")
+		}
+
+		if policy.OfferMinutes <= 0 {
+			return domain.Invalidf("waitlist offer minutes must be positive")
+		}
+	}
+
+	if policy := in.ReschedulePolicy; policy != nil {
+		if policy.MinHoursBefore < 0 {
+			return domain.Invalidf("reschedule min hours before cannot be negative")
+		}
+
+		if policy.MaxReschedules < 0 {
+			return domain.Invalidf("max reschedules cannot be negative")
+		}
+	}
 
-	SetupSchedules(fieldID, ownerID int, schedules []ScheduleInput) error
-	GetScheduleByFieldID(fieldID int) ([]*domain.Schedule, error)
+	if policy := in.SlotPolicy; policy != nil {
+		if policy.SlotMinutes <= 0 || 24*60%policy.SlotMinutes != 0 {
+			return domain.Invalidf("slot minutes must be positive and divide a day evenly")
+		}
 
-	FindAvailableSlots(fieldID int, date time.Time) ([]TimeSlot, error)
+		if policy.MinDurationMinutes <= 0 || policy.MinDurationMinutes%policy.SlotMinutes != 0 {
+			return domain.Invalidf("min duration minutes must be a positive multiple of slot minutes")
+		}
+
+		if policy.MaxDurationMinutes != 0 {
+			if policy.MaxDurationMinutes%policy.SlotMinutes != 0 {
+				return domain.Invalidf("max duration minutes must be a multiple of slot minutes")
+			}
+
+			if policy.MaxDurationMinutes < policy.MinDurationMinutes {
+				return domain.Invalidf("max duration minutes cannot be less than min duration minutes")
+			}
+		}
+
+		if policy.BufferMinutes < 0 {
+			return domain.Invalidf("buffer minutes cannot be negative")
+		}
+	}
+
+	return nil
+}
+
+// newField membuat court dengan kebijakan default; applyTo kemudian hanya
+// menimpa nilai yang diisi owner.
+func newField(now time.Time) *domain.Field {
+	return &domain.Field{
+		SurfaceType:        domain.DefaultSurfaceType,
+		PaymentHoldMinutes: domain.DefaultPaymentHoldMinutes,
+		CancellationPolicy: domain.DefaultCancellationPolicy,
+		WaitlistPolicy:     domain.DefaultWaitlistPolicy,
+		ReschedulePolicy:   domain.DefaultReschedulePolicy,
+		SlotPolicy:         domain.DefaultSlotPolicy,
+		CreatedAt:          now,
+	}
+}
+
+func (in FieldInput) applyTo(field *domain.Field) {
+	field.Name = in.Name
+	field.Description = in.Description
+	field.PricePerHour = in.PricePerHour
+	field.Indoor = in.Indoor
+
+	if in.SurfaceType != "" {
+		field.SurfaceType = in.SurfaceType
+	}
+
+	if in.PaymentHoldMinutes != 0 {
+		field.PaymentHoldMinutes = in.PaymentHoldMinutes
+	}
+
+	if in.CancellationPolicy != nil {
+		field.CancellationPolicy = *in.CancellationPolicy
+	}
+
+	if in.WaitlistPolicy != nil {
+		field.WaitlistPolicy = *in.WaitlistPolicy
+	}
+
+	if in.ReschedulePolicy != nil {
+		field.ReschedulePolicy = *in.ReschedulePolicy
+	}
+
+	if in.SlotPolicy != nil {
+		field.SlotPolicy = *in.SlotPolicy
+	}
 }
 
 type ScheduleInput struct {
@@ -28,65 +220,125 @@ type ScheduleInput struct {
 	CloseTime string
 }
 
-type TimeSlot struct {
-	StartTime time.Time
-	EndTime   time.Time
-	Available bool
-}
-
 type fieldService struct {
+	uow         repository.UnitOfWork
 	fieldRepo   repository.FieldRepository
+	venueRepo   repository.VenueRepository
 	bookingRepo repository.BookingRepository
+	policy      *authz.Policy
 }
 
-func NewFieldService(fieldRepo repository.FieldRepository, bookingRepo repository.BookingRepository) FieldService {
-	return &fieldService{fieldRepo: fieldRepo, bookingRepo: bookingRepo}
+func NewFieldService(uow repository.UnitOfWork, fieldRepo repository.FieldRepository, venueRepo repository.VenueRepository, bookingRepo repository.BookingRepository, policy *authz.Policy) FieldService {
+	return &fieldService{uow: uow, fieldRepo: fieldRepo, venueRepo: venueRepo, bookingRepo: bookingRepo, policy: policy}
 }
 
-// CreateField membuat lapangan baru
+// CreateField membuat court baru
 // Business logic:
-// 1. Validasi input (name, address tidak boleh kosong, price harus positif)
-// 2. Simpan field ke database
-func (u *fieldService) CreateField(ownerID int, name, address, description, imageURL string, pricePerHour int) (*domain.Field, error) {
-	if ownerID <= 0 {
-		return nil, fmt.Errorf("invalid owner id")
+// 1. Validasi input (name tidak boleh kosong, price harus positif)
+// 2. Jika VenueID diisi, hanya owner venue (atau admin) yang boleh menambah court di venue tersebut
+// 3. Jika VenueID kosong, venue baru berisi satu court dibuat dari name, address, dan image_url milik actor
+// 4. Jika Coordinates diisi, koordinat venue diperbarui
+// 5. Jam buka default venue disalin menjadi jadwal court
+// 6. Venue, court, dan jadwal disimpan dalam satu transaksi
+func (u *fieldService) CreateField(ctx context.Context, actor *domain.User, input FieldInput) (*domain.Field, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	field := newField(now)
+	input.applyTo(field)
+
+	err := u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		venue, err := u.courtVenue(ctx, repos, actor, input, now)
+		if err != nil {
+			return err
+		}
+
+		if err := u.policy.Authorize(actor, authz.ActionFieldCreate, authz.Resource{Venue: venue}); err != nil {
+			return err
+		}
+
+		if err := setVenueCoordinates(ctx, repos, venue, input.Coordinates); err != nil {
+			return err
+		}
+
+		field.VenueID = venue.ID
+		field.OwnerID = venue.OwnerID
+		field.Address = venue.Address
+		field.ImageURL = venue.ImageURL
+		field.TimeZone = venue.TimeZone
+		field.Amenities = venue.Amenities
+		field.Coordinates = venue.Coordinates
+
+		if err := repos.Fields.Create(ctx, field); err != nil {
+			return fmt.Errorf("error creating field: %w", err)
+		}
+
+		defaults, err := repos.Venues.FindSchedulesByVenueID(ctx, venue.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, schedule := range defaults {
+			schedule.FieldID = field.ID
+			if err := repos.Fields.CreateSchedule(ctx, schedule); err != nil {
+				return fmt.Errorf("error creating schedule: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if strings.TrimSpace(name) == "" {
-		return nil, fmt.Errorf("field name cannot be empty")
+	return field, nil
+}
+
+// courtVenue mengambil venue tujuan court baru, atau membuat venue berisi
+// satu court untuk client yang belum mengenal venue.
+func (u *fieldService) courtVenue(ctx context.Context, repos *repository.Repositories, actor *domain.User, input FieldInput, now time.Time) (*domain.Venue, error) {
+	if input.VenueID != 0 {
+		return repos.Venues.FindByID(ctx, input.VenueID)
 	}
 
-	if strings.TrimSpace(address) == "" {
-		return nil, fmt.Errorf("field address cannot be empty")
+	if err := u.policy.Authorize(actor, authz.ActionVenueCreate, authz.Resource{}); err != nil {
+		return nil, err
 	}
 
-	if pricePerHour <= 0 {
-		return nil, fmt.Errorf("price per hour must be positive")
+	if strings.TrimSpace(input.Address) == "" {
+		return nil, domain.Invalidf("field address cannot be empty")
 	}
 
-	field := &domain.Field{
-		OwnerID:      ownerID,
-		Name:         name,
-		Address:      address,
-		Description:  description,
-		PricePerHour: pricePerHour,
-		ImageURL:     imageURL,
-		CreatedAt:    time.Now(),
+	venue := &domain.Venue{
+		OwnerID:     actor.ID,
+		Name:        input.Name,
+		Address:     input.Address,
+		Description: input.Description,
+		ImageURL:    input.ImageURL,
+		Amenities:   []string{},
+		TimeZone:    input.TimeZone,
+		Coordinates: input.Coordinates,
+		CreatedAt:   now,
+	}
+	if venue.TimeZone == "" {
+		venue.TimeZone = domain.DefaultTimeZone
 	}
 
-	if err := u.fieldRepo.Create(field); err != nil {
-		return nil, fmt.Errorf("error creating field: %w", err)
+	if err := repos.Venues.Create(ctx, venue); err != nil {
+		return nil, err
 	}
 
-	return field, nil
+	return venue, nil
 }
 
-func (u *fieldService) GetFieldByID(id int) (*domain.Field, error) {
+func (u *fieldService) GetFieldByID(ctx context.Context, id int) (*domain.Field, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("invalid field ID")
+		return nil, domain.Invalidf("invalid field ID")
 	}
 
-	field, err := u.fieldRepo.FindByID(id)
+	field, err := u.fieldRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching fields: %w", err)
 	}
@@ -94,8 +346,8 @@ func (u *fieldService) GetFieldByID(id int) (*domain.Field, error) {
 	return field, nil
 }
 
-func (u *fieldService) GetAllFields() ([]*domain.Field, error) {
-	fields, err := u.fieldRepo.FindAll()
+func (u *fieldService) GetAllFields(ctx context.Context) ([]*domain.Field, error) {
+	fields, err := u.fieldRepo.FindAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching fields: %w", err)
 	}
@@ -103,12 +355,12 @@ func (u *fieldService) GetAllFields() ([]*domain.Field, error) {
 	return fields, nil
 }
 
-func (u *fieldService) GetFieldsByOwnerID(ownerID int) ([]*domain.Field, error) {
+func (u *fieldService) GetFieldsByOwnerID(ctx context.Context, ownerID int) ([]*domain.Field, error) {
 	if ownerID == 0 {
-		return nil, fmt.Errorf("invalid owner ID")
+		return nil, domain.Invalidf("invalid owner ID")
 	}
 
-	fields, err := u.fieldRepo.FindByOwnerID(ownerID)
+	fields, err := u.fieldRepo.FindByOwnerID(ctx, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching fields: %w", err)
 	}
@@ -116,128 +368,201 @@ func (u *fieldService) GetFieldsByOwnerID(ownerID int) ([]*domain.Field, error)
 	return fields, nil
 }
 
-func (u *fieldService) UpdateField(fieldID, ownerID int, name, address, description, imagerURL string, pricePerHour int) (*domain.Field, error) {
+func (u *fieldService) UpdateField(ctx context.Context, actor *domain.User, fieldID int, input FieldInput) (*domain.Field, error) {
 	if fieldID <= 0 {
-		return nil, fmt.Errorf("invalid field ID")
+		return nil, domain.Invalidf("invalid field ID")
 	}
 
-	field, err := u.fieldRepo.FindByID(fieldID)
+	field, err := u.fieldRepo.FindByID(ctx, fieldID)
 	if err != nil {
-		return nil, fmt.Errorf("field not found")
+		return nil, domain.ErrFieldNotFound
 	}
 
-	if !field.IsOwnedBy(ownerID) {
-		return nil, fmt.Errorf("unauthorized: you are not the owner of this field")
+	if err := u.policy.Authorize(actor, authz.ActionFieldUpdate, authz.Resource{Field: field}); err != nil {
+		return nil, err
 	}
 
-	if strings.TrimSpace(name) == "" {
-		return nil, fmt.Errorf("field name cannot be empty")
+	if err := input.validate(); err != nil {
+		return nil, err
 	}
 
-	if strings.TrimSpace(address) == "" {
-		return nil, fmt.Errorf("field address cannot be empty")
-	}
+	// Court boleh dipindah ke venue lain milik owner yang sama
+	if input.VenueID != 0 && input.VenueID != field.VenueID {
+		venue, err := u.venueRepo.FindByID(ctx, input.VenueID)
+		if err != nil {
+			return nil, err
+		}
 
-	if pricePerHour <= 0 {
-		return nil, fmt.Errorf("price per hour must be positive")
+		if err := u.policy.Authorize(actor, authz.ActionFieldCreate, authz.Resource{Venue: venue}); err != nil {
+			return nil, err
+		}
+
+		field.VenueID = venue.ID
+		field.OwnerID = venue.OwnerID
+		field.Address = venue.Address
+		field.ImageURL = venue.ImageURL
+		field.TimeZone = venue.TimeZone
+		field.Amenities = venue.Amenities
+		field.Coordinates = venue.Coordinates
 	}
 
-	field.Name = name
-	field.Address = address
-	field.Description = description
-	field.PricePerHour = pricePerHour
-	field.ImageURL = imagerURL
+	input.applyTo(field)
+
+	err = u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		if err := repos.Fields.Update(ctx, field); err != nil {
+			return fmt.Errorf("error updating field: %w", err)
+		}
+
+		if input.Coordinates == nil {
+			return nil
+		}
+
+		venue, err := repos.Venues.FindByID(ctx, field.VenueID)
+		if err != nil {
+			return err
+		}
 
-	if err := u.fieldRepo.Update(field); err != nil {
-		return nil, fmt.Errorf("error updating field: %w", err)
+		field.Coordinates = input.Coordinates
+		return setVenueCoordinates(ctx, repos, venue, input.Coordinates)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return field, nil
 }
 
-func (u *fieldService) DeleteField(fieldID, ownerID int) error {
+// setVenueCoordinates menyimpan koordinat court ke venue-nya. Nil berarti
+// koordinat venue tidak berubah.
+func setVenueCoordinates(ctx context.Context, repos *repository.Repositories, venue *domain.Venue, coordinates *domain.GeoPoint) error {
+	if coordinates == nil {
+		return nil
+	}
+
+	venue.Coordinates = coordinates
+
+	return repos.Venues.Update(ctx, venue)
+}
+
+func (u *fieldService) DeleteField(ctx context.Context, actor *domain.User, fieldID int) error {
 	if fieldID <= 0 {
-		return fmt.Errorf("invalid field ID")
+		return domain.Invalidf("invalid field ID")
 	}
 
-	field, err := u.fieldRepo.FindByID(fieldID)
+	field, err := u.fieldRepo.FindByID(ctx, fieldID)
 	if err != nil {
-		return fmt.Errorf("field not found")
+		return domain.ErrFieldNotFound
 	}
 
-	if !field.IsOwnedBy(ownerID) {
-		return fmt.Errorf("unauthorized: you are not the owner of this field")
+	if err := u.policy.Authorize(actor, authz.ActionFieldDelete, authz.Resource{Field: field}); err != nil {
+		return err
 	}
 
-	if err := u.fieldRepo.Delete(fieldID); err != nil {
+	if err := u.fieldRepo.Delete(ctx, fieldID); err != nil {
 		return fmt.Errorf("error deleting field: %w", err)
 	}
 
 	return nil
 }
 
-func (u *fieldService) SetupSchedules(fieldID, ownerID int, schedules []ScheduleInput) error {
+func (u *fieldService) SetupSchedules(ctx context.Context, actor *domain.User, fieldID int, schedules []ScheduleInput) error {
 	if fieldID <= 0 {
-		return fmt.Errorf("invalid field ID")
+		return domain.Invalidf("invalid field ID")
 	}
 
-	field, err := u.fieldRepo.FindByID(fieldID)
+	field, err := u.fieldRepo.FindByID(ctx, fieldID)
 	if err != nil {
-		return fmt.Errorf("field not found")
+		return domain.ErrFieldNotFound
 	}
 
-	if !field.IsOwnedBy(ownerID) {
-		return fmt.Errorf("unauthorized: you are not the owner of this field")
+	if err := u.policy.Authorize(actor, authz.ActionFieldManageSchedule, authz.Resource{Field: field}); err != nil {
+		return err
 	}
 
-	if len(schedules) == 0 {
-		return fmt.Errorf("at least one schedule is required")
+	newSchedules, err := parseSchedules(schedules)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range newSchedules {
+		schedule.FieldID = fieldID
 	}
 
-	if err := u.fieldRepo.DeleteScheduleByFieldID(fieldID); err != nil {
-		return fmt.Errorf("error deleting old schedules: %w", err)
+	// Jadwal lama dihapus dan jadwal baru disimpan dalam satu transaksi,
+	// sehingga lapangan tidak pernah tertinggal tanpa jadwal jika ada insert yang gagal.
+	return u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		if err := repos.Fields.DeleteScheduleByFieldID(ctx, fieldID); err != nil {
+			return fmt.Errorf("error deleting old schedules: %w", err)
+		}
+
+		for _, schedule := range newSchedules {
+			if err := repos.Fields.CreateSchedule(ctx, schedule); err != nil {
+				return fmt.Errorf("error creating schedule: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// parseSchedules memvalidasi input jadwal mingguan. Satu hari boleh punya
+// beberapa jendela, termasuk yang melewati tengah malam, selama tidak saling
+// beririsan. FieldID hasilnya belum diisi karena dipakai untuk jadwal court
+// maupun jam buka default venue.
+func parseSchedules(schedules []ScheduleInput) ([]*domain.Schedule, error) {
+	if len(schedules) == 0 {
+		return nil, domain.Invalidf("at least one schedule is required")
 	}
 
+	newSchedules := make([]*domain.Schedule, 0, len(schedules))
+
 	for _, input := range schedules {
 		if input.DayOfWeek < 0 || input.DayOfWeek > 6 {
-			return fmt.Errorf("invalid day of week: %d", input.DayOfWeek)
+			return nil, domain.Invalidf("invalid day of week: %d", input.DayOfWeek)
 		}
 
 		openTime, err := time.Parse("15:04", input.OpenTime)
 		if err != nil {
-			return fmt.Errorf("invalid open time format: %s", input.OpenTime)
+			return nil, domain.Invalidf("invalid open time format: %s", input.OpenTime)
 		}
 
 		closeTime, err := time.Parse("15:04", input.CloseTime)
 		if err != nil {
-			return fmt.Errorf("invalid close time format: %s", input.CloseTime)
+			return nil, domain.Invalidf("invalid close time format: %s", input.CloseTime)
 		}
 
-		if closeTime.Before(openTime) || closeTime.Equal(openTime) {
-			return fmt.Errorf("close time must be after open time")
+		// Jam tutup sebelum jam buka berarti jendela berakhir setelah
+		// tengah malam, misalnya 15:00-02:00.
+		if closeTime.Equal(openTime) {
+			return nil, domain.Invalidf("close time must differ from open time")
 		}
 
 		schedule := &domain.Schedule{
-			FieldID:   fieldID,
 			DayOfWeek: domain.DayOfWeek(input.DayOfWeek),
 			OpenTime:  openTime,
 			CloseTime: closeTime,
 		}
 
-		if err := u.fieldRepo.CreateSchedule(schedule); err != nil {
-			return fmt.Errorf("error creating schedule: %w", err)
+		for _, other := range newSchedules {
+			if schedule.Overlaps(other) {
+				return nil, domain.Invalidf("schedule %s %s-%s overlaps %s %s-%s",
+					time.Weekday(schedule.DayOfWeek), input.OpenTime, input.CloseTime,
+					time.Weekday(other.DayOfWeek), other.OpenTime.Format("15:04"), other.CloseTime.Format("15:04"))
+			}
 		}
+
+		newSchedules = append(newSchedules, schedule)
 	}
 
-	return nil
+	return newSchedules, nil
 }
 
-func (u *fieldService) GetScheduleByFieldID(fieldID int) ([]*domain.Schedule, error) {
+func (u *fieldService) GetScheduleByFieldID(ctx context.Context, fieldID int) ([]*domain.Schedule, error) {
 	if fieldID <= 0 {
-		return nil, fmt.Errorf("invalid field ID")
+		return nil, domain.Invalidf("invalid field ID")
 	}
 
-	schedules, err := u.fieldRepo.FindScheduleByFieldID(fieldID)
+	schedules, err := u.fieldRepo.FindScheduleByFieldID(ctx, fieldID)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching schedules: %w", err)
 	}
@@ -245,53 +570,26 @@ func (u *fieldService) GetScheduleByFieldID(fieldID int) ([]*domain.Schedule, er
 	return schedules, nil
 }
 
-func (u *fieldService) FindAvailableSlots(fieldID int, date time.Time) ([]TimeSlot, error) {
+// FindAvailableSlots membagi jam buka lapangan pada tanggal date menjadi slot
+// selebar SlotPolicy.SlotMinutes (lihat domain.FieldCalendar.SlotsOn). Jendela
+// yang melewati tengah malam ditampilkan utuh di tanggal mulainya. Hanya
+// tanggal dari date yang dipakai; slot dibangun di zona waktu lapangan, apa
+// pun zona yang dibawa date. Ketersediaan semua slot dihitung dari satu query
+// rentang yang ditahan booking dan penawaran waitlist.
+func (u *fieldService) FindAvailableSlots(ctx context.Context, fieldID int, date time.Time) ([]domain.TimeSlot, error) {
 	if fieldID <= 0 {
-		return nil, fmt.Errorf("invalid field ID")
+		return nil, domain.Invalidf("invalid field ID")
 	}
 
-	schedules, err := u.fieldRepo.FindScheduleByFieldID(fieldID)
+	field, err := u.fieldRepo.FindByID(ctx, fieldID)
 	if err != nil {
-		return nil, fmt.Errorf("error fecthing schedules: %w", err)
-	}
-
-	dayOfWeek := domain.DayOfWeek(date.Weekday())
-	var relevantSchedule *domain.Schedule
-	for _, schedule := range schedules {
-		if schedule.DayOfWeek == dayOfWeek {
-			relevantSchedule = schedule
-			break
-		}
+		return nil, err
 	}
 
-	if relevantSchedule == nil {
-		return []TimeSlot{}, nil
-	}
-
-	slots := []TimeSlot{}
-
-	openHour, openMin, _ := relevantSchedule.OpenTime.Clock()
-	closeHour, closeMin, _ := relevantSchedule.CloseTime.Clock()
-
-	currentSlot := time.Date(date.Year(), date.Month(), date.Day(), openHour, openMin, 0, 0, date.Location())
-	endOfDay := time.Date(date.Year(), date.Month(), date.Day(), closeHour, closeMin, 0, 0, date.Location())
-
-	for currentSlot.Before(endOfDay) {
-		slotEnd := currentSlot.Add(1 * time.Hour)
-
-		available, err := u.bookingRepo.CheckAvailability(fieldID, currentSlot, slotEnd)
-		if err != nil {
-			return nil, fmt.Errorf("error checking availability: %w", err)
-		}
-
-		slots = append(slots, TimeSlot{
-			StartTime: currentSlot,
-			EndTime:   slotEnd,
-			Available: available,
-		})
-
-		currentSlot = slotEnd
+	calendars, err := u.loadCalendars(ctx, []*domain.Field{field}, date, 1)
+	if err != nil {
+		return nil, err
 	}
 
-	return slots, nil
+	return calendars[0].SlotsOn(date), nil
 }
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"errors"
+	"futsal-booking-app/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestFieldInputApplyTo(t *testing.T) {
+	cancellation := domain.CancellationPolicy{FullRefundHours: 48, PartialRefundPercent: 25}
+	waitlist := domain.WaitlistPolicy{Order: domain.WaitlistLottery, OfferMinutes: 10}
+	reschedule := domain.ReschedulePolicy{MinHoursBefore: 6, MaxReschedules: 3}
+	slot := domain.SlotPolicy{SlotMinutes: 30, MinDurationMinutes: 60, MaxDurationMinutes: 180, BufferMinutes: 15}
+
+	custom := func() *domain.Field {
+		return &domain.Field{
+			SurfaceType:        domain.SurfaceVinyl,
+			PaymentHoldMinutes: 45,
+			CancellationPolicy: cancellation,
+			WaitlistPolicy:     waitlist,
+			ReschedulePolicy:   reschedule,
+			SlotPolicy:         slot,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		field *domain.Field
+		input FieldInput
+		want  domain.Field
+	}{
+		{
+			name:  "create without policies uses defaults",
+			field: newField(time.Time{}),
+			input: FieldInput{Name: "Court", PricePerHour: 100000},
+			want: domain.Field{
+				SurfaceType:        domain.DefaultSurfaceType,
+				PaymentHoldMinutes: domain.DefaultPaymentHoldMinutes,
+				CancellationPolicy: domain.DefaultCancellationPolicy,
+				WaitlistPolicy:     domain.DefaultWaitlistPolicy,
+				ReschedulePolicy:   domain.DefaultReschedulePolicy,
+				SlotPolicy:         domain.DefaultSlotPolicy,
+			},
+		},
+		{
+			name:  "create with policies",
+			field: newField(time.Time{}),
+			input: FieldInput{
+				Name: "Court", PricePerHour: 100000, SurfaceType: domain.SurfaceVinyl, PaymentHoldMinutes: 45,
+				CancellationPolicy: &cancellation, WaitlistPolicy: &waitlist, ReschedulePolicy: &reschedule, SlotPolicy: &slot,
+			},
+			want: *custom(),
+		},
+		{
+			name:  "update without policies keeps existing values",
+			field: custom(),
+			input: FieldInput{Name: "Court", PricePerHour: 100000},
+			want:  *custom(),
+		},
+		{
+			name:  "update overrides only given policies",
+			field: custom(),
+			input: FieldInput{Name: "Court", PricePerHour: 100000, PaymentHoldMinutes: 20, SlotPolicy: &domain.DefaultSlotPolicy},
+			want: domain.Field{
+				SurfaceType:        domain.SurfaceVinyl,
+				PaymentHoldMinutes: 20,
+				CancellationPolicy: cancellation,
+				WaitlistPolicy:     waitlist,
+				ReschedulePolicy:   reschedule,
+				SlotPolicy:         domain.DefaultSlotPolicy,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.applyTo(tt.field)
+
+			got := tt.field
+			if got.Name != tt.input.Name || got.PricePerHour != tt.input.PricePerHour {
+				t.Errorf("name/price = %q %d, want %q %d", got.Name, got.PricePerHour, tt.input.Name, tt.input.PricePerHour)
+			}
+			if got.SurfaceType != tt.want.SurfaceType {
+				t.Errorf("SurfaceType = %s, want %s", got.SurfaceType, tt.want.SurfaceType)
+			}
+			if got.PaymentHoldMinutes != tt.want.PaymentHoldMinutes {
+				t.Errorf("PaymentHoldMinutes = %d, want %d", got.PaymentHoldMinutes, tt.want.PaymentHoldMinutes)
+			}
+			if got.CancellationPolicy != tt.want.CancellationPolicy {
+				t.Errorf("CancellationPolicy = %+v, want %+v", got.CancellationPolicy, tt.want.CancellationPolicy)
+			}
+			if got.WaitlistPolicy != tt.want.WaitlistPolicy {
+				t.Errorf("WaitlistPolicy = %+v, want %+v", got.WaitlistPolicy, tt.want.WaitlistPolicy)
+			}
+			if got.ReschedulePolicy != tt.want.ReschedulePolicy {
+				t.Errorf("ReschedulePolicy = %+v, want %+v", got.ReschedulePolicy, tt.want.ReschedulePolicy)
+			}
+			if got.SlotPolicy != tt.want.SlotPolicy {
+				t.Errorf("SlotPolicy = %+v, want %+v", got.SlotPolicy, tt.want.SlotPolicy)
+			}
+		})
+	}
+}
+
+func TestFieldInputValidateCancellationPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *domain.CancellationPolicy
+		wantErr bool
+	}{
+		{"nil uses default", nil, false},
+		{"default", &domain.DefaultCancellationPolicy, false},
+		{"full refund at the cancellation notice", &domain.CancellationPolicy{FullRefundHours: 2, PartialRefundPercent: 50}, false},
+		{"full refund inside the cancellation notice", &domain.CancellationPolicy{FullRefundHours: 1, PartialRefundPercent: 50}, true},
+		{"no full refund window", &domain.CancellationPolicy{PartialRefundPercent: 50}, true},
+		{"negative full refund hours", &domain.CancellationPolicy{FullRefundHours: -1}, true},
+		{"partial percent above 100", &domain.CancellationPolicy{FullRefundHours: 24, PartialRefundPercent: 101}, true},
+		{"negative partial percent", &domain.CancellationPolicy{FullRefundHours: 24, PartialRefundPercent: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := FieldInput{Name: "Court", Address: "Jl. Sudirman 1", PricePerHour: 100000, CancellationPolicy: tt.policy}
+
+			err := input.validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var validation *domain.ValidationError
+			if err != nil && !errors.As(err, &validation) {
+				t.Fatalf("validate() error = %v, want ValidationError", err)
+			}
+		})
+	}
+}
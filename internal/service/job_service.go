@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"futsal-booking-app/internal/authz"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+)
+
+// maxJobRunsLimit membatasi jumlah riwayat job yang diambil sekaligus.
+const maxJobRunsLimit = 200
+
+type JobService interface {
+	ListJobRuns(ctx context.Context, actor *domain.User, filter repository.JobRunFilter) ([]*domain.JobRun, error)
+}
+
+type jobService struct {
+	jobRunRepo repository.JobRunRepository
+	policy     *authz.Policy
+}
+
+func NewJobService(jobRunRepo repository.JobRunRepository, policy *authz.Policy) JobService {
+	return &jobService{jobRunRepo: jobRunRepo, policy: policy}
+}
+
+// ListJobRuns mengambil riwayat eksekusi job terjadwal, terbaru lebih dulu
+// Hanya admin yang boleh melihat riwayat job
+func (u *jobService) ListJobRuns(ctx context.Context, actor *domain.User, filter repository.JobRunFilter) ([]*domain.JobRun, error) {
+	if err := u.policy.Authorize(actor, authz.ActionJobView, authz.Resource{}); err != nil {
+		return nil, err
+	}
+
+	switch filter.Status {
+	case "", domain.JobRunRunning, domain.JobRunSuccess, domain.JobRunFailed:
+	default:
+		return nil, domain.Invalidf("invalid job run status: %s", filter.Status)
+	}
+
+	if filter.Limit <= 0 || filter.Limit > maxJobRunsLimit {
+		filter.Limit = maxJobRunsLimit
+	}
+
+	runs, err := u.jobRunRepo.FindRecent(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching job runs: %w", err)
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/payment"
+	"futsal-booking-app/internal/repository"
+	"strings"
+	"time"
+)
+
+type PaymentService interface {
+	HandleNotification(ctx context.Context, body []byte) error
+}
+
+type paymentService struct {
+	uow     repository.UnitOfWork
+	gateway payment.Gateway
+}
+
+func NewPaymentService(uow repository.UnitOfWork, gateway payment.Gateway) PaymentService {
+	return &paymentService{
+		uow:     uow,
+		gateway: gateway,
+	}
+}
+
+// HandleNotification memproses webhook dari payment gateway
+// Business logic:
+// 1. Signature notifikasi diverifikasi oleh gateway, notifikasi palsu ditolak
+// 2. Payment dicari berdasarkan transaction ID dan dikunci sampai transaksi selesai
+// 3. SUCCESS mengkonfirmasi booking PENDING, FAILED membatalkan booking PENDING sehingga slot dilepas (semua occurrence untuk payment series)
+// 4. SUCCESS untuk booking yang sudah dibatalkan atau expired dicatat, lalu dananya di-refund
+// 5. Payment, booking, dan refund diupdate dalam satu transaksi; setiap perubahan status payment dicatat di riwayatnya
+// 6. Transaction ID yang bukan milik payment dicari di PaymentAdjustment (tagihan selisih reschedule). Adjustment FAILED membatalkan booking dan me-refund dananya; adjustment yang baru dibayar setelah gagal atau setelah booking dibatalkan di-refund
+// 7. Transaction ID charge lama yang sudah diganti (SupersededCharge) yang terlanjur dibayar di-refund penuh
+// 8. Refund dicairkan lewat gateway setelah transaksi commit
+// 9. Nominal SUCCESS yang tidak cocok tidak mengubah status apa pun; notifikasinya dicatat di riwayat status payment lalu AmountMismatchError dikembalikan
+// Transisi bersifat idempotent: notifikasi duplikat atau datang tidak berurutan
+// (misalnya FAILED setelah SUCCESS) tidak mengubah apa-apa.
+func (u *paymentService) HandleNotification(ctx context.Context, body []byte) error {
+	n, err := u.gateway.VerifyNotification(ctx, body)
+	if err != nil {
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			return domain.ErrInvalidSignature
+		}
+		return domain.Invalidf("invalid payment notification: %v", err)
+	}
+
+	var refunds []*domain.Refund
+	var mismatch *domain.AmountMismatchError
+
+	err = u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		mismatch = nil
+
+		var err error
+		refunds, err = u.apply(ctx, repos, n)
+		if errors.As(err, &mismatch) {
+			refunds = nil
+			return recordAmountMismatch(ctx, repos, mismatch)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if mismatch != nil {
+		return mismatch
+	}
+
+	for _, refund := range refunds {
+		issueRefund(ctx, u.uow, u.gateway, refund)
+	}
+
+	return nil
+}
+
+// apply menerapkan notifikasi yang sudah diverifikasi ke payment, adjustment,
+// atau charge lama pemilik transaction ID-nya. Harus dipanggil di dalam UnitOfWork.
+func (u *paymentService) apply(ctx context.Context, repos *repository.Repositories, n *payment.Notification) ([]*domain.Refund, error) {
+	p, err := repos.Payments.FindByTransactionIDForUpdate(ctx, n.TransactionID)
+	if errors.Is(err, domain.ErrPaymentNotFound) {
+		return u.applyOtherCharge(ctx, repos, n)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Status {
+	case payment.StatusSuccess:
+		return u.applySuccess(ctx, repos, p, n)
+	case payment.StatusFailed:
+		return nil, u.applyFailure(ctx, repos, p)
+	default:
+		// PENDING tidak mengubah apa-apa; REFUNDED dicatat saat refund dibuat.
+		return nil, nil
+	}
+}
+
+// recordAmountMismatch mencatat notifikasi yang nominalnya tidak cocok di
+// riwayat status payment tanpa mengubah statusnya, supaya operator bisa
+// menindaklanjuti dana yang sudah diterima gateway.
+func recordAmountMismatch(ctx context.Context, repos *repository.Repositories, mismatch *domain.AmountMismatchError) error {
+	p, err := repos.Payments.FindByID(ctx, mismatch.PaymentID)
+	if err != nil {
+		return fmt.Errorf("error fetching payment: %w", err)
+	}
+
+	return repos.PaymentHistory.Create(ctx, &domain.PaymentStatusChange{
+		PaymentID:  p.ID,
+		FromStatus: p.Status,
+		ToStatus:   p.Status,
+		Reason:     mismatch.Error(),
+		CreatedAt:  time.Now(),
+	})
+}
+
+// applyOtherCharge memproses notifikasi untuk transaction ID yang bukan
+// transaksi utama payment: tagihan selisih reschedule, atau charge lama yang
+// sudah diganti. Transaction ID yang tidak dikenal mengembalikan ErrPaymentNotFound.
+func (u *paymentService) applyOtherCharge(ctx context.Context, repos *repository.Repositories, n *payment.Notification) ([]*domain.Refund, error) {
+	a, err := repos.Adjustments.FindByTransactionIDForUpdate(ctx, n.TransactionID)
+	if err == nil {
+		return u.applyAdjustment(ctx, repos, a, n)
+	}
+	if !errors.Is(err, domain.ErrPaymentNotFound) {
+		return nil, err
+	}
+
+	charge, err := repos.Payments.FindSupersededChargeForUpdate(ctx, n.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.applySupersededCharge(ctx, repos, charge, n)
+}
+
+// applySuccess mencatat payment SUCCESS dan mengkonfirmasi booking PENDING.
+// Payment yang sudah FAILED (hold kadaluarsa atau dibatalkan) tetap dicatat
+// SUCCESS karena dana sudah diterima gateway; transisinya tercatat di riwayat
+// status payment. Booking yang sudah dibatalkan atau expired tidak dikonfirmasi,
+// dananya disiapkan sebagai refund PENDING.
+func (u *paymentService) applySuccess(ctx context.Context, repos *repository.Repositories, p *domain.Payment, n *payment.Notification) ([]*domain.Refund, error) {
+	if p.IsSuccess() || p.IsRefunded() {
+		return nil, nil
+	}
+
+	if n.Amount != p.Amount {
+		return nil, &domain.AmountMismatchError{PaymentID: p.ID, TransactionID: n.TransactionID, Received: n.Amount, Expected: p.Amount}
+	}
+
+	reason := "payment succeeded"
+	if p.IsFailed() {
+		reason = "payment received after it had failed"
+	}
+
+	if err := transitionPayment(ctx, repos, p, domain.PaymentSuccess, reason); err != nil {
+		return nil, err
+	}
+
+	bookings, err := lockPaymentBookings(ctx, repos, p)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var refunds []*domain.Refund
+
+	for _, booking := range bookings {
+		if booking.IsPending() {
+			if err := transitionBooking(ctx, repos, booking, domain.BookingConfirmed, nil, "payment succeeded"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if !booking.IsCancelled() && !booking.IsExpired() {
+			continue
+		}
+
+		// Payment series dikembalikan per occurrence yang sudah tidak berlaku
+		amount := p.Amount
+		if p.SeriesID != nil {
+			amount = booking.TotalPrice
+		}
+
+		refund := &domain.Refund{
+			BookingID: booking.ID,
+			PaymentID: p.ID,
+			Amount:    amount,
+			Reason:    "payment received after booking was " + strings.ToLower(string(booking.Status)),
+			RefundKey: fmt.Sprintf("RF-%d-P%d", booking.ID, p.ID),
+			Status:    domain.RefundPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		if err := repos.Refunds.Create(ctx, refund); err != nil {
+			return nil, fmt.Errorf("error creating refund: %w", err)
+		}
+		refunds = append(refunds, refund)
+	}
+
+	return refunds, nil
+}
+
+func (u *paymentService) applyFailure(ctx context.Context, repos *repository.Repositories, p *domain.Payment) error {
+	if !p.IsPending() {
+		return nil
+	}
+
+	if err := transitionPayment(ctx, repos, p, domain.PaymentFailed, "payment failed"); err != nil {
+		return err
+	}
+
+	bookings, err := lockPaymentBookings(ctx, repos, p)
+	if err != nil {
+		return err
+	}
+
+	for _, booking := range bookings {
+		if !booking.IsPending() {
+			continue
+		}
+
+		if err := transitionBooking(ctx, repos, booking, domain.BookingCancelled, nil, "payment failed"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyAdjustment mencatat hasil pembayaran tagihan tambahan yang sudah dikunci.
+// FAILED membatalkan booking dan me-refund dana yang sudah diterima (lihat
+// failAdjustment). SUCCESS yang datang setelah adjustment gagal atau setelah
+// booking dibatalkan tetap dicatat, lalu nominalnya di-refund.
+func (u *paymentService) applyAdjustment(ctx context.Context, repos *repository.Repositories, a *domain.PaymentAdjustment, n *payment.Notification) ([]*domain.Refund, error) {
+	now := time.Now()
+
+	switch n.Status {
+	case payment.StatusSuccess:
+		if a.IsSuccess() {
+			return nil, nil
+		}
+
+		if n.Amount != a.Amount {
+			return nil, &domain.AmountMismatchError{PaymentID: a.PaymentID, TransactionID: n.TransactionID, Received: n.Amount, Expected: a.Amount}
+		}
+
+		failed := !a.IsPending()
+
+		a.MarkAsSuccess()
+		if err := repos.Adjustments.Update(ctx, a); err != nil {
+			return nil, fmt.Errorf("error updating payment adjustment: %w", err)
+		}
+
+		booking, err := repos.Bookings.FindByIDForUpdate(ctx, a.BookingID)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching booking: %w", err)
+		}
+
+		if !failed && !booking.IsCancelled() {
+			return nil, nil
+		}
+
+		refund, err := createChargeRefund(ctx, repos, booking.ID, a.PaymentID, a.TransactionID, a.Amount, "reschedule adjustment paid after booking was cancelled", now)
+		if err != nil || refund == nil {
+			return nil, err
+		}
+		return []*domain.Refund{refund}, nil
+
+	case payment.StatusFailed:
+		if !a.IsPending() {
+			return nil, nil
+		}
+
+		return failAdjustment(ctx, repos, a, "reschedule adjustment payment failed", now)
+
+	default:
+		return nil, nil
+	}
+}
+
+// applySupersededCharge me-refund penuh charge lama yang terlanjur dibayar.
+// Payment dan booking tidak berubah karena sudah memakai charge pengganti.
+func (u *paymentService) applySupersededCharge(ctx context.Context, repos *repository.Repositories, charge *domain.SupersededCharge, n *payment.Notification) ([]*domain.Refund, error) {
+	if n.Status != payment.StatusSuccess {
+		return nil, nil
+	}
+
+	if n.Amount != charge.Amount {
+		return nil, &domain.AmountMismatchError{PaymentID: charge.PaymentID, TransactionID: n.TransactionID, Received: n.Amount, Expected: charge.Amount}
+	}
+
+	refund, err := createChargeRefund(ctx, repos, charge.BookingID, charge.PaymentID, charge.TransactionID, charge.Amount, "superseded charge paid", time.Now())
+	if err != nil || refund == nil {
+		return nil, err
+	}
+
+	return []*domain.Refund{refund}, nil
+}
+
+// lockPaymentBookings mengunci booking yang ditanggung payment: satu booking,
+// atau semua occurrence jika payment milik series.
+func lockPaymentBookings(ctx context.Context, repos *repository.Repositories, p *domain.Payment) ([]*domain.Booking, error) {
+	if p.SeriesID != nil {
+		bookings, err := repos.Bookings.LockBySeriesID(ctx, *p.SeriesID)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching bookings: %w", err)
+		}
+		return bookings, nil
+	}
+
+	booking, err := repos.Bookings.FindByIDForUpdate(ctx, *p.BookingID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching booking: %w", err)
+	}
+
+	return []*domain.Booking{booking}, nil
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/payment"
+	"futsal-booking-app/internal/repository"
+	"strings"
+	"testing"
+)
+
+type paymentFixture struct {
+	gateway  *payment.FakeGateway
+	service  PaymentService
+	payments *fakePaymentRepo
+	bookings *fakeBookingRepo
+	history  *fakePaymentHistoryRepo
+	refunds  *fakeRefundRepo
+	promos   *fakePromoRepo
+}
+
+const (
+	fixturePaymentID = 1
+	fixtureBookingID = 10
+	fixtureTrx       = "TRX-10-1"
+	fixtureAmount    = 150000
+)
+
+// newPaymentFixture menyiapkan satu booking PENDING dengan payment PENDING
+// dan charge fake gateway sebesar chargeAmount.
+func newPaymentFixture(t *testing.T, chargeAmount int) *paymentFixture {
+	t.Helper()
+
+	gateway := payment.NewFakeGateway("secret")
+	if _, err := gateway.CreateCharge(context.Background(), payment.ChargeRequest{TransactionID: fixtureTrx, Amount: chargeAmount}); err != nil {
+		t.Fatalf("CreateCharge: %v", err)
+	}
+
+	bookingID := fixtureBookingID
+	f := &paymentFixture{
+		gateway: gateway,
+		payments: &fakePaymentRepo{payments: map[int]*domain.Payment{
+			fixturePaymentID: {ID: fixturePaymentID, BookingID: &bookingID, Amount: fixtureAmount, TransactionID: fixtureTrx, Status: domain.PaymentPending},
+		}},
+		bookings: &fakeBookingRepo{bookings: map[int]*domain.Booking{
+			fixtureBookingID: {ID: fixtureBookingID, UserID: 1, TotalPrice: fixtureAmount, Status: domain.BookingPending},
+		}},
+		history: &fakePaymentHistoryRepo{},
+		refunds: &fakeRefundRepo{},
+		promos:  &fakePromoRepo{},
+	}
+
+	uow := &fakeUnitOfWork{repos: &repository.Repositories{
+		Payments:       f.payments,
+		PaymentHistory: f.history,
+		Bookings:       f.bookings,
+		BookingHistory: &fakeBookingHistoryRepo{},
+		Refunds:        f.refunds,
+		Promos:         f.promos,
+	}}
+	f.service = NewPaymentService(uow, gateway)
+
+	return f
+}
+
+// notify mensimulasikan gateway mengirim webhook dengan status status.
+func (f *paymentFixture) notify(t *testing.T, status payment.Status) error {
+	t.Helper()
+
+	if err := f.gateway.SetStatus(fixtureTrx, status); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	body, err := f.gateway.BuildNotification(fixtureTrx)
+	if err != nil {
+		t.Fatalf("BuildNotification: %v", err)
+	}
+
+	return f.service.HandleNotification(context.Background(), body)
+}
+
+func (f *paymentFixture) assertState(t *testing.T, wantPayment domain.PaymentStatus, wantBooking domain.BookingStatus, wantHistory int) {
+	t.Helper()
+
+	if got := f.payments.payments[fixturePaymentID].Status; got != wantPayment {
+		t.Errorf("payment status = %s, want %s", got, wantPayment)
+	}
+	if got := f.bookings.bookings[fixtureBookingID].Status; got != wantBooking {
+		t.Errorf("booking status = %s, want %s", got, wantBooking)
+	}
+	if got := len(f.history.changes); got != wantHistory {
+		t.Errorf("payment history has %d entries, want %d", got, wantHistory)
+	}
+}
+
+func TestHandleNotification(t *testing.T) {
+	tests := []struct {
+		name          string
+		notifications []payment.Status
+		wantPayment   domain.PaymentStatus
+		wantBooking   domain.BookingStatus
+		wantHistory   int
+	}{
+		{
+			name:          "success confirms booking",
+			notifications: []payment.Status{payment.StatusSuccess},
+			wantPayment:   domain.PaymentSuccess,
+			wantBooking:   domain.BookingConfirmed,
+			wantHistory:   1,
+		},
+		{
+			name:          "failure cancels booking",
+			notifications: []payment.Status{payment.StatusFailed},
+			wantPayment:   domain.PaymentFailed,
+			wantBooking:   domain.BookingCancelled,
+			wantHistory:   1,
+		},
+		{
+			name:          "duplicate success is ignored",
+			notifications: []payment.Status{payment.StatusSuccess, payment.StatusSuccess},
+			wantPayment:   domain.PaymentSuccess,
+			wantBooking:   domain.BookingConfirmed,
+			wantHistory:   1,
+		},
+		{
+			name:          "duplicate failure is ignored",
+			notifications: []payment.Status{payment.StatusFailed, payment.StatusFailed},
+			wantPayment:   domain.PaymentFailed,
+			wantBooking:   domain.BookingCancelled,
+			wantHistory:   1,
+		},
+		{
+			name:          "failure after success is ignored",
+			notifications: []payment.Status{payment.StatusSuccess, payment.StatusFailed},
+			wantPayment:   domain.PaymentSuccess,
+			wantBooking:   domain.BookingConfirmed,
+			wantHistory:   1,
+		},
+		{
+			name:          "pending changes nothing",
+			notifications: []payment.Status{payment.StatusPending},
+			wantPayment:   domain.PaymentPending,
+			wantBooking:   domain.BookingPending,
+			wantHistory:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPaymentFixture(t, fixtureAmount)
+
+			for _, status := range tt.notifications {
+				if err := f.notify(t, status); err != nil {
+					t.Fatalf("HandleNotification(%s): %v", status, err)
+				}
+			}
+
+			f.assertState(t, tt.wantPayment, tt.wantBooking, tt.wantHistory)
+
+			if len(f.refunds.refunds) != 0 {
+				t.Errorf("got %d refunds, want none", len(f.refunds.refunds))
+			}
+		})
+	}
+}
+
+func TestHandleNotificationFailureReleasesPromo(t *testing.T) {
+	f := newPaymentFixture(t, fixtureAmount)
+
+	if err := f.notify(t, payment.StatusFailed); err != nil {
+		t.Fatalf("HandleNotification: %v", err)
+	}
+
+	if len(f.promos.released) != 1 || f.promos.released[0] != fixtureBookingID {
+		t.Fatalf("released redemptions = %v, want [%d]", f.promos.released, fixtureBookingID)
+	}
+}
+
+func TestHandleNotificationRefundsLatePayment(t *testing.T) {
+	f := newPaymentFixture(t, fixtureAmount)
+
+	if err := f.notify(t, payment.StatusFailed); err != nil {
+		t.Fatalf("HandleNotification(FAILED): %v", err)
+	}
+
+	// Customer tetap membayar setelah hold dilepas
+	if err := f.notify(t, payment.StatusSuccess); err != nil {
+		t.Fatalf("HandleNotification(SUCCESS): %v", err)
+	}
+
+	// FAILED -> SUCCESS -> REFUNDED setelah refund dicairkan gateway
+	f.assertState(t, domain.PaymentRefunded, domain.BookingCancelled, 3)
+
+	if len(f.refunds.refunds) != 1 {
+		t.Fatalf("got %d refunds, want 1", len(f.refunds.refunds))
+	}
+
+	refund := f.refunds.refunds[0]
+	if refund.Amount != fixtureAmount || !refund.IsSuccess() {
+		t.Fatalf("refund = %d %s, want %d %s", refund.Amount, refund.Status, fixtureAmount, domain.RefundSuccess)
+	}
+
+	status, err := f.gateway.GetStatus(context.Background(), fixtureTrx)
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if status.Status != payment.StatusRefunded {
+		t.Fatalf("gateway status = %s, want %s", status.Status, payment.StatusRefunded)
+	}
+}
+
+func TestHandleNotificationAmountMismatch(t *testing.T) {
+	f := newPaymentFixture(t, fixtureAmount-1000)
+
+	err := f.notify(t, payment.StatusSuccess)
+
+	var mismatch *domain.AmountMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("HandleNotification error = %v, want AmountMismatchError", err)
+	}
+	if mismatch.Received != fixtureAmount-1000 || mismatch.Expected != fixtureAmount {
+		t.Fatalf("mismatch = %+v", mismatch)
+	}
+
+	// Notifikasi dicatat tanpa mengubah status
+	f.assertState(t, domain.PaymentPending, domain.BookingPending, 1)
+
+	change := f.history.changes[0]
+	if change.FromStatus != domain.PaymentPending || change.ToStatus != domain.PaymentPending {
+		t.Fatalf("history = %s -> %s, want PENDING -> PENDING", change.FromStatus, change.ToStatus)
+	}
+}
+
+func TestHandleNotificationRejectsTamperedBody(t *testing.T) {
+	f := newPaymentFixture(t, fixtureAmount)
+
+	body, err := f.gateway.BuildNotification(fixtureTrx)
+	if err != nil {
+		t.Fatalf("BuildNotification: %v", err)
+	}
+
+	tampered := strings.Replace(string(body), `"amount":150000`, `"amount":1000`, 1)
+	if tampered == string(body) {
+		t.Fatalf("notification body has no amount to tamper: %s", body)
+	}
+
+	if err := f.service.HandleNotification(context.Background(), []byte(tampered)); !errors.Is(err, domain.ErrInvalidSignature) {
+		t.Fatalf("HandleNotification error = %v, want %v", err, domain.ErrInvalidSignature)
+	}
+
+	f.assertState(t, domain.PaymentPending, domain.BookingPending, 0)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"futsal-booking-app/internal/authz"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"strings"
+	"time"
+)
+
+// PricingRuleInput berisi satu tarif khusus lapangan. StartTime dan EndTime
+// memakai format HH:MM ("24:00" untuk akhir hari); keduanya boleh kosong
+// untuk rule yang berlaku sepanjang hari.
+type PricingRuleInput struct {
+	Name         string
+	DayOfWeek    *int
+	StartTime    string
+	EndTime      string
+	StartDate    *time.Time
+	EndDate      *time.Time
+	PricePerHour *int
+	Multiplier   *float64
+	Priority     int
+}
+
+func (in PricingRuleInput) toRule(fieldID int, now time.Time) (*domain.PricingRule, error) {
+	rule := &domain.PricingRule{
+		FieldID:      fieldID,
+		Name:         strings.TrimSpace(in.Name),
+		StartMinute:  0,
+		EndMinute:    domain.MinutesPerDay,
+		StartDate:    in.StartDate,
+		EndDate:      in.EndDate,
+		PricePerHour: in.PricePerHour,
+		Multiplier:   in.Multiplier,
+		Priority:     in.Priority,
+		CreatedAt:    now,
+	}
+
+	if in.DayOfWeek != nil {
+		if *in.DayOfWeek < 0 || *in.DayOfWeek > 6 {
+			return nil, domain.Invalidf("invalid day of week: %d", *in.DayOfWeek)
+		}
+		day := domain.DayOfWeek(*in.DayOfWeek)
+		rule.DayOfWeek = &day
+	}
+
+	if in.StartTime != "" {
+		minute, err := parseMinuteOfDay(in.StartTime)
+		if err != nil {
+			return nil, domain.Invalidf("invalid start time format: %s", in.StartTime)
+		}
+		rule.StartMinute = minute
+	}
+
+	if in.EndTime != "" {
+		minute, err := parseMinuteOfDay(in.EndTime)
+		if err != nil {
+			return nil, domain.Invalidf("invalid end time format: %s", in.EndTime)
+		}
+		rule.EndMinute = minute
+	}
+
+	if rule.EndMinute <= rule.StartMinute {
+		return nil, domain.Invalidf("end time must be after start time")
+	}
+
+	if in.StartDate != nil && in.EndDate != nil && in.EndDate.Before(*in.StartDate) {
+		return nil, domain.Invalidf("end date must not be before start date")
+	}
+
+	if (in.PricePerHour == nil) == (in.Multiplier == nil) {
+		return nil, domain.Invalidf("either price per hour or multiplier must be set")
+	}
+
+	if in.PricePerHour != nil && *in.PricePerHour <= 0 {
+		return nil, domain.Invalidf("price per hour must be positive")
+	}
+
+	if in.Multiplier != nil && *in.Multiplier <= 0 {
+		return nil, domain.Invalidf("multiplier must be positive")
+	}
+
+	return rule, nil
+}
+
+// parseMinuteOfDay mengubah HH:MM menjadi menit sejak tengah malam.
+// "24:00" diterima sebagai akhir hari.
+func parseMinuteOfDay(value string) (int, error) {
+	if value == "24:00" {
+		return domain.MinutesPerDay, nil
+	}
+
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// SetPricingRules mengganti semua pricing rule lapangan
+// Business logic:
+// 1. Hanya owner lapangan (atau admin)
+// 2. Semua rule divalidasi sebelum ada yang disimpan, termasuk tarif efektif minimal 1 per jam; daftar kosong menghapus semua rule
+// 3. Rule lama dihapus dan rule baru disimpan dalam satu transaksi
+// 4. Booking yang sudah ada tidak berubah harga karena rinciannya tersimpan di booking
+func (u *fieldService) SetPricingRules(ctx context.Context, actor *domain.User, fieldID int, inputs []PricingRuleInput) ([]*domain.PricingRule, error) {
+	if fieldID <= 0 {
+		return nil, domain.Invalidf("invalid field ID")
+	}
+
+	field, err := u.fieldRepo.FindByID(ctx, fieldID)
+	if err != nil {
+		return nil, domain.ErrFieldNotFound
+	}
+
+	if err := u.policy.Authorize(actor, authz.ActionFieldManagePricing, authz.Resource{Field: field}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rules := make([]*domain.PricingRule, 0, len(inputs))
+
+	for _, input := range inputs {
+		rule, err := input.toRule(fieldID, now)
+		if err != nil {
+			return nil, err
+		}
+
+		// Multiplier yang terlalu kecil dibulatkan menjadi tarif 0
+		if rule.HourlyRate(field.PricePerHour) < 1 {
+			return nil, domain.Invalidf("multiplier is too small for the field price per hour")
+		}
+		rules = append(rules, rule)
+	}
+
+	err = u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		if err := repos.Fields.DeletePricingRulesByFieldID(ctx, fieldID); err != nil {
+			return err
+		}
+
+		for _, rule := range rules {
+			if err := repos.Fields.CreatePricingRule(ctx, rule); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetPricingRules(ctx, fieldID)
+}
+
+func (u *fieldService) GetPricingRules(ctx context.Context, fieldID int) ([]*domain.PricingRule, error) {
+	if fieldID <= 0 {
+		return nil, domain.Invalidf("invalid field ID")
+	}
+
+	rules, err := u.fieldRepo.FindPricingRulesByFieldID(ctx, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching pricing rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// quotePrice menghitung rincian harga rentang [start, end) dengan pricing rule
+// lapangan yang berlaku saat ini.
+func quotePrice(ctx context.Context, fields repository.FieldRepository, field *domain.Field, start, end time.Time) (domain.PriceBreakdown, error) {
+	rules, err := fields.FindPricingRulesByFieldID(ctx, field.ID)
+	if err != nil {
+		return domain.PriceBreakdown{}, err
+	}
+
+	price := domain.QuotePrice(field, rules, start, end)
+	if err := checkQuote(price); err != nil {
+		return domain.PriceBreakdown{}, err
+	}
+
+	return price, nil
+}
+
+// checkQuote menolak harga yang tidak positif, misalnya karena tarif rule
+// dibulatkan ke 0 setelah tarif dasar lapangan diturunkan, atau potongan yang
+// terlalu pendek untuk tarifnya.
+func checkQuote(price domain.PriceBreakdown) error {
+	if price.Total <= 0 {
+		return domain.Invalidf("price for the requested time must be positive")
+	}
+
+	return nil
+}
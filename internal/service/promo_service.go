@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"futsal-booking-app/internal/authz"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"time"
+)
+
+type PromoService interface {
+	CreatePromo(ctx context.Context, actor *domain.User, input PromoInput) (*domain.PromoCode, error)
+	UpdatePromo(ctx context.Context, actor *domain.User, promoID int, input PromoInput) (*domain.PromoCode, error)
+	GetPromo(ctx context.Context, actor *domain.User, promoID int) (*domain.PromoCode, error)
+	GetMyPromos(ctx context.Context, actor *domain.User) ([]*domain.PromoCode, error)
+	GetPromoRedemptions(ctx context.Context, actor *domain.User, promoID int) ([]*domain.PromoRedemption, error)
+}
+
+// PromoInput berisi pengaturan kode promo. Code diabaikan saat update
+// karena kode yang sudah dibagikan ke customer tidak boleh berubah.
+type PromoInput struct {
+	Code             string
+	DiscountType     domain.DiscountType
+	DiscountValue    int
+	FieldIDs         []int
+	MinSpend         int
+	ValidFrom        *time.Time
+	ValidUntil       *time.Time
+	MaxUses          int
+	MaxUsesPerUser   int
+	FirstBookingOnly bool
+	Active           bool
+}
+
+func (in PromoInput) validate() error {
+	switch in.DiscountType {
+	case domain.DiscountPercentage:
+		if in.DiscountValue <= 0 || in.DiscountValue > 100 {
+			return domain.Invalidf("percentage discount must be between 1 and 100")
+		}
+	case domain.DiscountFixed:
+		if in.DiscountValue <= 0 {
+			return domain.Invalidf("fixed discount must be positive")
+		}
+	default:
+		return domain.Invalidf("discount type must be PERCENTAGE or FIXED")
+	}
+
+	if in.MinSpend < 0 {
+		return domain.Invalidf("minimum spend cannot be negative")
+	}
+
+	if in.MaxUses < 0 || in.MaxUsesPerUser < 0 {
+		return domain.Invalidf("usage limits cannot be negative")
+	}
+
+	if in.ValidFrom != nil && in.ValidUntil != nil && !in.ValidFrom.Before(*in.ValidUntil) {
+		return domain.Invalidf("valid from must be before valid until")
+	}
+
+	return nil
+}
+
+func (in PromoInput) applyTo(promo *domain.PromoCode) {
+	promo.DiscountType = in.DiscountType
+	promo.DiscountValue = in.DiscountValue
+	promo.FieldIDs = in.FieldIDs
+	promo.MinSpend = in.MinSpend
+	promo.ValidFrom = in.ValidFrom
+	promo.ValidUntil = in.ValidUntil
+	promo.MaxUses = in.MaxUses
+	promo.MaxUsesPerUser = in.MaxUsesPerUser
+	promo.FirstBookingOnly = in.FirstBookingOnly
+	promo.Active = in.Active
+}
+
+type promoService struct {
+	promoRepo repository.PromoRepository
+	fieldRepo repository.FieldRepository
+	policy    *authz.Policy
+}
+
+func NewPromoService(promoRepo repository.PromoRepository, fieldRepo repository.FieldRepository, policy *authz.Policy) PromoService {
+	return &promoService{
+		promoRepo: promoRepo,
+		fieldRepo: fieldRepo,
+		policy:    policy,
+	}
+}
+
+// CreatePromo membuat kode promo baru
+// Business logic:
+// 1. Hanya owner (atau admin) yang boleh membuat promo
+// 2. Promo owner wajib dibatasi ke lapangan miliknya; hanya admin yang boleh membuat promo untuk semua lapangan
+// 3. Kode disimpan dalam huruf besar dan harus unik
+func (u *promoService) CreatePromo(ctx context.Context, actor *domain.User, input PromoInput) (*domain.PromoCode, error) {
+	if err := u.policy.Authorize(actor, authz.ActionPromoCreate, authz.Resource{}); err != nil {
+		return nil, err
+	}
+
+	code := domain.NormalizePromoCode(input.Code)
+	if code == "" {
+		return nil, domain.Invalidf("promo code cannot be empty")
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.checkFieldScope(ctx, actor, input.FieldIDs); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	promo := &domain.PromoCode{
+		Code:      code,
+		CreatedBy: actor.ID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	input.applyTo(promo)
+
+	if err := u.promoRepo.Create(ctx, promo); err != nil {
+		return nil, err
+	}
+
+	return promo, nil
+}
+
+// UpdatePromo mengubah pengaturan promo, termasuk menonaktifkannya.
+// Redemption yang sudah ada tidak terpengaruh.
+func (u *promoService) UpdatePromo(ctx context.Context, actor *domain.User, promoID int, input PromoInput) (*domain.PromoCode, error) {
+	promo, err := u.authorizePromo(ctx, actor, promoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.checkFieldScope(ctx, actor, input.FieldIDs); err != nil {
+		return nil, err
+	}
+
+	input.applyTo(promo)
+
+	if err := u.promoRepo.Update(ctx, promo); err != nil {
+		return nil, err
+	}
+
+	return promo, nil
+}
+
+func (u *promoService) GetPromo(ctx context.Context, actor *domain.User, promoID int) (*domain.PromoCode, error) {
+	return u.authorizePromo(ctx, actor, promoID)
+}
+
+// GetMyPromos mengambil promo buatan actor. Admin melihat semua promo.
+func (u *promoService) GetMyPromos(ctx context.Context, actor *domain.User) ([]*domain.PromoCode, error) {
+	if actor.IsAdmin() {
+		return u.promoRepo.FindAll(ctx)
+	}
+
+	return u.promoRepo.FindByCreator(ctx, actor.ID)
+}
+
+func (u *promoService) GetPromoRedemptions(ctx context.Context, actor *domain.User, promoID int) ([]*domain.PromoRedemption, error) {
+	if _, err := u.authorizePromo(ctx, actor, promoID); err != nil {
+		return nil, err
+	}
+
+	return u.promoRepo.FindRedemptionsByPromoID(ctx, promoID)
+}
+
+func (u *promoService) authorizePromo(ctx context.Context, actor *domain.User, promoID int) (*domain.PromoCode, error) {
+	if promoID <= 0 {
+		return nil, domain.Invalidf("invalid promo ID")
+	}
+
+	promo, err := u.promoRepo.FindByID(ctx, promoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.policy.Authorize(actor, authz.ActionPromoManage, authz.Resource{Promo: promo}); err != nil {
+		return nil, err
+	}
+
+	return promo, nil
+}
+
+// checkFieldScope memastikan owner hanya membuat promo untuk lapangan miliknya.
+func (u *promoService) checkFieldScope(ctx context.Context, actor *domain.User, fieldIDs []int) error {
+	if actor.IsAdmin() {
+		for _, id := range fieldIDs {
+			if _, err := u.fieldRepo.FindByID(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(fieldIDs) == 0 {
+		return domain.Invalidf("owner promo codes must be limited to at least one field")
+	}
+
+	for _, id := range fieldIDs {
+		field, err := u.fieldRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := u.policy.Authorize(actor, authz.ActionFieldUpdate, authz.Resource{Field: field}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// redeemPromo memvalidasi kode promo untuk booking yang belum disimpan lalu
+// menerapkan diskonnya. Baris promo dikunci sampai transaksi selesai supaya
+// batas pemakaian tidak terlampaui oleh checkout bersamaan. Redemption
+// disimpan oleh recordRedemption setelah booking mendapat ID.
+func redeemPromo(ctx context.Context, repos *repository.Repositories, code string, booking *domain.Booking, now time.Time) (*domain.PromoCode, error) {
+	promo, err := repos.Promos.FindByCodeForUpdate(ctx, domain.NormalizePromoCode(code))
+	if err != nil {
+		if errors.Is(err, domain.ErrPromoNotFound) {
+			return nil, domain.Invalidf("invalid promo code")
+		}
+		return nil, err
+	}
+
+	if !promo.IsValidAt(now) {
+		return nil, domain.Invalidf("promo code is not active")
+	}
+
+	if !promo.AppliesToField(booking.FieldID) {
+		return nil, domain.Invalidf("promo code cannot be used for this field")
+	}
+
+	if booking.OriginalPrice < promo.MinSpend {
+		return nil, domain.Invalidf("promo code requires a minimum spend of %d", promo.MinSpend)
+	}
+
+	if promo.MaxUses > 0 {
+		used, err := repos.Promos.CountActiveRedemptions(ctx, promo.ID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= promo.MaxUses {
+			return nil, domain.Invalidf("promo code usage limit has been reached")
+		}
+	}
+
+	if promo.MaxUsesPerUser > 0 {
+		used, err := repos.Promos.CountActiveRedemptionsByUser(ctx, promo.ID, booking.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= promo.MaxUsesPerUser {
+			return nil, domain.Invalidf("you have already used this promo code")
+		}
+	}
+
+	if promo.FirstBookingOnly {
+		count, err := repos.Bookings.CountCommittedByUserID(ctx, booking.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, domain.Invalidf("promo code is only valid for your first booking")
+		}
+	}
+
+	// Diskon persentase bisa terbulatkan menjadi 0 untuk harga kecil
+	discount := promo.Discount(booking.OriginalPrice)
+	if discount <= 0 {
+		return nil, domain.Invalidf("promo code gives no discount for this booking price")
+	}
+
+	booking.ApplyDiscount(discount)
+	if booking.TotalPrice <= 0 {
+		return nil, domain.Invalidf("promo code cannot cover the full booking price")
+	}
+
+	return promo, nil
+}
+
+func recordRedemption(ctx context.Context, repos *repository.Repositories, promo *domain.PromoCode, booking *domain.Booking, now time.Time) error {
+	return repos.Promos.CreateRedemption(ctx, &domain.PromoRedemption{
+		PromoCodeID:    promo.ID,
+		BookingID:      booking.ID,
+		UserID:         booking.UserID,
+		DiscountAmount: booking.DiscountAmount,
+		Status:         domain.RedemptionActive,
+		CreatedAt:      now,
+	})
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"testing"
+	"time"
+)
+
+type fakePromoCodeRepo struct {
+	repository.PromoRepository
+	promo *domain.PromoCode
+}
+
+func (r *fakePromoCodeRepo) FindByCodeForUpdate(ctx context.Context, code string) (*domain.PromoCode, error) {
+	if r.promo == nil || r.promo.Code != code {
+		return nil, domain.ErrPromoNotFound
+	}
+	return r.promo, nil
+}
+
+func TestRedeemPromo(t *testing.T) {
+	tests := []struct {
+		name         string
+		discountType domain.DiscountType
+		value        int
+		price        int
+		wantDiscount int
+		wantErr      bool
+	}{
+		{"percentage", domain.DiscountPercentage, 10, 150000, 15000, false},
+		{"fixed", domain.DiscountFixed, 20000, 150000, 20000, false},
+		{"percentage rounds down to zero", domain.DiscountPercentage, 1, 99, 0, true},
+		{"fixed covering the full price", domain.DiscountFixed, 150000, 150000, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promo := &domain.PromoCode{ID: 1, Code: "HEMAT", DiscountType: tt.discountType, DiscountValue: tt.value, Active: true}
+			repos := &repository.Repositories{Promos: &fakePromoCodeRepo{promo: promo}}
+
+			booking := &domain.Booking{UserID: 1, FieldID: 1}
+			booking.SetPrice(domain.PriceBreakdown{Total: tt.price})
+
+			got, err := redeemPromo(context.Background(), repos, " hemat ", booking, time.Now())
+			if tt.wantErr {
+				var validation *domain.ValidationError
+				if !errors.As(err, &validation) {
+					t.Fatalf("redeemPromo error = %v, want ValidationError", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("redeemPromo: %v", err)
+			}
+			if got != promo {
+				t.Fatalf("redeemPromo returned %+v, want %+v", got, promo)
+			}
+			if booking.DiscountAmount != tt.wantDiscount || booking.TotalPrice != tt.price-tt.wantDiscount {
+				t.Fatalf("booking discount %d total %d, want %d %d", booking.DiscountAmount, booking.TotalPrice, tt.wantDiscount, tt.price-tt.wantDiscount)
+			}
+		})
+	}
+}
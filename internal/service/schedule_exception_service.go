@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"futsal-booking-app/internal/authz"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"strings"
+	"time"
+)
+
+// ScheduleExceptionInput berisi perubahan jadwal pada satu tanggal. StartTime
+// dan EndTime (HH:MM, "24:00" untuk akhir hari) wajib untuk CLOSED_WINDOW
+// dan SPECIAL_HOURS, dan diabaikan untuk CLOSED.
+type ScheduleExceptionInput struct {
+	Date      time.Time
+	Type      domain.ScheduleExceptionType
+	StartTime string
+	EndTime   string
+	Reason    string
+}
+
+func (in ScheduleExceptionInput) toException(fieldID int, now time.Time) (*domain.ScheduleException, error) {
+	exception := &domain.ScheduleException{
+		FieldID:     fieldID,
+		Date:        time.Date(in.Date.Year(), in.Date.Month(), in.Date.Day(), 0, 0, 0, 0, time.UTC),
+		Type:        in.Type,
+		StartMinute: 0,
+		EndMinute:   domain.MinutesPerDay,
+		Reason:      strings.TrimSpace(in.Reason),
+		CreatedAt:   now,
+	}
+
+	switch in.Type {
+	case domain.ExceptionClosed:
+		return exception, nil
+	case domain.ExceptionClosedWindow, domain.ExceptionSpecialHours:
+	default:
+		return nil, domain.Invalidf("exception type must be CLOSED, CLOSED_WINDOW, or SPECIAL_HOURS")
+	}
+
+	start, err := parseMinuteOfDay(in.StartTime)
+	if err != nil {
+		return nil, domain.Invalidf("invalid start time format: %s", in.StartTime)
+	}
+
+	end, err := parseMinuteOfDay(in.EndTime)
+	if err != nil {
+		return nil, domain.Invalidf("invalid end time format: %s", in.EndTime)
+	}
+
+	if end <= start {
+		return nil, domain.Invalidf("end time must be after start time")
+	}
+
+	exception.StartMinute = start
+	exception.EndMinute = end
+
+	return exception, nil
+}
+
+// ScheduleExceptionResult berisi exception yang baru dibuat beserta booking
+// aktif yang kini jatuh di luar jam buka dan perlu diselesaikan owner.
+type ScheduleExceptionResult struct {
+	Exception *domain.ScheduleException
+	Conflicts []*domain.Booking
+}
+
+// AddScheduleException menambah perubahan jadwal pada satu tanggal
+// Business logic:
+// 1. Hanya owner lapangan (atau admin)
+// 2. Tanggal tidak boleh sudah lewat menurut zona waktu lapangan
+// 3. Exception langsung berlaku untuk slot dan booking baru
+// 4. Booking aktif yang tidak lagi berada di dalam jam buka dikembalikan sebagai konflik; booking tersebut tidak dibatalkan otomatis
+func (u *fieldService) AddScheduleException(ctx context.Context, actor *domain.User, fieldID int, input ScheduleExceptionInput) (*ScheduleExceptionResult, error) {
+	field, err := u.authorizeSchedule(ctx, actor, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	exception, err := input.toException(field.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	local := now.In(field.Location())
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	if exception.Date.Before(today) {
+		return nil, domain.Invalidf("cannot add an exception for a past date")
+	}
+
+	var conflicts []*domain.Booking
+
+	// Konflik dihitung di transaksi yang sama supaya exception tidak tersimpan
+	// tanpa daftar konflik yang dikembalikan ke owner.
+	err = u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		if err := repos.Fields.CreateScheduleException(ctx, exception); err != nil {
+			return err
+		}
+
+		var err error
+		conflicts, err = exceptionConflicts(ctx, repos.Fields, repos.Bookings, field, exception)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScheduleExceptionResult{Exception: exception, Conflicts: conflicts}, nil
+}
+
+func (u *fieldService) GetScheduleExceptions(ctx context.Context, fieldID int, from, to time.Time) ([]*domain.ScheduleException, error) {
+	if fieldID <= 0 {
+		return nil, domain.Invalidf("invalid field ID")
+	}
+
+	if to.Before(from) {
+		return nil, domain.Invalidf("to date must not be before from date")
+	}
+
+	exceptions, err := u.fieldRepo.FindScheduleExceptions(ctx, fieldID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching schedule exceptions: %w", err)
+	}
+
+	return exceptions, nil
+}
+
+func (u *fieldService) DeleteScheduleException(ctx context.Context, actor *domain.User, fieldID, exceptionID int) error {
+	if _, err := u.authorizeSchedule(ctx, actor, fieldID); err != nil {
+		return err
+	}
+
+	return u.fieldRepo.DeleteScheduleException(ctx, fieldID, exceptionID)
+}
+
+// GetScheduleExceptionConflicts menghitung ulang booking aktif yang jatuh di
+// luar jam buka pada tanggal exception, misalnya setelah owner memindahkan
+// sebagian booking.
+func (u *fieldService) GetScheduleExceptionConflicts(ctx context.Context, actor *domain.User, fieldID, exceptionID int) ([]*domain.Booking, error) {
+	field, err := u.authorizeSchedule(ctx, actor, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	exception, err := u.fieldRepo.FindScheduleExceptionByID(ctx, fieldID, exceptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return exceptionConflicts(ctx, u.fieldRepo, u.bookingRepo, field, exception)
+}
+
+// exceptionConflicts mengambil booking aktif yang beririsan dengan tanggal
+// exception, termasuk jendela jadwal yang berlanjut melewati tengah malam,
+// lalu menyaring yang tidak lagi berada di dalam jam buka.
+func exceptionConflicts(ctx context.Context, fields repository.FieldRepository, bookings repository.BookingRepository, field *domain.Field, exception *domain.ScheduleException) ([]*domain.Booking, error) {
+	loc := field.Location()
+	dayStart := time.Date(exception.Date.Year(), exception.Date.Month(), exception.Date.Day(), 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	schedules, exceptions, err := openingHours(ctx, fields, field, dayStart, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range domain.OpenWindowsOn(schedules, nil, dayStart) {
+		if w.End.After(dayEnd) {
+			dayEnd = w.End
+		}
+	}
+
+	active, err := bookings.FindConflictingBookings(ctx, field.ID, dayStart, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []*domain.Booking{}
+	for _, booking := range active {
+		if !domain.IsOpenBetween(schedules, exceptions, booking.StartTime.In(loc), booking.EndTime.In(loc)) {
+			conflicts = append(conflicts, booking)
+		}
+	}
+
+	return conflicts, nil
+}
+
+func (u *fieldService) authorizeSchedule(ctx context.Context, actor *domain.User, fieldID int) (*domain.Field, error) {
+	if fieldID <= 0 {
+		return nil, domain.Invalidf("invalid field ID")
+	}
+
+	field, err := u.fieldRepo.FindByID(ctx, fieldID)
+	if err != nil {
+		return nil, domain.ErrFieldNotFound
+	}
+
+	if err := u.policy.Authorize(actor, authz.ActionFieldManageSchedule, authz.Resource{Field: field}); err != nil {
+		return nil, err
+	}
+
+	return field, nil
+}
+
+// openingHours mengambil jadwal mingguan dan exception lapangan yang
+// dibutuhkan untuk menghitung jam buka antara from dan to. Tanggal exception
+// dibaca di zona waktu lapangan, dan exception sehari sebelum from ikut
+// diambil karena jendelanya bisa melewati tengah malam.
+func openingHours(ctx context.Context, fields repository.FieldRepository, field *domain.Field, from, to time.Time) ([]*domain.Schedule, []*domain.ScheduleException, error) {
+	schedules, err := fields.FindScheduleByFieldID(ctx, field.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching schedules: %w", err)
+	}
+
+	loc := field.Location()
+	exceptions, err := fields.FindScheduleExceptions(ctx, field.ID, from.In(loc).AddDate(0, 0, -1), to.In(loc))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching schedule exceptions: %w", err)
+	}
+
+	return schedules, exceptions, nil
+}
+
+// ensureOpen menolak rentang booking yang jatuh di luar jam buka lapangan,
+// termasuk tanggal yang ditutup lewat schedule exception. Jam buka dibaca di
+// zona waktu lapangan.
+func ensureOpen(ctx context.Context, fields repository.FieldRepository, field *domain.Field, start, end time.Time) error {
+	schedules, exceptions, err := openingHours(ctx, fields, field, start, end)
+	if err != nil {
+		return err
+	}
+
+	if !isOpen(field, schedules, exceptions, start, end) {
+		return domain.Invalidf("field is closed at the requested time")
+	}
+
+	return nil
+}
+
+// isOpen mengecek rentang booking terhadap jam buka yang sudah dimuat
+// openingHours, termasuk jendela yang melewati tengah malam.
+func isOpen(field *domain.Field, schedules []*domain.Schedule, exceptions []*domain.ScheduleException, start, end time.Time) bool {
+	loc := field.Location()
+	return domain.IsOpenBetween(schedules, exceptions, start.In(loc), end.In(loc))
+}
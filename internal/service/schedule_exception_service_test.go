@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"futsal-booking-app/internal/authz"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"testing"
+	"time"
+)
+
+// fakeFieldLookupRepo hanya melayani pencarian field di luar transaksi.
+type fakeFieldLookupRepo struct {
+	repository.FieldRepository
+	field *domain.Field
+}
+
+func (r *fakeFieldLookupRepo) FindByID(ctx context.Context, id int) (*domain.Field, error) {
+	if r.field.ID != id {
+		return nil, domain.ErrFieldNotFound
+	}
+	return r.field, nil
+}
+
+type fakeScheduleRepo struct {
+	repository.FieldRepository
+	schedules  []*domain.Schedule
+	exceptions []*domain.ScheduleException
+}
+
+func (r *fakeScheduleRepo) FindScheduleByFieldID(ctx context.Context, fieldID int) ([]*domain.Schedule, error) {
+	return r.schedules, nil
+}
+
+func (r *fakeScheduleRepo) FindScheduleExceptions(ctx context.Context, fieldID int, from, to time.Time) ([]*domain.ScheduleException, error) {
+	return r.exceptions, nil
+}
+
+func (r *fakeScheduleRepo) CreateScheduleException(ctx context.Context, exception *domain.ScheduleException) error {
+	exception.ID = len(r.exceptions) + 1
+	r.exceptions = append(r.exceptions, exception)
+	return nil
+}
+
+type fakeConflictBookingRepo struct {
+	repository.BookingRepository
+	bookings []*domain.Booking
+	err      error
+}
+
+func (r *fakeConflictBookingRepo) FindConflictingBookings(ctx context.Context, fieldID int, startTime, endTime time.Time) ([]*domain.Booking, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	result := []*domain.Booking{}
+	for _, b := range r.bookings {
+		if b.StartTime.Before(endTime) && b.EndTime.After(startTime) {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+func TestAddScheduleException(t *testing.T) {
+	owner := &domain.User{ID: 1, Role: domain.RoleOwner}
+	field := &domain.Field{ID: 1, OwnerID: owner.ID, TimeZone: "UTC"}
+
+	// Buka setiap hari 08:00 sampai 22:00
+	schedules := []*domain.Schedule{}
+	for day := domain.Sunday; day <= domain.Saturday; day++ {
+		schedules = append(schedules, &domain.Schedule{
+			FieldID:   field.ID,
+			DayOfWeek: day,
+			OpenTime:  time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC),
+			CloseTime: time.Date(0, 1, 1, 22, 0, 0, 0, time.UTC),
+		})
+	}
+
+	date := time.Now().UTC().AddDate(0, 0, 7)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	morning := &domain.Booking{ID: 1, StartTime: day.Add(10 * time.Hour), EndTime: day.Add(11 * time.Hour)}
+	evening := &domain.Booking{ID: 2, StartTime: day.Add(19 * time.Hour), EndTime: day.Add(20 * time.Hour)}
+
+	input := ScheduleExceptionInput{Date: day, Type: domain.ExceptionClosedWindow, StartTime: "18:00", EndTime: "20:00", Reason: "turnamen"}
+
+	tests := []struct {
+		name          string
+		bookingErr    error
+		wantConflicts []int
+		wantErr       error
+	}{
+		{"conflicts computed with the insert", nil, []int{2}, nil},
+		{"conflict lookup failure fails the transaction", errors.New("connection lost"), nil, errors.New("connection lost")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := &fakeScheduleRepo{schedules: schedules}
+			bookings := &fakeConflictBookingRepo{bookings: []*domain.Booking{morning, evening}, err: tt.bookingErr}
+
+			var doErr error
+			uow := unitOfWorkFunc(func(ctx context.Context, fn func(repos *repository.Repositories) error) error {
+				doErr = fn(&repository.Repositories{Fields: fields, Bookings: bookings})
+				return doErr
+			})
+
+			// fieldRepo dan bookingRepo di luar transaksi tidak boleh dipakai
+			// untuk menyimpan exception maupun menghitung konflik.
+			svc := &fieldService{uow: uow, fieldRepo: &fakeFieldLookupRepo{field: field}, policy: authz.NewPolicy()}
+
+			result, err := svc.AddScheduleException(context.Background(), owner, field.ID, input)
+
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("AddScheduleException error = %v, want %v", err, tt.wantErr)
+				}
+				if doErr == nil {
+					t.Fatal("transaction committed although the conflict lookup failed")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("AddScheduleException: %v", err)
+			}
+			if result.Exception.ID == 0 || len(fields.exceptions) != 1 {
+				t.Fatalf("exception = %+v, stored %d", result.Exception, len(fields.exceptions))
+			}
+
+			ids := []int{}
+			for _, b := range result.Conflicts {
+				ids = append(ids, b.ID)
+			}
+			if len(ids) != len(tt.wantConflicts) || (len(ids) > 0 && ids[0] != tt.wantConflicts[0]) {
+				t.Fatalf("conflicts = %v, want %v", ids, tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestAddScheduleExceptionRejectsPastDate(t *testing.T) {
+	owner := &domain.User{ID: 1, Role: domain.RoleOwner}
+	field := &domain.Field{ID: 1, OwnerID: owner.ID, TimeZone: "UTC"}
+
+	uow := unitOfWorkFunc(func(ctx context.Context, fn func(repos *repository.Repositories) error) error {
+		t.Fatal("transaction started for a past date")
+		return nil
+	})
+	svc := &fieldService{uow: uow, fieldRepo: &fakeFieldLookupRepo{field: field}, policy: authz.NewPolicy()}
+
+	input := ScheduleExceptionInput{Date: time.Now().UTC().AddDate(0, 0, -1), Type: domain.ExceptionClosed}
+
+	_, err := svc.AddScheduleException(context.Background(), owner, field.ID, input)
+
+	var validation *domain.ValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("AddScheduleException error = %v, want ValidationError", err)
+	}
+}
+
+// unitOfWorkFunc membuat UnitOfWork dari fungsi, dipakai test yang perlu
+// melihat hasil fn.
+type unitOfWorkFunc func(ctx context.Context, fn func(repos *repository.Repositories) error) error
+
+func (f unitOfWorkFunc) Do(ctx context.Context, fn func(repos *repository.Repositories) error) error {
+	return f(ctx, fn)
+}
+
+func TestEnsureOpenUsesFieldTimeZone(t *testing.T) {
+	// Buka Jumat 08:00 sampai 22:00 waktu lapangan
+	fields := &fakeScheduleRepo{schedules: []*domain.Schedule{{
+		FieldID:   1,
+		DayOfWeek: domain.Friday,
+		OpenTime:  time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC),
+		CloseTime: time.Date(0, 1, 1, 22, 0, 0, 0, time.UTC),
+	}}}
+	// Jumat 2026-03-06 dalam UTC
+	utc := func(hour int) time.Time { return time.Date(2026, 3, 6, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		timeZone string
+		start    time.Time
+		wantOpen bool
+	}{
+		{"WIB evening", "Asia/Jakarta", utc(14), true},
+		{"same instant after closing in WITA", "Asia/Makassar", utc(14), false},
+		{"WIT evening", "Asia/Jayapura", utc(12), true},
+		{"before opening in WIB", "Asia/Jakarta", utc(0), false},
+		{"empty time zone defaults to WIB", "", utc(14), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := &domain.Field{ID: 1, TimeZone: tt.timeZone}
+
+			err := ensureOpen(context.Background(), fields, field, tt.start, tt.start.Add(time.Hour))
+
+			if tt.wantOpen && err != nil {
+				t.Fatalf("ensureOpen error = %v, want nil", err)
+			}
+			if !tt.wantOpen && !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("ensureOpen error = %v, want validation error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"futsal-booking-app/internal/authz"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"strings"
+	"time"
+)
+
+type VenueService interface {
+	CreateVenue(ctx context.Context, actor *domain.User, input VenueInput) (*domain.Venue, error)
+	GetVenueByID(ctx context.Context, id int) (*domain.Venue, error)
+	GetAllVenues(ctx context.Context) ([]*domain.Venue, error)
+	GetVenuesByOwnerID(ctx context.Context, ownerID int) ([]*domain.Venue, error)
+	UpdateVenue(ctx context.Context, actor *domain.User, venueID int, input VenueInput) (*domain.Venue, error)
+	DeleteVenue(ctx context.Context, actor *domain.User, venueID int) error
+
+	GetVenueCourts(ctx context.Context, venueID int) ([]*domain.Field, error)
+
+	SetupVenueSchedules(ctx context.Context, actor *domain.User, venueID int, schedules []ScheduleInput) ([]*domain.Schedule, error)
+	GetVenueSchedules(ctx context.Context, venueID int) ([]*domain.Schedule, error)
+
+	FindAvailableCourts(ctx context.Context, venueID int, startTime time.Time, durationMinutes int) ([]CourtAvailability, error)
+}
+
+// VenueInput berisi data venue yang bisa diatur owner saat create/update.
+type VenueInput struct {
+	Name        string
+	Address     string
+	Description string
+	ImageURL    string
+	Amenities   []string
+	// Coordinates adalah titik peta venue. Nil menghapus koordinat.
+	Coordinates *domain.GeoPoint
+	// TimeZone adalah nama zona waktu IANA, misalnya "Asia/Makassar". Kosong
+	// berarti domain.DefaultTimeZone saat create dan tidak berubah saat update.
+	TimeZone string
+}
+
+func (in VenueInput) validate() error {
+	if strings.TrimSpace(in.Name) == "" {
+		return domain.Invalidf("venue name cannot be empty")
+	}
+
+	if strings.TrimSpace(in.Address) == "" {
+		return domain.Invalidf("venue address cannot be empty")
+	}
+
+	if in.Coordinates != nil && !in.Coordinates.IsValid() {
+		return domain.Invalidf("invalid coordinates")
+	}
+
+	return validateTimeZone(in.TimeZone)
+}
+
+func (in VenueInput) applyTo(venue *domain.Venue) {
+	venue.Name = in.Name
+	venue.Address = in.Address
+	venue.Description = in.Description
+	venue.ImageURL = in.ImageURL
+	venue.Coordinates = in.Coordinates
+
+	switch {
+	case in.TimeZone != "":
+		venue.TimeZone = in.TimeZone
+	case venue.TimeZone == "":
+		venue.TimeZone = domain.DefaultTimeZone
+	}
+
+	venue.Amenities = []string{}
+	for _, amenity := range in.Amenities {
+		if amenity = strings.TrimSpace(amenity); amenity != "" {
+			venue.Amenities = append(venue.Amenities, amenity)
+		}
+	}
+}
+
+// CourtAvailability adalah status satu court di venue untuk rentang waktu
+// yang diminta. Reason menjelaskan kenapa court tidak tersedia.
+type CourtAvailability struct {
+	Field     *domain.Field
+	Available bool
+	Price     int
+	Reason    string
+}
+
+type venueService struct {
+	uow         repository.UnitOfWork
+	venueRepo   repository.VenueRepository
+	fieldRepo   repository.FieldRepository
+	bookingRepo repository.BookingRepository
+	policy      *authz.Policy
+}
+
+func NewVenueService(uow repository.UnitOfWork, venueRepo repository.VenueRepository, fieldRepo repository.FieldRepository, bookingRepo repository.BookingRepository, policy *authz.Policy) VenueService {
+	return &venueService{uow: uow, venueRepo: venueRepo, fieldRepo: fieldRepo, bookingRepo: bookingRepo, policy: policy}
+}
+
+// CreateVenue membuat venue baru tanpa court
+// Business logic:
+// 1. Hanya owner (atau admin) yang boleh membuat venue
+// 2. Validasi input (name dan address tidak boleh kosong)
+// 3. Simpan venue dengan actor sebagai owner; court ditambahkan lewat CreateField
+func (u *venueService) CreateVenue(ctx context.Context, actor *domain.User, input VenueInput) (*domain.Venue, error) {
+	if err := u.policy.Authorize(actor, authz.ActionVenueCreate, authz.Resource{}); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	venue := &domain.Venue{
+		OwnerID:   actor.ID,
+		CreatedAt: time.Now(),
+	}
+	input.applyTo(venue)
+
+	if err := u.venueRepo.Create(ctx, venue); err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (u *venueService) GetVenueByID(ctx context.Context, id int) (*domain.Venue, error) {
+	if id <= 0 {
+		return nil, domain.Invalidf("invalid venue ID")
+	}
+
+	return u.venueRepo.FindByID(ctx, id)
+}
+
+func (u *venueService) GetAllVenues(ctx context.Context) ([]*domain.Venue, error) {
+	venues, err := u.venueRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching venues: %w", err)
+	}
+
+	return venues, nil
+}
+
+func (u *venueService) GetVenuesByOwnerID(ctx context.Context, ownerID int) ([]*domain.Venue, error) {
+	if ownerID == 0 {
+		return nil, domain.Invalidf("invalid owner ID")
+	}
+
+	venues, err := u.venueRepo.FindByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching venues: %w", err)
+	}
+
+	return venues, nil
+}
+
+// UpdateVenue mengubah data venue. Alamat, foto, dan zona waktu baru langsung
+// berlaku untuk semua court di venue karena court membacanya dari venue.
+// Booking yang sudah ada tidak bergeser karena disimpan sebagai timestamptz.
+func (u *venueService) UpdateVenue(ctx context.Context, actor *domain.User, venueID int, input VenueInput) (*domain.Venue, error) {
+	venue, err := u.authorizeVenue(ctx, actor, authz.ActionVenueUpdate, venueID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	input.applyTo(venue)
+
+	if err := u.venueRepo.Update(ctx, venue); err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+// DeleteVenue menghapus venue beserta semua court-nya.
+func (u *venueService) DeleteVenue(ctx context.Context, actor *domain.User, venueID int) error {
+	if _, err := u.authorizeVenue(ctx, actor, authz.ActionVenueDelete, venueID); err != nil {
+		return err
+	}
+
+	return u.venueRepo.Delete(ctx, venueID)
+}
+
+func (u *venueService) GetVenueCourts(ctx context.Context, venueID int) ([]*domain.Field, error) {
+	if _, err := u.GetVenueByID(ctx, venueID); err != nil {
+		return nil, err
+	}
+
+	fields, err := u.fieldRepo.FindByVenueID(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching courts: %w", err)
+	}
+
+	return fields, nil
+}
+
+// SetupVenueSchedules mengganti jam buka default venue. Jadwal court yang
+// sudah ada tidak berubah; jam buka default hanya disalin ke court baru.
+func (u *venueService) SetupVenueSchedules(ctx context.Context, actor *domain.User, venueID int, inputs []ScheduleInput) ([]*domain.Schedule, error) {
+	if _, err := u.authorizeVenue(ctx, actor, authz.ActionVenueManageSchedule, venueID); err != nil {
+		return nil, err
+	}
+
+	schedules, err := parseSchedules(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		if err := repos.Venues.DeleteSchedulesByVenueID(ctx, venueID); err != nil {
+			return err
+		}
+
+		for _, schedule := range schedules {
+			if err := repos.Venues.CreateSchedule(ctx, venueID, schedule); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (u *venueService) GetVenueSchedules(ctx context.Context, venueID int) ([]*domain.Schedule, error) {
+	if venueID <= 0 {
+		return nil, domain.Invalidf("invalid venue ID")
+	}
+
+	schedules, err := u.venueRepo.FindSchedulesByVenueID(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching venue schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// FindAvailableCourts mengecek semua court di venue untuk rentang
+// [startTime, startTime+durationMinutes)
+// Business logic:
+// 1. Court yang tidak menerima durasi atau jam mulai tersebut (SlotPolicy) tidak tersedia
+// 2. Court yang tutup pada rentang tersebut menurut jadwal dan schedule exception-nya tidak tersedia
+// 3. Court yang bentrok dengan booking aktif (termasuk buffer court) tidak tersedia
+// 4. Court yang tersedia disertai harganya sesuai pricing rule court
+func (u *venueService) FindAvailableCourts(ctx context.Context, venueID int, startTime time.Time, durationMinutes int) ([]CourtAvailability, error) {
+	if durationMinutes <= 0 {
+		return nil, domain.Invalidf("duration must be positive")
+	}
+
+	courts, err := u.GetVenueCourts(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+
+	endTime := startTime.Add(time.Duration(durationMinutes) * time.Minute)
+	result := make([]CourtAvailability, 0, len(courts))
+
+	for _, court := range courts {
+		availability, err := u.courtAvailability(ctx, court, startTime, endTime, durationMinutes)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, availability)
+	}
+
+	return result, nil
+}
+
+func (u *venueService) courtAvailability(ctx context.Context, court *domain.Field, startTime, endTime time.Time, durationMinutes int) (CourtAvailability, error) {
+	availability := CourtAvailability{Field: court}
+
+	if err := validateDuration(court, startTime, durationMinutes); err != nil {
+		availability.Reason = err.Error()
+		return availability, nil
+	}
+
+	schedules, exceptions, err := openingHours(ctx, u.fieldRepo, court, startTime, endTime)
+	if err != nil {
+		return availability, err
+	}
+
+	loc := court.Location()
+	if !domain.IsOpenBetween(schedules, exceptions, startTime.In(loc), endTime.In(loc)) {
+		availability.Reason = "court is closed at this time"
+		return availability, nil
+	}
+
+	free, err := u.bookingRepo.CheckAvailability(ctx, court.ID, startTime, endTime.Add(court.SlotPolicy.Buffer()))
+	if err != nil {
+		return availability, fmt.Errorf("error checking availability: %w", err)
+	}
+
+	if !free {
+		availability.Reason = domain.ErrSlotNotAvailable.Error()
+		return availability, nil
+	}
+
+	price, err := quotePrice(ctx, u.fieldRepo, court, startTime, endTime)
+	if errors.Is(err, domain.ErrValidation) {
+		availability.Reason = err.Error()
+		return availability, nil
+	}
+	if err != nil {
+		return availability, fmt.Errorf("error calculating price: %w", err)
+	}
+
+	availability.Available = true
+	availability.Price = price.Total
+
+	return availability, nil
+}
+
+// validateTimeZone memastikan name adalah zona waktu IANA yang dikenal.
+// Nilai kosong diterima dan diganti default oleh pemanggil.
+func validateTimeZone(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	if _, err := domain.LoadTimeZone(name); err != nil {
+		return domain.Invalidf("invalid time zone: %s", name)
+	}
+
+	return nil
+}
+
+func (u *venueService) authorizeVenue(ctx context.Context, actor *domain.User, action authz.Action, venueID int) (*domain.Venue, error) {
+	if venueID <= 0 {
+		return nil, domain.Invalidf("invalid venue ID")
+	}
+
+	venue, err := u.venueRepo.FindByID(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.policy.Authorize(actor, action, authz.Resource{Venue: venue}); err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
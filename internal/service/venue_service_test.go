@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"testing"
+	"time"
+)
+
+type fakeVenueLookupRepo struct {
+	repository.VenueRepository
+	venue *domain.Venue
+}
+
+func (r *fakeVenueLookupRepo) FindByID(ctx context.Context, id int) (*domain.Venue, error) {
+	if r.venue.ID != id {
+		return nil, domain.ErrVenueNotFound
+	}
+	return r.venue, nil
+}
+
+// fakeCourtRepo menyimpan court satu venue beserta jadwal dan exception per
+// court.
+type fakeCourtRepo struct {
+	repository.FieldRepository
+	courts     []*domain.Field
+	schedules  map[int][]*domain.Schedule
+	exceptions map[int][]*domain.ScheduleException
+}
+
+func (r *fakeCourtRepo) FindByVenueID(ctx context.Context, venueID int) ([]*domain.Field, error) {
+	return r.courts, nil
+}
+
+func (r *fakeCourtRepo) FindScheduleByFieldID(ctx context.Context, fieldID int) ([]*domain.Schedule, error) {
+	return r.schedules[fieldID], nil
+}
+
+func (r *fakeCourtRepo) FindScheduleExceptions(ctx context.Context, fieldID int, from, to time.Time) ([]*domain.ScheduleException, error) {
+	return r.exceptions[fieldID], nil
+}
+
+func (r *fakeCourtRepo) FindPricingRulesByFieldID(ctx context.Context, fieldID int) ([]*domain.PricingRule, error) {
+	return nil, nil
+}
+
+type fakeAvailabilityBookingRepo struct {
+	repository.BookingRepository
+	bookings []*domain.Booking
+}
+
+func (r *fakeAvailabilityBookingRepo) CheckAvailability(ctx context.Context, fieldID int, startTime, endTime time.Time) (bool, error) {
+	for _, b := range r.bookings {
+		if b.FieldID == fieldID && b.StartTime.Before(endTime) && b.EndTime.After(startTime) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func TestFindAvailableCourts(t *testing.T) {
+	venue := &domain.Venue{ID: 1, OwnerID: 1}
+	clock := func(hour int) time.Time { return time.Date(0, 1, 1, hour, 0, 0, 0, time.UTC) }
+	// Jumat 2026-03-06
+	day := func(hour, minute int) time.Time { return time.Date(2026, 3, 6, hour, minute, 0, 0, time.UTC) }
+
+	court := func(id int, policy domain.SlotPolicy) *domain.Field {
+		return &domain.Field{ID: id, VenueID: venue.ID, TimeZone: "UTC", PricePerHour: 100000, SlotPolicy: policy}
+	}
+	buffered := domain.DefaultSlotPolicy
+	buffered.BufferMinutes = 30
+	ninetyMinutes := domain.SlotPolicy{SlotMinutes: 90, MinDurationMinutes: 90}
+
+	courts := []*domain.Field{
+		court(1, domain.DefaultSlotPolicy),
+		court(2, domain.DefaultSlotPolicy),
+		court(3, buffered),
+		court(4, domain.DefaultSlotPolicy),
+		court(5, ninetyMinutes),
+		court(6, domain.DefaultSlotPolicy),
+	}
+
+	schedules := map[int][]*domain.Schedule{}
+	for _, c := range courts {
+		schedules[c.ID] = []*domain.Schedule{{FieldID: c.ID, DayOfWeek: domain.Friday, OpenTime: clock(8), CloseTime: clock(22)}}
+	}
+	// Court 4 tutup lebih awal
+	schedules[4] = []*domain.Schedule{{FieldID: 4, DayOfWeek: domain.Friday, OpenTime: clock(8), CloseTime: clock(18)}}
+
+	// Court 6 ditutup untuk turnamen pada tanggal tersebut
+	exceptions := map[int][]*domain.ScheduleException{
+		6: {{FieldID: 6, Date: day(0, 0), Type: domain.ExceptionClosedWindow, StartMinute: 18 * 60, EndMinute: 22 * 60}},
+	}
+
+	bookings := &fakeAvailabilityBookingRepo{bookings: []*domain.Booking{
+		{FieldID: 2, StartTime: day(19, 0), EndTime: day(20, 0)},
+		// Bentrok dengan buffer 30 menit court 3 setelah 20:00
+		{FieldID: 3, StartTime: day(20, 15), EndTime: day(21, 0)},
+	}}
+
+	svc := &venueService{
+		venueRepo:   &fakeVenueLookupRepo{venue: venue},
+		fieldRepo:   &fakeCourtRepo{courts: courts, schedules: schedules, exceptions: exceptions},
+		bookingRepo: bookings,
+	}
+
+	got, err := svc.FindAvailableCourts(context.Background(), venue.ID, day(19, 0), 60)
+	if err != nil {
+		t.Fatalf("FindAvailableCourts: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		available bool
+		price     int
+		reason    string
+	}{
+		{"free court", true, 100000, ""},
+		{"booked court", false, 0, domain.ErrSlotNotAvailable.Error()},
+		{"buffer overlaps next booking", false, 0, domain.ErrSlotNotAvailable.Error()},
+		{"court closed", false, 0, "court is closed at this time"},
+		{"start not on slot grid", false, 0, "start time must be aligned to 90-minute slots"},
+		{"closed by schedule exception", false, 0, "court is closed at this time"},
+	}
+
+	if len(got) != len(tests) {
+		t.Fatalf("got %d courts, want %d", len(got), len(tests))
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := got[i]
+			if c.Field.ID != courts[i].ID {
+				t.Fatalf("court = %d, want %d", c.Field.ID, courts[i].ID)
+			}
+			if c.Available != tt.available || c.Price != tt.price || c.Reason != tt.reason {
+				t.Errorf("availability = %v %d %q, want %v %d %q", c.Available, c.Price, c.Reason, tt.available, tt.price, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,422 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"futsal-booking-app/internal/authz"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/notification"
+	"futsal-booking-app/internal/payment"
+	"futsal-booking-app/internal/repository"
+	"log"
+	"math/rand"
+	"time"
+)
+
+type WaitlistService interface {
+	JoinWaitlist(ctx context.Context, actor *domain.User, fieldID int, startTime time.Time, durationMinutes int) (*domain.WaitlistEntry, error)
+	GetMyWaitlist(ctx context.Context, userID int) ([]*domain.WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, actor *domain.User, entryID int) error
+	AcceptOffer(ctx context.Context, actor *domain.User, entryID int) (*domain.Booking, error)
+
+	ProcessWaitlist(ctx context.Context, limit int) (int, error)
+}
+
+type waitlistService struct {
+	uow          repository.UnitOfWork
+	waitlistRepo repository.WaitlistRepository
+	fieldRepo    repository.FieldRepository
+	bookingRepo  repository.BookingRepository
+	userRepo     repository.UserRepository
+	gateway      payment.Gateway
+	notifier     notification.Notifier
+	policy       *authz.Policy
+}
+
+func NewWaitlistService(uow repository.UnitOfWork, waitlistRepo repository.WaitlistRepository, fieldRepo repository.FieldRepository, bookingRepo repository.BookingRepository, userRepo repository.UserRepository, gateway payment.Gateway, notifier notification.Notifier, policy *authz.Policy) WaitlistService {
+	return &waitlistService{
+		uow:          uow,
+		waitlistRepo: waitlistRepo,
+		fieldRepo:    fieldRepo,
+		bookingRepo:  bookingRepo,
+		userRepo:     userRepo,
+		gateway:      gateway,
+		notifier:     notifier,
+		policy:       policy,
+	}
+}
+
+// JoinWaitlist mendaftarkan customer ke antrean slot yang sedang penuh
+// Business logic:
+// 1. Validasi input, otorisasi actor (hanya customer), dan SlotPolicy lapangan
+// 2. Slot yang masih tersedia ditolak, customer diminta langsung booking
+// 3. Satu customer hanya bisa mengantre sekali untuk rentang waktu yang sama
+func (u *waitlistService) JoinWaitlist(ctx context.Context, actor *domain.User, fieldID int, startTime time.Time, durationMinutes int) (*domain.WaitlistEntry, error) {
+	if fieldID <= 0 {
+		return nil, domain.Invalidf("invalid field ID")
+	}
+
+	if durationMinutes <= 0 {
+		return nil, domain.Invalidf("duration must be positive")
+	}
+
+	now := time.Now()
+	if startTime.Before(now) {
+		return nil, domain.Invalidf("cannot join the waitlist for a past time slot")
+	}
+
+	field, err := u.fieldRepo.FindByID(ctx, fieldID)
+	if err != nil {
+		return nil, domain.ErrFieldNotFound
+	}
+
+	if err := u.policy.Authorize(actor, authz.ActionWaitlistJoin, authz.Resource{Field: field}); err != nil {
+		return nil, err
+	}
+
+	if err := validateDuration(field, startTime, durationMinutes); err != nil {
+		return nil, err
+	}
+
+	endTime := startTime.Add(time.Duration(durationMinutes) * time.Minute)
+
+	if err := ensureOpen(ctx, u.fieldRepo, field, startTime, endTime); err != nil {
+		return nil, err
+	}
+
+	available, err := u.bookingRepo.CheckAvailability(ctx, fieldID, startTime, endTime.Add(field.SlotPolicy.Buffer()))
+	if err != nil {
+		return nil, fmt.Errorf("error checking availability: %w", err)
+	}
+
+	if available {
+		return nil, domain.Invalidf("time slot is available, book it directly")
+	}
+
+	entry := &domain.WaitlistEntry{
+		UserID:    actor.ID,
+		FieldID:   fieldID,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Status:    domain.WaitlistWaiting,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := u.waitlistRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (u *waitlistService) GetMyWaitlist(ctx context.Context, userID int) ([]*domain.WaitlistEntry, error) {
+	if userID <= 0 {
+		return nil, domain.Invalidf("invalid user ID")
+	}
+
+	entries, err := u.waitlistRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching waitlist: %w", err)
+	}
+
+	return entries, nil
+}
+
+// LeaveWaitlist mengeluarkan customer dari antrean, termasuk menolak penawaran
+// yang sedang aktif. Slot yang dilepas ditawarkan ke antrean berikutnya oleh
+// ProcessWaitlist.
+func (u *waitlistService) LeaveWaitlist(ctx context.Context, actor *domain.User, entryID int) error {
+	if _, err := u.authorizeEntry(ctx, actor, entryID); err != nil {
+		return err
+	}
+
+	return u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		entry, err := repos.Waitlist.FindByIDForUpdate(ctx, entryID)
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsOpen() {
+			return domain.Invalidf("waitlist entry is no longer active")
+		}
+
+		entry.Cancel(time.Now())
+		return repos.Waitlist.Update(ctx, entry)
+	})
+}
+
+// AcceptOffer mengubah penawaran waitlist menjadi booking
+// Business logic:
+// 1. Hanya customer pemilik entry (atau admin), dan hanya selama penawaran masih aktif
+// 2. Booking PENDING dan payment dibuat seperti CreateBooking (charge gateway setelah commit), dengan hold pembayaran normal lapangan dan harga sesuai pricing rule saat penawaran diterima
+// 3. Entry ditandai ACCEPTED dalam transaksi yang sama dengan pembuatan booking
+// 4. Owner lapangan diberi notifikasi jika diaktifkan di WaitlistPolicy
+func (u *waitlistService) AcceptOffer(ctx context.Context, actor *domain.User, entryID int) (*domain.Booking, error) {
+	field, err := u.authorizeEntry(ctx, actor, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var booking *domain.Booking
+	var charge *pendingCharge
+
+	err = u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		entry, err := repos.Waitlist.FindByIDForUpdate(ctx, entryID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if !entry.HasActiveOffer(now) {
+			return domain.Invalidf("waitlist entry has no active offer")
+		}
+
+		price, err := quotePrice(ctx, repos.Fields, field, entry.StartTime, entry.EndTime)
+		if err != nil {
+			return fmt.Errorf("error calculating price: %w", err)
+		}
+
+		expiresAt := field.HoldDeadline(now)
+		booking = &domain.Booking{
+			UserID:        entry.UserID,
+			FieldID:       field.ID,
+			StartTime:     entry.StartTime,
+			EndTime:       entry.EndTime,
+			Status:        domain.BookingPending,
+			ExpiresAt:     &expiresAt,
+			BufferMinutes: field.SlotPolicy.BufferMinutes,
+			CreatedAt:     now,
+		}
+		booking.SetPrice(price)
+
+		slotTaken := &domain.SlotTakenError{FieldID: field.ID, StartTime: entry.StartTime, EndTime: entry.EndTime}
+		if err := insertBooking(ctx, repos, booking, actor, slotTaken, now); err != nil {
+			return err
+		}
+
+		charge, err = createPayment(ctx, repos, u.gateway, actor, field, paymentOwner{bookingID: &booking.ID}, booking.TotalPrice, expiresAt, now)
+		if err != nil {
+			return err
+		}
+		booking.PaymentID = &charge.payment.ID
+
+		entry.Accept(booking.ID, now)
+		return repos.Waitlist.Update(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := createCharges(ctx, u.uow, u.gateway, charge); err != nil {
+		return nil, err
+	}
+
+	if field.WaitlistPolicy.NotifyOwner {
+		u.notify(ctx, field.OwnerID, "Waitlist offer accepted", fmt.Sprintf(
+			"%s %s has been booked from the waitlist (booking #%d).",
+			field.Name, formatSlot(booking.StartTime, booking.EndTime), booking.ID,
+		))
+	}
+
+	return booking, nil
+}
+
+// ProcessWaitlist mengakhiri penawaran yang habis dan menawarkan slot kosong ke antrean
+// Business logic:
+// 1. Entry yang penawarannya habis atau slotnya sudah dimulai ditandai EXPIRED
+// 2. Entry WAITING yang seluruh rentangnya kosong (booking dibatalkan/expired, atau penawaran sebelumnya habis) menjadi kandidat
+// 3. Kandidat per lapangan diurutkan sesuai WaitlistPolicy (FIFO atau LOTTERY); kandidat yang bentrok dengan penawaran di putaran yang sama dilewati
+// 4. Penawaran menahan slot selama OfferMinutes, tidak melewati waktu mulai slot
+// 5. Customer diberi notifikasi penawaran dan penawaran yang habis jika diaktifkan di WaitlistPolicy
+// Dipanggil secara berkala oleh scheduler, maksimal limit entry per langkah.
+func (u *waitlistService) ProcessWaitlist(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, domain.Invalidf("limit must be positive")
+	}
+
+	now := time.Now()
+	fields := map[int]*domain.Field{}
+
+	expired, err := u.waitlistRepo.ExpireStale(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, entry := range expired {
+		if entry.OfferedAt == nil {
+			continue
+		}
+
+		field, err := u.cachedField(ctx, fields, entry.FieldID)
+		if err != nil {
+			return 0, err
+		}
+
+		if field.WaitlistPolicy.NotifyCustomer {
+			u.notify(ctx, entry.UserID, "Waitlist offer expired", fmt.Sprintf(
+				"Your offer for %s %s has expired.",
+				field.Name, formatSlot(entry.StartTime, entry.EndTime),
+			))
+		}
+	}
+
+	candidates, err := u.waitlistRepo.FindOfferable(ctx, now, limit)
+	if err != nil {
+		return len(expired), err
+	}
+
+	offered := 0
+	for _, queue := range groupByField(candidates) {
+		field, err := u.cachedField(ctx, fields, queue[0].FieldID)
+		if err != nil {
+			return len(expired) + offered, err
+		}
+
+		if field.WaitlistPolicy.Order == domain.WaitlistLottery {
+			rand.Shuffle(len(queue), func(i, j int) { queue[i], queue[j] = queue[j], queue[i] })
+		}
+
+		var taken []*domain.WaitlistEntry
+		for _, entry := range queue {
+			if overlapsAny(entry, taken) {
+				continue
+			}
+
+			ok, err := u.offer(ctx, entry.ID, field, now)
+			if err != nil {
+				return len(expired) + offered, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			taken = append(taken, entry)
+			offered++
+
+			if field.WaitlistPolicy.NotifyCustomer {
+				u.notify(ctx, entry.UserID, "Waitlist slot available", fmt.Sprintf(
+					"%s %s is available for you until %s. Accept the offer to book it.",
+					field.Name, formatSlot(entry.StartTime, entry.EndTime),
+					field.WaitlistPolicy.OfferDeadline(entry.StartTime, now).Format("2006-01-02 15:04"),
+				))
+			}
+		}
+	}
+
+	return len(expired) + offered, nil
+}
+
+// offer menandai entry sebagai OFFERED jika masih WAITING. Entry yang sudah
+// dibatalkan customer sejak dipilih sebagai kandidat dilewati.
+func (u *waitlistService) offer(ctx context.Context, entryID int, field *domain.Field, now time.Time) (bool, error) {
+	var ok bool
+
+	err := u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		entry, err := repos.Waitlist.FindByIDForUpdate(ctx, entryID)
+		if err != nil {
+			return err
+		}
+
+		if entry.Status != domain.WaitlistWaiting {
+			return nil
+		}
+
+		entry.Offer(field.WaitlistPolicy.OfferDeadline(entry.StartTime, now), now)
+		if err := repos.Waitlist.Update(ctx, entry); err != nil {
+			return err
+		}
+
+		ok = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("error offering waitlist entry %d: %w", entryID, err)
+	}
+
+	return ok, nil
+}
+
+// authorizeEntry memuat entry beserta lapangannya lalu memastikan actor boleh mengelolanya.
+func (u *waitlistService) authorizeEntry(ctx context.Context, actor *domain.User, entryID int) (*domain.Field, error) {
+	if entryID <= 0 {
+		return nil, domain.Invalidf("invalid waitlist entry ID")
+	}
+
+	entry, err := u.waitlistRepo.FindByID(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := u.fieldRepo.FindByID(ctx, entry.FieldID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching field: %w", err)
+	}
+
+	if err := u.policy.Authorize(actor, authz.ActionWaitlistManage, authz.Resource{Field: field, Waitlist: entry}); err != nil {
+		return nil, err
+	}
+
+	return field, nil
+}
+
+func (u *waitlistService) cachedField(ctx context.Context, fields map[int]*domain.Field, fieldID int) (*domain.Field, error) {
+	if field, ok := fields[fieldID]; ok {
+		return field, nil
+	}
+
+	field, err := u.fieldRepo.FindByID(ctx, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching field: %w", err)
+	}
+
+	fields[fieldID] = field
+	return field, nil
+}
+
+// notify mengirim notifikasi ke user secara best-effort; kegagalan hanya dicatat di log.
+func (u *waitlistService) notify(ctx context.Context, userID int, subject, body string) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		log.Printf("error fetching user %d for notification: %v", userID, err)
+		return
+	}
+
+	err = u.notifier.Send(ctx, notification.Message{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Subject: subject,
+		Body:    body,
+	})
+	if err != nil {
+		log.Printf("error sending notification to user %d: %v", userID, err)
+	}
+}
+
+// groupByField memecah entry yang sudah terurut per lapangan menjadi antrean per lapangan.
+func groupByField(entries []*domain.WaitlistEntry) [][]*domain.WaitlistEntry {
+	var queues [][]*domain.WaitlistEntry
+
+	for i, entry := range entries {
+		if i == 0 || entry.FieldID != entries[i-1].FieldID {
+			queues = append(queues, nil)
+		}
+		queues[len(queues)-1] = append(queues[len(queues)-1], entry)
+	}
+
+	return queues
+}
+
+func overlapsAny(entry *domain.WaitlistEntry, others []*domain.WaitlistEntry) bool {
+	for _, other := range others {
+		if entry.StartTime.Before(other.EndTime) && entry.EndTime.After(other.StartTime) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatSlot(start, end time.Time) string {
+	return fmt.Sprintf("%s - %s", start.Format("2006-01-02 15:04"), end.Format("15:04"))
+}
@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"futsal-booking-app/internal/service"
+	"futsal-booking-app/pkg/cron"
+)
+
+const (
+	JobExpirePendingBookings = "expire-pending-bookings"
+	JobCompleteEndedBookings = "complete-ended-bookings"
+	JobProcessWaitlist       = "process-waitlist"
+	JobExpireAdjustments     = "expire-unpaid-adjustments"
+	JobRetryRefunds          = "retry-refunds"
+)
+
+// NewExpirePendingBookingsJob meng-expire booking PENDING yang melewati batas
+// waktu pembayaran, supaya slotnya kembali tersedia.
+func NewExpirePendingBookingsJob(bookingService service.BookingService, schedule cron.Schedule, batchSize int) Job {
+	return Job{
+		Name:     JobExpirePendingBookings,
+		Schedule: schedule,
+		Run:      inBatches(bookingService.ExpireStaleBookings, batchSize),
+	}
+}
+
+// NewCompleteEndedBookingsJob menandai booking CONFIRMED yang sudah selesai
+// dimainkan sebagai COMPLETED.
+func NewCompleteEndedBookingsJob(bookingService service.BookingService, schedule cron.Schedule, batchSize int) Job {
+	return Job{
+		Name:     JobCompleteEndedBookings,
+		Schedule: schedule,
+		Run:      inBatches(bookingService.CompleteEndedBookings, batchSize),
+	}
+}
+
+// NewExpireAdjustmentsJob membatalkan booking yang tagihan selisih
+// reschedule-nya tidak dibayar sampai batas waktunya.
+func NewExpireAdjustmentsJob(bookingService service.BookingService, schedule cron.Schedule, batchSize int) Job {
+	return Job{
+		Name:     JobExpireAdjustments,
+		Schedule: schedule,
+		Run:      inBatches(bookingService.ExpireUnpaidAdjustments, batchSize),
+	}
+}
+
+// NewRetryRefundsJob mencairkan ulang refund yang gagal atau terhenti sebelum
+// dicairkan. Satu batch per eksekusi, karena refund yang gagal lagi baru boleh
+// dicoba setelah jeda.
+func NewRetryRefundsJob(bookingService service.BookingService, schedule cron.Schedule, batchSize int) Job {
+	return Job{
+		Name:     JobRetryRefunds,
+		Schedule: schedule,
+		Run: func(ctx context.Context) (int, error) {
+			return bookingService.RetryRefunds(ctx, batchSize)
+		},
+	}
+}
+
+// NewProcessWaitlistJob mengakhiri penawaran waitlist yang habis dan menawarkan
+// slot yang kosong ke antrean berikutnya. Satu langkah per eksekusi, karena
+// kandidat yang bentrok dengan penawaran baru baru bisa diproses setelah
+// penawaran itu selesai.
+func NewProcessWaitlistJob(waitlistService service.WaitlistService, schedule cron.Schedule, batchSize int) Job {
+	return Job{
+		Name:     JobProcessWaitlist,
+		Schedule: schedule,
+		Run: func(ctx context.Context) (int, error) {
+			return waitlistService.ProcessWaitlist(ctx, batchSize)
+		},
+	}
+}
+
+// inBatches memanggil fn per batch sampai batch terakhir tidak penuh.
+func inBatches(fn func(ctx context.Context, limit int) (int, error), batchSize int) func(ctx context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) {
+		total := 0
+
+		for ctx.Err() == nil {
+			count, err := fn(ctx, batchSize)
+			total += count
+			if err != nil {
+				return total, err
+			}
+
+			if count < batchSize {
+				break
+			}
+		}
+
+		return total, nil
+	}
+}
@@ -0,0 +1,175 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"futsal-booking-app/pkg/cron"
+	"log"
+	"time"
+)
+
+// Job adalah pekerjaan terjadwal. Run mengembalikan jumlah item yang diproses.
+type Job struct {
+	Name     string
+	Schedule cron.Schedule
+	Run      func(ctx context.Context) (int, error)
+}
+
+// finishTimeout membatasi pencatatan akhir job run. Pencatatan ini memakai
+// context tersendiri supaya tetap tersimpan walaupun scheduler sedang
+// dihentikan.
+const finishTimeout = 5 * time.Second
+
+// Leader menentukan instance mana yang boleh menjalankan job ketika ada
+// beberapa replica. Diimplementasikan oleh db.AdvisoryLock.
+type Leader interface {
+	TryAcquire(ctx context.Context) (bool, error)
+	Release(ctx context.Context) error
+}
+
+// Scheduler menjalankan job sesuai jadwalnya di dalam proses server. Hanya
+// instance yang memegang Leader yang menjalankan job, dan setiap eksekusi
+// dicatat di job_runs beserta error-nya.
+type Scheduler struct {
+	leader     Leader
+	jobRuns    repository.JobRunRepository
+	instanceID string
+	tick       time.Duration
+	jobs       []Job
+	nextRun    map[string]time.Time
+	isLeader   bool
+	done       chan struct{}
+}
+
+func NewScheduler(leader Leader, jobRuns repository.JobRunRepository, instanceID string, tick time.Duration, jobs ...Job) *Scheduler {
+	return &Scheduler{
+		leader:     leader,
+		jobRuns:    jobRuns,
+		instanceID: instanceID,
+		tick:       tick,
+		jobs:       jobs,
+		nextRun:    map[string]time.Time{},
+		done:       make(chan struct{}),
+	}
+}
+
+// Run berjalan sampai ctx dibatalkan, lalu melepas leadership. Job yang sedang
+// berjalan diselesaikan dan dicatat dulu; Done ditutup setelah Run kembali.
+func (s *Scheduler) Run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+
+	log.Printf("Scheduler started on %s with %d job(s)", s.instanceID, len(s.jobs))
+
+	start := time.Now()
+	for _, job := range s.jobs {
+		s.nextRun[job.Name] = job.Schedule.Next(start)
+	}
+
+	for {
+		s.runDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := s.leader.Release(releaseCtx); err != nil {
+				log.Printf("Error releasing scheduler leadership: %v", err)
+			}
+			cancel()
+
+			log.Println("Scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Done ditutup setelah Run selesai, termasuk pencatatan job terakhir dan
+// pelepasan leadership, sehingga koneksi database aman ditutup.
+func (s *Scheduler) Done() <-chan struct{} {
+	return s.done
+}
+
+// runDue menjalankan job yang sudah jatuh tempo jika instance ini leader.
+func (s *Scheduler) runDue(ctx context.Context) {
+	leader, err := s.leader.TryAcquire(ctx)
+	if err != nil {
+		log.Printf("Error acquiring scheduler leadership: %v", err)
+	}
+
+	if leader != s.isLeader {
+		if leader {
+			log.Printf("Scheduler on %s became leader", s.instanceID)
+		} else {
+			log.Printf("Scheduler on %s lost leadership", s.instanceID)
+		}
+		s.isLeader = leader
+	}
+
+	if !leader {
+		return
+	}
+
+	now := time.Now()
+	for _, job := range s.jobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if now.Before(s.nextRun[job.Name]) {
+			continue
+		}
+
+		s.runJob(ctx, job)
+		s.nextRun[job.Name] = job.Schedule.Next(now)
+	}
+}
+
+func (s *Scheduler) runJob(ctx context.Context, job Job) {
+	run := &domain.JobRun{
+		JobName:    job.Name,
+		InstanceID: s.instanceID,
+		Status:     domain.JobRunRunning,
+		StartedAt:  time.Now(),
+	}
+
+	if err := s.jobRuns.Create(ctx, run); err != nil {
+		log.Printf("Error recording job run %s: %v", job.Name, err)
+	}
+
+	processed, err := runSafely(ctx, job)
+	if err != nil {
+		log.Printf("Job %s failed: %v", job.Name, err)
+	} else if processed > 0 {
+		log.Printf("Job %s processed %d item(s)", job.Name, processed)
+	}
+
+	run.Finish(processed, err, time.Now())
+
+	if run.ID == 0 {
+		return
+	}
+
+	finishCtx, cancel := context.WithTimeout(context.Background(), finishTimeout)
+	defer cancel()
+
+	if err := s.jobRuns.Update(finishCtx, run); err != nil {
+		log.Printf("Error recording job run %s: %v", job.Name, err)
+	}
+}
+
+// runSafely menjalankan job dan mengubah panic menjadi error supaya satu job
+// yang bermasalah tidak menghentikan scheduler.
+func runSafely(ctx context.Context, job Job) (processed int, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	return job.Run(ctx)
+}
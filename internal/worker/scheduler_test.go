@@ -0,0 +1,194 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"futsal-booking-app/internal/domain"
+	"futsal-booking-app/internal/repository"
+	"futsal-booking-app/pkg/cron"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLeader mengembalikan hasil TryAcquire berikutnya dari results; setelah
+// habis, hasil terakhir dipakai terus.
+type fakeLeader struct {
+	mu       sync.Mutex
+	results  []bool
+	err      error
+	acquired int
+	released int
+}
+
+func (l *fakeLeader) TryAcquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.acquired++
+	if l.err != nil {
+		return false, l.err
+	}
+
+	result := l.results[0]
+	if len(l.results) > 1 {
+		l.results = l.results[1:]
+	}
+	return result, nil
+}
+
+func (l *fakeLeader) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.released++
+	return nil
+}
+
+type fakeJobRunRepo struct {
+	repository.JobRunRepository
+	runs []*domain.JobRun
+}
+
+func (r *fakeJobRunRepo) Create(ctx context.Context, run *domain.JobRun) error {
+	run.ID = len(r.runs) + 1
+	r.runs = append(r.runs, run)
+	return nil
+}
+
+func (r *fakeJobRunRepo) Update(ctx context.Context, run *domain.JobRun) error {
+	return nil
+}
+
+func countingJob(name string, calls *int, processed int, err error) Job {
+	return Job{
+		Name:     name,
+		Schedule: cron.Every(time.Minute),
+		Run: func(ctx context.Context) (int, error) {
+			*calls++
+			return processed, err
+		},
+	}
+}
+
+func TestSchedulerRunDue(t *testing.T) {
+	tests := []struct {
+		name      string
+		leader    *fakeLeader
+		due       bool
+		wantCalls int
+		wantRuns  int
+	}{
+		{"leader runs due job", &fakeLeader{results: []bool{true}}, true, 1, 1},
+		{"leader skips job not yet due", &fakeLeader{results: []bool{true}}, false, 0, 0},
+		{"non-leader does not run due job", &fakeLeader{results: []bool{false}}, true, 0, 0},
+		{"acquire error is treated as non-leader", &fakeLeader{err: errors.New("connection lost")}, true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			jobRuns := &fakeJobRunRepo{}
+			s := NewScheduler(tt.leader, jobRuns, "instance-1", time.Minute, countingJob("complete", &calls, 3, nil))
+
+			next := time.Now().Add(time.Hour)
+			if tt.due {
+				next = time.Now().Add(-time.Second)
+			}
+			s.nextRun["complete"] = next
+
+			s.runDue(context.Background())
+
+			if calls != tt.wantCalls {
+				t.Fatalf("job ran %d time(s), want %d", calls, tt.wantCalls)
+			}
+			if len(jobRuns.runs) != tt.wantRuns {
+				t.Fatalf("recorded %d job run(s), want %d", len(jobRuns.runs), tt.wantRuns)
+			}
+			if tt.wantRuns == 0 {
+				if !s.nextRun["complete"].Equal(next) {
+					t.Fatalf("next run moved to %s without running", s.nextRun["complete"])
+				}
+				return
+			}
+
+			run := jobRuns.runs[0]
+			if run.JobName != "complete" || run.InstanceID != "instance-1" || run.Status != domain.JobRunSuccess || run.Processed != 3 || run.FinishedAt == nil {
+				t.Fatalf("job run = %+v", run)
+			}
+			if !s.nextRun["complete"].After(time.Now()) {
+				t.Fatalf("next run = %s, want in the future", s.nextRun["complete"])
+			}
+		})
+	}
+}
+
+func TestSchedulerLeadershipChanges(t *testing.T) {
+	// Leader, kehilangan leadership, lalu menjadi leader lagi
+	leader := &fakeLeader{results: []bool{true, false, true}}
+	calls := 0
+	s := NewScheduler(leader, &fakeJobRunRepo{}, "instance-1", time.Minute, countingJob("complete", &calls, 0, nil))
+
+	wantLeader := []bool{true, false, true}
+	wantCalls := []int{1, 1, 2}
+
+	for i := range wantLeader {
+		s.nextRun["complete"] = time.Now().Add(-time.Second)
+		s.runDue(context.Background())
+
+		if s.isLeader != wantLeader[i] {
+			t.Fatalf("tick %d: isLeader = %v, want %v", i, s.isLeader, wantLeader[i])
+		}
+		if calls != wantCalls[i] {
+			t.Fatalf("tick %d: job ran %d time(s), want %d", i, calls, wantCalls[i])
+		}
+	}
+}
+
+func TestSchedulerRecordsFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       func(ctx context.Context) (int, error)
+		wantError string
+	}{
+		{"error", func(ctx context.Context) (int, error) { return 1, errors.New("boom") }, "boom"},
+		{"panic", func(ctx context.Context) (int, error) { panic("boom") }, "panic: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobRuns := &fakeJobRunRepo{}
+			job := Job{Name: "failing", Schedule: cron.Every(time.Minute), Run: tt.run}
+			s := NewScheduler(&fakeLeader{results: []bool{true}}, jobRuns, "instance-1", time.Minute, job)
+			s.nextRun["failing"] = time.Now().Add(-time.Second)
+
+			s.runDue(context.Background())
+
+			if len(jobRuns.runs) != 1 {
+				t.Fatalf("recorded %d job run(s), want 1", len(jobRuns.runs))
+			}
+			if run := jobRuns.runs[0]; run.Status != domain.JobRunFailed || run.Error != tt.wantError {
+				t.Fatalf("job run = %s %q, want %s %q", run.Status, run.Error, domain.JobRunFailed, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestSchedulerRunReleasesLeadershipOnStop(t *testing.T) {
+	leader := &fakeLeader{results: []bool{false}}
+	s := NewScheduler(leader, &fakeJobRunRepo{}, "instance-1", time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.Run(ctx)
+	cancel()
+
+	select {
+	case <-s.Done():
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancel")
+	}
+
+	if leader.released != 1 {
+		t.Fatalf("Release called %d time(s), want 1", leader.released)
+	}
+}
@@ -50,7 +50,7 @@ CREATE TABLE bookings (
     status VARCHAR(50) NOT NULL CHECK (status IN ('PENDING', 'CONFIRMED', 'CANCELLED', 'COMPLETED')),
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     CONSTRAINT check_booking_time_order CHECK (end_time > start_time)
-)
+);
 
 CREATE INDEX idx_bookings_user_id ON bookings(user_id);
 
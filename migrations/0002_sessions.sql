@@ -0,0 +1,13 @@
+CREATE TABLE sessions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    refresh_token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    revoke_reason VARCHAR(20) CHECK (revoke_reason IN ('ROTATED', 'LOGOUT', 'REUSE_DETECTED')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions(user_id);
+
+COMMENT ON TABLE sessions IS 'Tabel untuk menyimpan refresh token (session login) pengguna';
@@ -0,0 +1,11 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Mencegah double booking di level database: dua booking aktif (PENDING/CONFIRMED)
+-- pada lapangan yang sama tidak boleh memiliki rentang waktu yang beririsan.
+-- Rentang memakai batas '[)' sehingga booking 19:00-20:00 dan 20:00-21:00 tidak bentrok.
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        field_id WITH =,
+        tsrange(start_time, end_time, '[)') WITH &&
+    ) WHERE (status IN ('PENDING', 'CONFIRMED'));
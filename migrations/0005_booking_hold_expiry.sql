@@ -0,0 +1,12 @@
+ALTER TABLE fields ADD COLUMN payment_hold_minutes INTEGER NOT NULL DEFAULT 15 CHECK (payment_hold_minutes > 0);
+
+ALTER TABLE bookings ADD COLUMN expires_at TIMESTAMP;
+
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check CHECK (status IN ('PENDING', 'CONFIRMED', 'CANCELLED', 'COMPLETED', 'EXPIRED'));
+
+CREATE INDEX idx_bookings_pending_expires_at ON bookings(expires_at) WHERE status = 'PENDING';
+
+COMMENT ON COLUMN bookings.expires_at IS 'Batas waktu pembayaran untuk booking PENDING';
+COMMENT ON COLUMN fields.payment_hold_minutes IS 'Lama slot ditahan menunggu pembayaran';
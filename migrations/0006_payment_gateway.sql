@@ -0,0 +1,7 @@
+ALTER TABLE payments ADD COLUMN payment_url VARCHAR(500) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_payments_transaction_id_unique ON payments(transaction_id);
+
+DROP INDEX IF EXISTS idx_payments_transaction_id;
+
+COMMENT ON COLUMN payments.payment_url IS 'URL halaman pembayaran dari payment gateway';
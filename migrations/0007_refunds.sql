@@ -0,0 +1,33 @@
+ALTER TABLE fields ADD COLUMN full_refund_hours INTEGER NOT NULL DEFAULT 24 CHECK (full_refund_hours >= 0);
+
+ALTER TABLE fields ADD COLUMN partial_refund_percent INTEGER NOT NULL DEFAULT 50 CHECK (partial_refund_percent BETWEEN 0 AND 100);
+
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_status_check;
+
+ALTER TABLE payments ADD CONSTRAINT payments_status_check CHECK (status IN ('PENDING', 'SUCCESS', 'FAILED', 'REFUNDED'));
+
+CREATE TABLE refunds (
+    id SERIAL PRIMARY KEY,
+    booking_id INTEGER NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    refund_key VARCHAR(255) NOT NULL UNIQUE,
+    status VARCHAR(50) NOT NULL CHECK (status IN ('PENDING', 'SUCCESS', 'FAILED')),
+    failure_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_refunds_booking_id ON refunds(booking_id);
+
+CREATE INDEX idx_refunds_status ON refunds(status);
+
+CREATE TRIGGER update_refunds_updated_at
+    BEFORE UPDATE ON refunds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE refunds IS 'Tabel untuk menyimpan riwayat refund pembayaran booking';
+COMMENT ON COLUMN fields.full_refund_hours IS 'Pembatalan paling lambat N jam sebelum mulai mendapat refund penuh';
+COMMENT ON COLUMN fields.partial_refund_percent IS 'Persentase refund untuk pembatalan di bawah full_refund_hours';
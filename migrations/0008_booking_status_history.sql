@@ -0,0 +1,19 @@
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check CHECK (status IN ('PENDING', 'CONFIRMED', 'CANCELLED', 'COMPLETED', 'EXPIRED', 'NO_SHOW'));
+
+CREATE TABLE booking_status_history (
+    id SERIAL PRIMARY KEY,
+    booking_id INTEGER NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    actor_type VARCHAR(50) NOT NULL CHECK (actor_type IN ('CUSTOMER', 'OWNER', 'ADMIN', 'SYSTEM')),
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_booking_status_history_booking_id ON booking_status_history(booking_id, created_at);
+
+COMMENT ON TABLE booking_status_history IS 'Riwayat perubahan status booking untuk audit';
+COMMENT ON COLUMN booking_status_history.from_status IS 'NULL untuk pembuatan booking';
@@ -0,0 +1,18 @@
+CREATE TABLE job_runs (
+    id SERIAL PRIMARY KEY,
+    job_name VARCHAR(100) NOT NULL,
+    instance_id VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL CHECK (status IN ('RUNNING', 'SUCCESS', 'FAILED')),
+    processed INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_job_runs_job_name_started_at ON job_runs(job_name, started_at DESC);
+
+CREATE INDEX idx_job_runs_failed ON job_runs(started_at DESC) WHERE status = 'FAILED';
+
+CREATE INDEX idx_bookings_confirmed_end_time ON bookings(end_time) WHERE status = 'CONFIRMED';
+
+COMMENT ON TABLE job_runs IS 'Riwayat eksekusi job terjadwal';
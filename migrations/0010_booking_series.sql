@@ -0,0 +1,27 @@
+CREATE TABLE booking_series (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    field_id INTEGER NOT NULL REFERENCES fields(id) ON DELETE CASCADE,
+    frequency VARCHAR(50) NOT NULL CHECK (frequency IN ('WEEKLY', 'BIWEEKLY')),
+    first_start TIMESTAMP NOT NULL,
+    duration_hours INTEGER NOT NULL CHECK (duration_hours > 0),
+    occurrences INTEGER NOT NULL CHECK (occurrences > 0),
+    payment_mode VARCHAR(50) NOT NULL CHECK (payment_mode IN ('PER_OCCURRENCE', 'UPFRONT')),
+    status VARCHAR(50) NOT NULL CHECK (status IN ('ACTIVE', 'CANCELLED')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_booking_series_user_id ON booking_series(user_id);
+
+ALTER TABLE bookings ADD COLUMN series_id INTEGER REFERENCES booking_series(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_bookings_series_id ON bookings(series_id) WHERE series_id IS NOT NULL;
+
+-- Payment dimiliki satu booking atau satu series (pembayaran di muka)
+ALTER TABLE payments ALTER COLUMN booking_id DROP NOT NULL;
+
+ALTER TABLE payments ADD COLUMN series_id INTEGER UNIQUE REFERENCES booking_series(id) ON DELETE CASCADE;
+
+ALTER TABLE payments ADD CONSTRAINT payments_owner_check CHECK ((booking_id IS NULL) <> (series_id IS NULL));
+
+COMMENT ON TABLE booking_series IS 'Booking berulang mingguan/dua mingguan; occurrence disimpan di bookings.series_id';
@@ -0,0 +1,40 @@
+ALTER TABLE fields ADD COLUMN waitlist_order VARCHAR(50) NOT NULL DEFAULT 'FIFO' CHECK (waitlist_order IN ('FIFO', 'LOTTERY'));
+
+ALTER TABLE fields ADD COLUMN waitlist_offer_minutes INTEGER NOT NULL DEFAULT 30 CHECK (waitlist_offer_minutes > 0);
+
+ALTER TABLE fields ADD COLUMN waitlist_notify_customer BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE fields ADD COLUMN waitlist_notify_owner BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE waitlist_entries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    field_id INTEGER NOT NULL REFERENCES fields(id) ON DELETE CASCADE,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    status VARCHAR(50) NOT NULL CHECK (status IN ('WAITING', 'OFFERED', 'ACCEPTED', 'EXPIRED', 'CANCELLED')),
+    offered_at TIMESTAMP,
+    offer_expires_at TIMESTAMP,
+    booking_id INTEGER REFERENCES bookings(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_time > start_time),
+    CHECK (status <> 'OFFERED' OR offer_expires_at IS NOT NULL)
+);
+
+-- Satu customer hanya bisa antre sekali untuk rentang waktu yang sama
+CREATE UNIQUE INDEX idx_waitlist_entries_open ON waitlist_entries(user_id, field_id, start_time, end_time)
+    WHERE status IN ('WAITING', 'OFFERED');
+
+CREATE INDEX idx_waitlist_entries_field_status ON waitlist_entries(field_id, status, start_time);
+
+CREATE INDEX idx_waitlist_entries_user_id ON waitlist_entries(user_id);
+
+CREATE TRIGGER update_waitlist_entries_updated_at
+    BEFORE UPDATE ON waitlist_entries
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE waitlist_entries IS 'Antrean customer untuk slot yang penuh; entry OFFERED menahan slot sampai offer_expires_at';
+COMMENT ON COLUMN fields.waitlist_order IS 'Urutan antrean: FIFO (siapa cepat) atau LOTTERY (acak)';
+COMMENT ON COLUMN fields.waitlist_offer_minutes IS 'Lama penawaran slot ke customer waitlist sebelum dialihkan ke antrean berikutnya';
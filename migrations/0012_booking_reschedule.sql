@@ -0,0 +1,46 @@
+ALTER TABLE fields ADD COLUMN reschedule_min_hours INTEGER NOT NULL DEFAULT 2 CHECK (reschedule_min_hours >= 0);
+
+ALTER TABLE fields ADD COLUMN reschedule_max_count INTEGER NOT NULL DEFAULT 1 CHECK (reschedule_max_count >= 0);
+
+ALTER TABLE bookings ADD COLUMN reschedule_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE booking_reschedules (
+    id SERIAL PRIMARY KEY,
+    booking_id INTEGER NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    old_start_time TIMESTAMP NOT NULL,
+    old_end_time TIMESTAMP NOT NULL,
+    new_start_time TIMESTAMP NOT NULL,
+    new_end_time TIMESTAMP NOT NULL,
+    old_price INTEGER NOT NULL,
+    new_price INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_booking_reschedules_booking_id ON booking_reschedules(booking_id, created_at);
+
+-- Tagihan selisih harga untuk booking yang sudah dibayar lalu dipindah ke slot lebih mahal
+CREATE TABLE payment_adjustments (
+    id SERIAL PRIMARY KEY,
+    payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    booking_id INTEGER NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    transaction_id VARCHAR(255) NOT NULL UNIQUE,
+    payment_url VARCHAR(500) NOT NULL DEFAULT '',
+    status VARCHAR(50) NOT NULL CHECK (status IN ('PENDING', 'SUCCESS', 'FAILED')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_payment_adjustments_booking_id ON payment_adjustments(booking_id);
+
+CREATE TRIGGER update_payment_adjustments_updated_at
+    BEFORE UPDATE ON payment_adjustments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE booking_reschedules IS 'Riwayat perpindahan jadwal booking';
+COMMENT ON TABLE payment_adjustments IS 'Tagihan tambahan atas payment booking, misalnya selisih harga reschedule';
+COMMENT ON COLUMN fields.reschedule_min_hours IS 'Reschedule paling lambat N jam sebelum booking dimulai';
+COMMENT ON COLUMN fields.reschedule_max_count IS 'Berapa kali satu booking boleh di-reschedule; 0 berarti tidak boleh';
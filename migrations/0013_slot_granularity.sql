@@ -0,0 +1,39 @@
+ALTER TABLE fields ADD COLUMN slot_minutes INTEGER NOT NULL DEFAULT 60 CHECK (slot_minutes > 0 AND 1440 % slot_minutes = 0);
+
+ALTER TABLE fields ADD COLUMN min_duration_minutes INTEGER NOT NULL DEFAULT 60 CHECK (min_duration_minutes > 0);
+
+ALTER TABLE fields ADD COLUMN max_duration_minutes INTEGER NOT NULL DEFAULT 0 CHECK (max_duration_minutes >= 0);
+
+ALTER TABLE fields ADD COLUMN buffer_minutes INTEGER NOT NULL DEFAULT 0 CHECK (buffer_minutes >= 0);
+
+-- Booking menyimpan buffer lapangan saat dibuat, supaya perubahan buffer
+-- tidak menggeser slot yang sudah terjual. blocked_until = end_time + buffer.
+ALTER TABLE bookings ADD COLUMN buffer_minutes INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE bookings ADD COLUMN blocked_until TIMESTAMP;
+
+UPDATE bookings SET blocked_until = end_time;
+
+ALTER TABLE bookings ALTER COLUMN blocked_until SET NOT NULL;
+
+ALTER TABLE bookings ADD CONSTRAINT bookings_blocked_until_check CHECK (blocked_until >= end_time);
+
+-- Buffer ikut dihitung saat mencegah double booking: booking berikutnya
+-- baru boleh dimulai setelah blocked_until booking sebelumnya.
+ALTER TABLE bookings DROP CONSTRAINT bookings_no_overlap;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        field_id WITH =,
+        tsrange(start_time, blocked_until, '[)') WITH &&
+    ) WHERE (status IN ('PENDING', 'CONFIRMED'));
+
+ALTER TABLE booking_series RENAME COLUMN duration_hours TO duration_minutes;
+
+UPDATE booking_series SET duration_minutes = duration_minutes * 60;
+
+COMMENT ON COLUMN fields.slot_minutes IS 'Granularitas jam mulai dan durasi booking dalam menit; harus membagi habis 1440';
+COMMENT ON COLUMN fields.min_duration_minutes IS 'Durasi booking minimal dalam menit';
+COMMENT ON COLUMN fields.max_duration_minutes IS 'Durasi booking maksimal dalam menit; 0 berarti tanpa batas';
+COMMENT ON COLUMN fields.buffer_minutes IS 'Jeda setelah booking selesai sebelum booking berikutnya boleh dimulai';
@@ -0,0 +1,27 @@
+CREATE TABLE pricing_rules (
+    id SERIAL PRIMARY KEY,
+    field_id INTEGER NOT NULL REFERENCES fields(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    day_of_week INTEGER CHECK (day_of_week >= 0 AND day_of_week <= 6),
+    start_minute INTEGER NOT NULL DEFAULT 0,
+    end_minute INTEGER NOT NULL DEFAULT 1440,
+    start_date DATE,
+    end_date DATE,
+    price_per_hour INTEGER CHECK (price_per_hour > 0),
+    multiplier NUMERIC(6, 3) CHECK (multiplier > 0),
+    priority INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pricing_rules_window_check CHECK (start_minute >= 0 AND start_minute < end_minute AND end_minute <= 1440),
+    CONSTRAINT pricing_rules_date_check CHECK (start_date IS NULL OR end_date IS NULL OR start_date <= end_date),
+    CONSTRAINT pricing_rules_rate_check CHECK ((price_per_hour IS NULL) <> (multiplier IS NULL))
+);
+
+CREATE INDEX idx_pricing_rules_field_id ON pricing_rules(field_id);
+
+-- Rincian harga per potongan tarif, disimpan saat booking dibuat atau di-reschedule
+-- supaya perubahan rule tidak mengubah harga booking yang sudah ada.
+ALTER TABLE bookings ADD COLUMN price_breakdown JSONB NOT NULL DEFAULT '[]';
+
+COMMENT ON TABLE pricing_rules IS 'Tarif khusus per lapangan berdasarkan hari, jam, dan rentang tanggal';
+COMMENT ON COLUMN pricing_rules.multiplier IS 'Pengali tarif dasar lapangan; diisi jika price_per_hour kosong';
+COMMENT ON COLUMN pricing_rules.priority IS 'Rule dengan priority lebih besar menang jika beberapa rule berlaku bersamaan';
@@ -0,0 +1,57 @@
+CREATE TABLE promo_codes (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    discount_type VARCHAR(50) NOT NULL CHECK (discount_type IN ('PERCENTAGE', 'FIXED')),
+    discount_value INTEGER NOT NULL CHECK (discount_value > 0),
+    field_ids INTEGER[] NOT NULL DEFAULT '{}',
+    min_spend INTEGER NOT NULL DEFAULT 0 CHECK (min_spend >= 0),
+    valid_from TIMESTAMP,
+    valid_until TIMESTAMP,
+    max_uses INTEGER NOT NULL DEFAULT 0 CHECK (max_uses >= 0),
+    max_uses_per_user INTEGER NOT NULL DEFAULT 0 CHECK (max_uses_per_user >= 0),
+    first_booking_only BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT promo_codes_percentage_check CHECK (discount_type <> 'PERCENTAGE' OR discount_value <= 100),
+    CONSTRAINT promo_codes_validity_check CHECK (valid_from IS NULL OR valid_until IS NULL OR valid_from < valid_until)
+);
+
+-- Kode promo tidak case-sensitive; service menyimpannya dalam huruf besar
+CREATE UNIQUE INDEX idx_promo_codes_code ON promo_codes(code);
+
+CREATE INDEX idx_promo_codes_created_by ON promo_codes(created_by);
+
+CREATE TRIGGER update_promo_codes_updated_at
+    BEFORE UPDATE ON promo_codes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE promo_redemptions (
+    id SERIAL PRIMARY KEY,
+    promo_code_id INTEGER NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    booking_id INTEGER NOT NULL UNIQUE REFERENCES bookings(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    discount_amount INTEGER NOT NULL CHECK (discount_amount > 0),
+    status VARCHAR(50) NOT NULL CHECK (status IN ('ACTIVE', 'RELEASED')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    released_at TIMESTAMP
+);
+
+-- Batas pemakaian hanya menghitung redemption ACTIVE
+CREATE INDEX idx_promo_redemptions_active ON promo_redemptions(promo_code_id, user_id) WHERE status = 'ACTIVE';
+
+ALTER TABLE bookings ADD COLUMN original_price INTEGER;
+
+UPDATE bookings SET original_price = total_price;
+
+ALTER TABLE bookings ALTER COLUMN original_price SET NOT NULL;
+
+ALTER TABLE bookings ADD COLUMN discount_amount INTEGER NOT NULL DEFAULT 0 CHECK (discount_amount >= 0);
+
+COMMENT ON TABLE promo_codes IS 'Kode promo dari admin (semua lapangan) atau owner (lapangan miliknya)';
+COMMENT ON TABLE promo_redemptions IS 'Pemakaian kode promo per booking; RELEASED jika booking dibatalkan atau expired';
+COMMENT ON COLUMN promo_codes.field_ids IS 'Lapangan tempat promo berlaku; kosong berarti semua lapangan';
+COMMENT ON COLUMN promo_codes.max_uses IS 'Batas pemakaian total; 0 berarti tanpa batas';
+COMMENT ON COLUMN promo_codes.max_uses_per_user IS 'Batas pemakaian per customer; 0 berarti tanpa batas';
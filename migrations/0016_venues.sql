@@ -0,0 +1,60 @@
+CREATE TABLE venues (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    address TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    image_url VARCHAR(500) NOT NULL DEFAULT '',
+    amenities TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    -- Kolom sementara untuk memetakan field lama ke venue hasil migrasi
+    legacy_field_id INTEGER
+);
+
+CREATE INDEX idx_venues_owner_id ON venues(owner_id);
+
+-- Jam buka default venue, disalin ke court baru yang dibuat di venue tersebut
+CREATE TABLE venue_schedules (
+    id SERIAL PRIMARY KEY,
+    venue_id INTEGER NOT NULL REFERENCES venues(id) ON DELETE CASCADE,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week >= 0 AND day_of_week <= 6),
+    open_time TIME NOT NULL,
+    close_time TIME NOT NULL,
+    CONSTRAINT venue_schedules_time_order CHECK (close_time > open_time)
+);
+
+CREATE UNIQUE INDEX idx_venue_schedules_venue_day ON venue_schedules(venue_id, day_of_week);
+
+ALTER TABLE fields
+    ADD COLUMN venue_id INTEGER REFERENCES venues(id) ON DELETE CASCADE,
+    ADD COLUMN surface_type VARCHAR(50) NOT NULL DEFAULT 'SYNTHETIC'
+        CHECK (surface_type IN ('SYNTHETIC', 'VINYL', 'INTERLOCK', 'PARQUET', 'CEMENT')),
+    ADD COLUMN indoor BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- Setiap field lama menjadi venue dengan satu court, jadwalnya menjadi jam buka default venue
+INSERT INTO venues (owner_id, name, address, description, image_url, created_at, legacy_field_id)
+SELECT owner_id, name, address, COALESCE(description, ''), COALESCE(image_url, ''), created_at, id
+FROM fields;
+
+UPDATE fields f SET venue_id = v.id FROM venues v WHERE v.legacy_field_id = f.id;
+
+INSERT INTO venue_schedules (venue_id, day_of_week, open_time, close_time)
+SELECT f.venue_id, s.day_of_week, s.open_time, s.close_time
+FROM schedules s
+JOIN fields f ON f.id = s.field_id;
+
+ALTER TABLE venues DROP COLUMN legacy_field_id;
+
+ALTER TABLE fields ALTER COLUMN venue_id SET NOT NULL;
+
+CREATE INDEX idx_fields_venue_id ON fields(venue_id);
+
+-- Owner, alamat, dan foto sekarang milik venue
+DROP INDEX IF EXISTS idx_fields_owner_id;
+ALTER TABLE fields
+    DROP COLUMN owner_id,
+    DROP COLUMN address,
+    DROP COLUMN image_url;
+
+COMMENT ON TABLE venues IS 'Lokasi fisik yang menaungi satu atau lebih court (fields)';
+COMMENT ON COLUMN fields.venue_id IS 'Venue tempat court berada; owner dan alamat diambil dari venue';
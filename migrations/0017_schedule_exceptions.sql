@@ -0,0 +1,16 @@
+CREATE TABLE schedule_exceptions (
+    id SERIAL PRIMARY KEY,
+    field_id INTEGER NOT NULL REFERENCES fields(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    type VARCHAR(50) NOT NULL CHECK (type IN ('CLOSED', 'CLOSED_WINDOW', 'SPECIAL_HOURS')),
+    start_minute INTEGER NOT NULL DEFAULT 0,
+    end_minute INTEGER NOT NULL DEFAULT 1440,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT schedule_exceptions_window_check CHECK (start_minute >= 0 AND start_minute < end_minute AND end_minute <= 1440)
+);
+
+CREATE INDEX idx_schedule_exceptions_field_date ON schedule_exceptions(field_id, date);
+
+COMMENT ON TABLE schedule_exceptions IS 'Perubahan jadwal mingguan lapangan pada tanggal tertentu (libur, maintenance, jam khusus)';
+COMMENT ON COLUMN schedule_exceptions.start_minute IS 'Awal jendela dalam menit sejak tengah malam; tidak dipakai untuk CLOSED';
@@ -0,0 +1,15 @@
+-- Satu hari boleh punya beberapa jendela jam buka (misalnya 08:00-12:00 dan
+-- 15:00-02:00). Jendela dengan close_time <= open_time dianggap tutup setelah
+-- tengah malam pada hari berikutnya. Jendela yang saling beririsan ditolak
+-- oleh aplikasi saat jadwal disimpan.
+DROP INDEX IF EXISTS idx_schedules_field_day;
+ALTER TABLE schedules DROP CONSTRAINT IF EXISTS check_time_order;
+ALTER TABLE schedules ADD CONSTRAINT schedules_nonempty_window CHECK (close_time <> open_time);
+
+DROP INDEX IF EXISTS idx_venue_schedules_venue_day;
+ALTER TABLE venue_schedules DROP CONSTRAINT IF EXISTS venue_schedules_time_order;
+ALTER TABLE venue_schedules ADD CONSTRAINT venue_schedules_nonempty_window CHECK (close_time <> open_time);
+
+CREATE INDEX idx_venue_schedules_venue_id ON venue_schedules(venue_id);
+
+COMMENT ON COLUMN schedules.close_time IS 'Jam tutup; jika <= open_time, jendela berakhir pada hari berikutnya';
@@ -0,0 +1,106 @@
+-- Zona waktu IANA venue. Jadwal, grid slot, dan pricing rule semua court di
+-- venue dibaca di zona ini (Asia/Jakarta = WIB, Asia/Makassar = WITA,
+-- Asia/Jayapura = WIT).
+ALTER TABLE venues ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta';
+
+COMMENT ON COLUMN venues.time_zone IS 'Nama zona waktu IANA venue, misalnya Asia/Makassar';
+
+-- Waktu booking sebelumnya disimpan sebagai TIMESTAMP tanpa zona berisi jam
+-- dinding lapangan. Data lama dikonversi dengan zona waktu venue masing-masing
+-- (default Asia/Jakarta). Subquery tidak boleh dipakai di USING, jadi zona
+-- dibaca lewat fungsi sementara.
+CREATE FUNCTION pg_temp.field_time_zone(p_field_id INTEGER) RETURNS TEXT AS $$
+    SELECT v.time_zone FROM fields f JOIN venues v ON v.id = f.venue_id WHERE f.id = p_field_id
+$$ LANGUAGE sql STABLE;
+
+CREATE FUNCTION pg_temp.booking_time_zone(p_booking_id INTEGER) RETURNS TEXT AS $$
+    SELECT pg_temp.field_time_zone(b.field_id) FROM bookings b WHERE b.id = p_booking_id
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE bookings DROP CONSTRAINT bookings_no_overlap;
+
+ALTER TABLE bookings
+    ALTER COLUMN start_time TYPE TIMESTAMPTZ USING start_time AT TIME ZONE pg_temp.field_time_zone(field_id),
+    ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE pg_temp.field_time_zone(field_id),
+    ALTER COLUMN blocked_until TYPE TIMESTAMPTZ USING blocked_until AT TIME ZONE pg_temp.field_time_zone(field_id),
+    ALTER COLUMN expires_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        field_id WITH =,
+        tstzrange(start_time, blocked_until, '[)') WITH &&
+    ) WHERE (status IN ('PENDING', 'CONFIRMED'));
+
+ALTER TABLE booking_reschedules
+    ALTER COLUMN old_start_time TYPE TIMESTAMPTZ USING old_start_time AT TIME ZONE pg_temp.booking_time_zone(booking_id),
+    ALTER COLUMN old_end_time TYPE TIMESTAMPTZ USING old_end_time AT TIME ZONE pg_temp.booking_time_zone(booking_id),
+    ALTER COLUMN new_start_time TYPE TIMESTAMPTZ USING new_start_time AT TIME ZONE pg_temp.booking_time_zone(booking_id),
+    ALTER COLUMN new_end_time TYPE TIMESTAMPTZ USING new_end_time AT TIME ZONE pg_temp.booking_time_zone(booking_id),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE booking_series
+    ALTER COLUMN first_start TYPE TIMESTAMPTZ USING first_start AT TIME ZONE pg_temp.field_time_zone(field_id),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE booking_status_history
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE waitlist_entries
+    ALTER COLUMN start_time TYPE TIMESTAMPTZ USING start_time AT TIME ZONE pg_temp.field_time_zone(field_id),
+    ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE pg_temp.field_time_zone(field_id),
+    ALTER COLUMN offered_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN offer_expires_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+-- Kolom berikut berisi waktu kejadian (bukan jam dinding lapangan) yang
+-- ditulis server, jadi nilai lamanya dibaca di zona waktu session database.
+-- Setelah ini perbandingan seperti expires_at <= now dan masa berlaku promo
+-- tidak lagi bergantung pada zona waktu server aplikasi.
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE fields
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE venues
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE sessions
+    ALTER COLUMN expires_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN revoked_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE payments
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE refunds
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE payment_adjustments
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE job_runs
+    ALTER COLUMN started_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN finished_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE pricing_rules
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE promo_codes
+    ALTER COLUMN valid_from TYPE TIMESTAMPTZ,
+    ALTER COLUMN valid_until TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE promo_redemptions
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN released_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE schedule_exceptions
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
@@ -0,0 +1,11 @@
+-- Koordinat venue untuk pencarian berdasarkan jarak. Keduanya diisi bersamaan
+-- atau dibiarkan kosong untuk venue yang belum memasang titik peta.
+ALTER TABLE venues
+    ADD COLUMN latitude DOUBLE PRECISION,
+    ADD COLUMN longitude DOUBLE PRECISION,
+    ADD CONSTRAINT venues_coordinates_pair_check CHECK ((latitude IS NULL) = (longitude IS NULL)),
+    ADD CONSTRAINT venues_latitude_check CHECK (latitude BETWEEN -90 AND 90),
+    ADD CONSTRAINT venues_longitude_check CHECK (longitude BETWEEN -180 AND 180);
+
+COMMENT ON COLUMN venues.latitude IS 'Latitude WGS84 venue dalam derajat';
+COMMENT ON COLUMN venues.longitude IS 'Longitude WGS84 venue dalam derajat';
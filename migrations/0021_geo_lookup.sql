@@ -0,0 +1,17 @@
+-- Index untuk query bounding box peta.
+CREATE INDEX idx_venues_coordinates ON venues(latitude, longitude) WHERE latitude IS NOT NULL;
+
+-- Query radius memakai ekstensi earthdistance jika tersedia (paket contrib
+-- PostgreSQL). Jika ekstensi tidak bisa dipasang, aplikasi memakai filter
+-- bounding box lalu menghitung jarak haversine di Go.
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS cube;
+    CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+    CREATE INDEX idx_venues_earth ON venues USING gist (ll_to_earth(latitude, longitude)) WHERE latitude IS NOT NULL;
+EXCEPTION
+    WHEN OTHERS THEN
+        RAISE NOTICE 'earthdistance is not available (%); radius queries fall back to haversine', SQLERRM;
+END
+$$;
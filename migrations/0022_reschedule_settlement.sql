@@ -0,0 +1,30 @@
+-- Charge lama payment PENDING yang diganti charge baru saat reschedule
+-- mengubah harga. Charge lama masih bisa dibayar di gateway, jadi
+-- notifikasinya tetap dikenali dan dananya dikembalikan.
+CREATE TABLE superseded_charges (
+    transaction_id VARCHAR(255) PRIMARY KEY,
+    payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    booking_id INTEGER NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_superseded_charges_payment_id ON superseded_charges(payment_id);
+
+-- Refund atas transaksi selain transaksi utama payment (charge lama atau
+-- adjustment). Kosong berarti transaksi utama payment.
+ALTER TABLE refunds ADD COLUMN transaction_id VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Batas pembayaran tagihan selisih reschedule. Adjustment lama mengikuti
+-- expiry charge-nya, yaitu jam mulai booking.
+ALTER TABLE payment_adjustments ADD COLUMN expires_at TIMESTAMPTZ;
+
+UPDATE payment_adjustments a SET expires_at = b.start_time FROM bookings b WHERE b.id = a.booking_id;
+
+ALTER TABLE payment_adjustments ALTER COLUMN expires_at SET NOT NULL;
+
+CREATE INDEX idx_payment_adjustments_pending_expires_at ON payment_adjustments(expires_at) WHERE status = 'PENDING';
+
+COMMENT ON TABLE superseded_charges IS 'Charge gateway lama milik payment yang sudah diganti charge baru';
+COMMENT ON COLUMN refunds.transaction_id IS 'Transaksi gateway yang di-refund jika bukan transaksi utama payment';
+COMMENT ON COLUMN payment_adjustments.expires_at IS 'Adjustment yang belum dibayar sampai batas ini membatalkan booking';
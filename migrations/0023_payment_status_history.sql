@@ -0,0 +1,12 @@
+CREATE TABLE payment_status_history (
+    id SERIAL PRIMARY KEY,
+    payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_payment_status_history_payment_id ON payment_status_history(payment_id, created_at);
+
+COMMENT ON TABLE payment_status_history IS 'Riwayat perubahan status payment untuk audit, termasuk FAILED -> SUCCESS untuk dana yang diterima terlambat';
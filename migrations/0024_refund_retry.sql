@@ -0,0 +1,3 @@
+-- Refund PENDING/FAILED dicairkan ulang oleh job terjadwal, urut dari yang
+-- paling lama tidak diubah.
+CREATE INDEX idx_refunds_retryable_updated_at ON refunds(updated_at) WHERE status IN ('PENDING', 'FAILED');
@@ -0,0 +1,232 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule menentukan kapan sebuah job berikutnya dijalankan.
+type Schedule interface {
+	// Next mengembalikan waktu eksekusi pertama setelah after.
+	Next(after time.Time) time.Time
+}
+
+// Every adalah jadwal berbasis interval tetap, dihitung dari eksekusi
+// sebelumnya.
+type Every time.Duration
+
+func (e Every) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(e))
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse membaca spesifikasi jadwal. Format yang diterima:
+//  1. Durasi Go ("30s", "5m") atau "@every <durasi>" untuk interval tetap.
+//  2. Ekspresi cron 5 field "menit jam tanggal bulan hari-minggu" dengan
+//     "*", angka, range "a-b", step "/n" dan daftar "a,b". Hari minggu 0-7,
+//     0 dan 7 sama-sama Minggu.
+//  3. Singkatan @yearly, @monthly, @weekly, @daily, @midnight dan @hourly.
+//
+// Ekspresi cron dievaluasi di zona waktu dari waktu yang diberikan ke Next,
+// yaitu zona waktu server untuk scheduler.
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		return parseEvery(strings.TrimSpace(rest))
+	}
+
+	if expr, ok := descriptors[spec]; ok {
+		spec = expr
+	}
+
+	if d, err := time.ParseDuration(spec); err == nil {
+		return parseEvery(d.String())
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule %q must be a duration or a 5-field cron expression", spec)
+	}
+
+	s := &cronSchedule{}
+	var err error
+
+	if s.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if s.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if s.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field: %w", err)
+	}
+	if s.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	if s.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field: %w", err)
+	}
+
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+
+	s.domAny = strings.HasPrefix(fields[2], "*")
+	s.dowAny = strings.HasPrefix(fields[4], "*")
+
+	if s.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("schedule %q never fires", spec)
+	}
+
+	return s, nil
+}
+
+func parseEvery(value string) (Schedule, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval %q: %w", value, err)
+	}
+
+	if d <= 0 {
+		return nil, fmt.Errorf("interval %q must be positive", value)
+	}
+
+	return Every(d), nil
+}
+
+// cronSchedule menyimpan nilai yang cocok untuk setiap field sebagai bitset.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// maxSearch membatasi pencarian Next untuk ekspresi yang sangat jarang cocok.
+const maxSearch = 5
+
+// Next mencari menit pertama setelah after yang cocok dengan semua field.
+// Field yang tidak cocok dilompati per bulan, hari atau jam supaya pencarian
+// tidak perlu memeriksa setiap menit.
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(maxSearch, 0, 0)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches mengikuti aturan cron: jika tanggal dan hari minggu sama-sama
+// dibatasi, cukup salah satunya yang cocok.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+
+	if !s.domAny && !s.dowAny {
+		return domMatch || dowMatch
+	}
+
+	return domMatch && dowMatch
+}
+
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+// parseField mengubah satu field cron menjadi bitset nilai antara min dan max.
+func parseField(expr string, min, max int) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepExpr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepExpr)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rangeExpr == "*":
+			lo, hi = min, max
+		case strings.Contains(rangeExpr, "-"):
+			loExpr, hiExpr, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			if lo, err = parseValue(loExpr, min, max); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(hiExpr, min, max); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", rangeExpr)
+			}
+		default:
+			value, err := parseValue(rangeExpr, min, max)
+			if err != nil {
+				return 0, err
+			}
+			lo, hi = value, value
+			if hasStep {
+				hi = max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+func parseValue(expr string, min, max int) (int, error) {
+	value, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", expr)
+	}
+
+	if value < min || value > max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", value, min, max)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"empty", ""},
+		{"too few fields", "* * * *"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "0 24 * * *"},
+		{"day of month zero", "0 0 0 * *"},
+		{"reversed range", "0 5-2 * * *"},
+		{"zero step", "*/0 * * * *"},
+		{"garbage", "a * * * *"},
+		{"negative interval", "-1m"},
+		{"zero interval", "@every 0s"},
+		{"never fires", "0 0 30 2 *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.spec); err == nil {
+				t.Fatalf("Parse(%q) succeeded, want error", tt.spec)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2026-03-04 adalah hari Rabu.
+	base := time.Date(2026, 3, 4, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		spec  string
+		after time.Time
+		want  time.Time
+	}{
+		{"duration", "90s", base, base.Add(90 * time.Second)},
+		{"every", "@every 5m", base, base.Add(5 * time.Minute)},
+		{"every minute", "* * * * *", base, time.Date(2026, 3, 4, 10, 18, 0, 0, time.UTC)},
+		{"minute step", "*/15 * * * *", base, time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)},
+		{"exact minute is exclusive", "30 10 * * *", time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC), time.Date(2026, 3, 5, 10, 30, 0, 0, time.UTC)},
+		{"list", "0 8,20 * * *", base, time.Date(2026, 3, 4, 20, 0, 0, 0, time.UTC)},
+		{"hour range", "0 2-4 * * *", base, time.Date(2026, 3, 5, 2, 0, 0, 0, time.UTC)},
+		{"weekdays", "0 2 * * 1-5", time.Date(2026, 3, 6, 3, 0, 0, 0, time.UTC), time.Date(2026, 3, 9, 2, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", base, time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{"daily", "@daily", base, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"monthly rolls year", "@monthly", time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"day of month or weekday", "0 0 13 * 5", base, time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", base, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.spec, err)
+			}
+
+			if got := schedule.Next(tt.after); !got.Equal(tt.want) {
+				t.Fatalf("Next(%s) = %s, want %s", tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextUsesLocation(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	schedule, err := Parse("0 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC) // 19:00 WIB
+	want := time.Date(2026, 3, 5, 2, 0, 0, 0, jakarta)
+
+	if got := schedule.Next(after.In(jakarta)); !got.Equal(want) {
+		t.Fatalf("Next = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+)
+
+// AdvisoryLock adalah session-level advisory lock PostgreSQL yang dipegang
+// lewat satu koneksi khusus. Lock otomatis dilepas server jika koneksi
+// putus, sehingga replica lain bisa mengambil alih.
+type AdvisoryLock struct {
+	db  *sql.DB
+	key int64
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+func NewAdvisoryLock(db *sql.DB, key int64) *AdvisoryLock {
+	return &AdvisoryLock{db: db, key: key}
+}
+
+// TryAcquire mengembalikan true jika lock sedang dipegang instance ini.
+// Jika lock sudah dipegang, koneksinya dicek dulu; koneksi yang putus
+// dianggap kehilangan lock lalu dicoba ambil ulang.
+func (l *AdvisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		if err := l.conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		// Ping bisa gagal karena ctx, sementara session masih hidup dan
+		// memegang lock, jadi koneksinya dibuang dan tidak kembali ke pool.
+		discardConn(l.conn)
+		l.conn = nil
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error opening lock connection: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, l.key).Scan(&acquired); err != nil {
+		discardConn(conn)
+		return false, fmt.Errorf("error acquiring advisory lock: %w", err)
+	}
+
+	if !acquired {
+		conn.Close()
+		return false, nil
+	}
+
+	l.conn = conn
+	return true, nil
+}
+
+// Release melepas lock jika sedang dipegang.
+func (l *AdvisoryLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+
+	conn := l.conn
+	l.conn = nil
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, l.key); err != nil {
+		discardConn(conn)
+		return fmt.Errorf("error releasing advisory lock: %w", err)
+	}
+
+	return conn.Close()
+}
+
+// discardConn menutup koneksi fisik alih-alih mengembalikannya ke pool.
+// Session PostgreSQL ikut berakhir, sehingga advisory lock yang mungkin
+// masih dipegangnya dilepas server dan tidak terbawa ke query lain.
+func discardConn(conn *sql.Conn) {
+	conn.Raw(func(driverConn interface{}) error {
+		return driver.ErrBadConn
+	})
+	conn.Close()
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"time"
+
+	_ "github.com/lib/pq"
 )
 
 type Config struct {
@@ -14,6 +16,9 @@ type Config struct {
 	Password string
 	DBName   string
 	SSLMode  string
+
+	// QueryTimeout adalah batas waktu default untuk satu query.
+	QueryTimeout time.Duration
 }
 
 func MewPostgresDB(cfg Config) (*sql.DB, error) {
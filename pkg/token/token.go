@@ -0,0 +1,111 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// Claims adalah payload access token. Field mengikuti nama claim JWT
+// standar (sub, iat, exp, iss) ditambah role dan session ID.
+type Claims struct {
+	UserID    int    `json:"sub"`
+	Role      string `json:"role"`
+	SessionID int    `json:"sid"`
+	Issuer    string `json:"iss,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Manager membuat dan memverifikasi access token berformat JWT HS256.
+type Manager struct {
+	secret []byte
+	issuer string
+	ttl    time.Duration
+}
+
+func NewManager(secret, issuer string, ttl time.Duration) (*Manager, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("token secret must be at least 32 characters")
+	}
+
+	if ttl <= 0 {
+		return nil, fmt.Errorf("token ttl must be positive")
+	}
+
+	return &Manager{secret: []byte(secret), issuer: issuer, ttl: ttl}, nil
+}
+
+var encodedHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Generate membuat access token baru untuk user dan session tertentu.
+func (m *Manager) Generate(userID int, role string, sessionID int, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(m.ttl)
+
+	claims := Claims{
+		UserID:    userID,
+		Role:      role,
+		SessionID: sessionID,
+		Issuer:    m.issuer,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("error encoding claims: %w", err)
+	}
+
+	unsigned := encodedHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return unsigned + "." + m.sign(unsigned), expiresAt, nil
+}
+
+// Verify memeriksa signature dan masa berlaku token lalu mengembalikan claims.
+func (m *Manager) Verify(tokenString string, now time.Time) (*Claims, error) {
+	parts := strings.Split(tokenString, ".")
+	if len(parts) != 3 || parts[0] != encodedHeader {
+		return nil, ErrInvalidToken
+	}
+
+	expected := m.sign(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims := &Claims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if m.issuer != "" && claims.Issuer != m.issuer {
+		return nil, ErrInvalidToken
+	}
+
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	return claims, nil
+}
+
+func (m *Manager) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
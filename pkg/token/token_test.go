@@ -0,0 +1,93 @@
+package token
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func TestManagerVerify(t *testing.T) {
+	now := time.Date(2026, 3, 6, 10, 0, 0, 0, time.UTC)
+
+	manager, err := NewManager(testSecret, "futsal", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	valid, _, err := manager.Generate(7, "CUSTOMER", 3, now)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	otherSecret, _ := NewManager(strings.Repeat("x", 32), "futsal", 15*time.Minute)
+	otherIssuer, _ := NewManager(testSecret, "other", 15*time.Minute)
+
+	parts := strings.Split(valid, ".")
+
+	tests := []struct {
+		name    string
+		manager *Manager
+		token   string
+		now     time.Time
+		wantErr error
+	}{
+		{"valid", manager, valid, now, nil},
+		{"just before expiry", manager, valid, now.Add(15*time.Minute - time.Second), nil},
+		{"at expiry", manager, valid, now.Add(15 * time.Minute), ErrExpiredToken},
+		{"signed with other secret", otherSecret, valid, now, ErrInvalidToken},
+		{"other issuer", otherIssuer, valid, now, ErrInvalidToken},
+		{"tampered payload", manager, parts[0] + "." + parts[1] + "x." + parts[2], now, ErrInvalidToken},
+		{"tampered signature", manager, parts[0] + "." + parts[1] + "." + strings.ToUpper(parts[2]), now, ErrInvalidToken},
+		{"other header", manager, "e30." + parts[1] + "." + parts[2], now, ErrInvalidToken},
+		{"missing part", manager, parts[0] + "." + parts[1], now, ErrInvalidToken},
+		{"empty", manager, "", now, ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.manager.Verify(tt.token, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if claims.UserID != 7 || claims.Role != "CUSTOMER" || claims.SessionID != 3 || claims.Issuer != "futsal" {
+				t.Fatalf("claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestNewManagerValidation(t *testing.T) {
+	if _, err := NewManager("short", "futsal", time.Minute); err == nil {
+		t.Error("NewManager accepted a short secret")
+	}
+	if _, err := NewManager(testSecret, "futsal", 0); err == nil {
+		t.Error("NewManager accepted a zero ttl")
+	}
+}
+
+func TestHashRefreshToken(t *testing.T) {
+	a, err := NewRefreshToken()
+	if err != nil {
+		t.Fatalf("NewRefreshToken: %v", err)
+	}
+	b, err := NewRefreshToken()
+	if err != nil {
+		t.Fatalf("NewRefreshToken: %v", err)
+	}
+
+	if a == b {
+		t.Fatal("NewRefreshToken returned the same token twice")
+	}
+	if HashRefreshToken(a) != HashRefreshToken(a) {
+		t.Fatal("HashRefreshToken is not deterministic")
+	}
+	if HashRefreshToken(a) == HashRefreshToken(b) {
+		t.Fatal("different tokens hash to the same value")
+	}
+}